@@ -22,7 +22,7 @@ func (ai *arrayIterObject) next() Value {
 		return ai.val.runtime.createIterResultObject(_undefined, true)
 	}
 	if ta, ok := ai.obj.self.(*typedArrayObject); ok {
-		ta.viewedArrayBuf.ensureNotDetached(true)
+		ta.ensureNotOutOfBounds(true)
 	}
 	l := toLength(ai.obj.self.getStr("length", nil))
 	index := ai.nextIdx
@@ -369,7 +369,10 @@ func (a *arrayObject) expand(idx uint32) bool {
 					}
 				}
 				tl := int(targetLen)
-				newValues := make([]Value, tl, growCap(tl, len(a.values), cap(a.values)))
+				newCap := growCap(tl, len(a.values), cap(a.values))
+				a.val.runtime.checkAlloc(memoryArrays, int64(newCap-cap(a.values)), valueSize)
+				newValues := make([]Value, tl, newCap)
+				a.val.runtime.trackAlloc(memoryArrays, 0, int64(newCap-cap(a.values))*valueSize)
 				copy(newValues, a.values)
 				a.values = newValues
 			}
@@ -550,7 +553,9 @@ func (a *arrayObject) exportToArrayOrSlice(dst reflect.Value, typ reflect.Type,
 }
 
 func (a *arrayObject) setValuesFromSparse(items []sparseArrayItem, newMaxIdx int) {
+	a.val.runtime.checkAlloc(memoryArrays, int64(newMaxIdx+1), valueSize)
 	a.values = make([]Value, newMaxIdx+1)
+	a.val.runtime.trackAlloc(memoryArrays, 0, int64(newMaxIdx+1)*valueSize)
 	for _, item := range items {
 		a.values[item.idx] = item.value
 	}
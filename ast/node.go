@@ -300,6 +300,13 @@ type (
 		Meta, Property *Identifier
 		Idx            file.Idx
 	}
+
+	// ImportCall is a dynamic import, i.e. import(specifier).
+	ImportCall struct {
+		Import           file.Idx
+		Argument         Expression
+		RightParenthesis file.Idx
+	}
 )
 
 // _expressionNode
@@ -332,6 +339,7 @@ func (*ThisExpression) _expressionNode()        {}
 func (*SuperExpression) _expressionNode()       {}
 func (*UnaryExpression) _expressionNode()       {}
 func (*MetaProperty) _expressionNode()          {}
+func (*ImportCall) _expressionNode()            {}
 func (*ObjectPattern) _expressionNode()         {}
 func (*ArrayPattern) _expressionNode()          {}
 func (*Binding) _expressionNode()               {}
@@ -410,6 +418,7 @@ type (
 		Into   ForInto
 		Source Expression
 		Body   Statement
+		Await  bool // for await (... of ...)
 	}
 
 	ForStatement struct {
@@ -465,7 +474,8 @@ type (
 
 	LexicalDeclaration struct {
 		Idx   file.Idx
-		Token token.Token
+		Token token.Token // token.LET, token.CONST or token.USING
+		Await bool        // 'await using'
 		List  []*Binding
 	}
 
@@ -488,6 +498,63 @@ type (
 	ClassDeclaration struct {
 		Class *ClassLiteral
 	}
+
+	// ImportDeclaration is an import declaration of a module:
+	//
+	//	import "m"
+	//	import x, * as ns from "m"
+	//	import x, {a, b as c} from "m"
+	ImportDeclaration struct {
+		Import          file.Idx
+		Default         *Identifier // nil if there is no default import
+		Namespace       *Identifier // nil if there is no namespace import
+		Specifiers      []*ImportSpecifier
+		ModuleSpecifier *StringLiteral
+	}
+
+	// ExportDeclaration is an export declaration of a module. Exactly one of Declaration, Expression,
+	// Specifiers (which may be empty) and Star describes what's exported:
+	//
+	//	export var a, b            // Declaration
+	//	export default function() {}  // Declaration, Default is set and the function or class may be anonymous
+	//	export default a + b       // Expression
+	//	export {a, b as c}         // Specifiers
+	//	export {a, b as c} from "m"
+	//	export * from "m"          // Star
+	//	export * as ns from "m"    // Star with a Namespace
+	ExportDeclaration struct {
+		Export          file.Idx
+		Declaration     Statement
+		Default         bool
+		Expression      Expression
+		Specifiers      []*ExportSpecifier
+		Star            bool
+		Namespace       *ModuleExportName
+		ModuleSpecifier *StringLiteral
+		RightBrace      file.Idx
+	}
+)
+
+type (
+	// ModuleExportName is the name of an imported or exported binding, an identifier name or a string
+	// literal.
+	ModuleExportName struct {
+		Idx     file.Idx
+		Literal string
+		Name    unistring.String
+	}
+
+	ImportSpecifier struct {
+		ImportName *ModuleExportName
+		Local      *Identifier
+	}
+
+	// ExportSpecifier exports Local as Exported, they are the same for "export {a}". Local is an
+	// identifier unless the binding is re-exported from another module.
+	ExportSpecifier struct {
+		Local    *ModuleExportName
+		Exported *ModuleExportName
+	}
 )
 
 // _statementNode
@@ -516,6 +583,8 @@ func (*WithStatement) _statementNode()       {}
 func (*LexicalDeclaration) _statementNode()  {}
 func (*FunctionDeclaration) _statementNode() {}
 func (*ClassDeclaration) _statementNode()    {}
+func (*ImportDeclaration) _statementNode()   {}
+func (*ExportDeclaration) _statementNode()   {}
 
 // =========== //
 // Declaration //
@@ -588,6 +657,8 @@ type (
 	ForDeclaration struct {
 		Idx     file.Idx
 		IsConst bool
+		Using   bool // 'using' or 'await using' (IsConst is also set)
+		Await   bool // 'await using'
 		Target  BindingTarget
 	}
 
@@ -678,6 +749,7 @@ func (self *UnaryExpression) Idx0() file.Idx {
 	return self.Idx
 }
 func (self *MetaProperty) Idx0() file.Idx { return self.Idx }
+func (self *ImportCall) Idx0() file.Idx   { return self.Import }
 
 func (self *BadStatement) Idx0() file.Idx        { return self.From }
 func (self *BlockStatement) Idx0() file.Idx      { return self.LeftBrace }
@@ -704,8 +776,14 @@ func (self *WithStatement) Idx0() file.Idx       { return self.With }
 func (self *LexicalDeclaration) Idx0() file.Idx  { return self.Idx }
 func (self *FunctionDeclaration) Idx0() file.Idx { return self.Function.Idx0() }
 func (self *ClassDeclaration) Idx0() file.Idx    { return self.Class.Idx0() }
+func (self *ImportDeclaration) Idx0() file.Idx   { return self.Import }
+func (self *ExportDeclaration) Idx0() file.Idx   { return self.Export }
 func (self *Binding) Idx0() file.Idx             { return self.Target.Idx0() }
 
+func (self *ModuleExportName) Idx0() file.Idx { return self.Idx }
+func (self *ImportSpecifier) Idx0() file.Idx  { return self.ImportName.Idx0() }
+func (self *ExportSpecifier) Idx0() file.Idx  { return self.Local.Idx0() }
+
 func (self *ForLoopInitializerExpression) Idx0() file.Idx  { return self.Expression.Idx0() }
 func (self *ForLoopInitializerVarDeclList) Idx0() file.Idx { return self.List[0].Idx0() }
 func (self *ForLoopInitializerLexicalDecl) Idx0() file.Idx { return self.LexicalDeclaration.Idx0() }
@@ -770,6 +848,7 @@ func (self *UnaryExpression) Idx1() file.Idx {
 func (self *MetaProperty) Idx1() file.Idx {
 	return self.Property.Idx1()
 }
+func (self *ImportCall) Idx1() file.Idx { return self.RightParenthesis + 1 }
 
 func (self *BadStatement) Idx1() file.Idx   { return self.To }
 func (self *BlockStatement) Idx1() file.Idx { return self.RightBrace + 1 }
@@ -819,6 +898,24 @@ func (self *WithStatement) Idx1() file.Idx       { return self.Body.Idx1() }
 func (self *LexicalDeclaration) Idx1() file.Idx  { return self.List[len(self.List)-1].Idx1() }
 func (self *FunctionDeclaration) Idx1() file.Idx { return self.Function.Idx1() }
 func (self *ClassDeclaration) Idx1() file.Idx    { return self.Class.Idx1() }
+func (self *ImportDeclaration) Idx1() file.Idx   { return self.ModuleSpecifier.Idx1() }
+func (self *ExportDeclaration) Idx1() file.Idx {
+	switch {
+	case self.ModuleSpecifier != nil:
+		return self.ModuleSpecifier.Idx1()
+	case self.Declaration != nil:
+		return self.Declaration.Idx1()
+	case self.Expression != nil:
+		return self.Expression.Idx1()
+	}
+	return self.RightBrace + 1
+}
+
+func (self *ModuleExportName) Idx1() file.Idx {
+	return file.Idx(int(self.Idx) + len(self.Literal))
+}
+func (self *ImportSpecifier) Idx1() file.Idx { return self.Local.Idx1() }
+func (self *ExportSpecifier) Idx1() file.Idx { return self.Exported.Idx1() }
 func (self *Binding) Idx1() file.Idx {
 	if self.Initializer != nil {
 		return self.Initializer.Idx1()
@@ -24,6 +24,9 @@ func (r *Runtime) newArrayObject() *arrayObject {
 }
 
 func setArrayValues(a *arrayObject, values []Value) *arrayObject {
+	if r := a.val.runtime; r != nil {
+		r.trackAlloc(memoryArrays, 0, int64(cap(values))*valueSize)
+	}
 	a.values = values
 	a.length = uint32(len(values))
 	a.objCount = len(values)
@@ -190,21 +193,29 @@ func (r *Runtime) arrayproto_join(call FunctionCall) Value {
 	}
 
 	var buf StringBuilder
+	size := stringSizeCheck{r: r}
 
 	element0 := o.self.getIdx(valueInt(0), nil)
 	if element0 != nil && element0 != _undefined && element0 != _null {
-		buf.WriteString(element0.toString())
+		s := element0.toString()
+		size.add(s)
+		buf.WriteString(s)
 	}
 
 	for i := 1; i < l; i++ {
+		size.add(sep)
 		buf.WriteString(sep)
 		element := o.self.getIdx(valueInt(int64(i)), nil)
 		if element != nil && element != _undefined && element != _null {
-			buf.WriteString(element.toString())
+			s := element.toString()
+			size.add(s)
+			buf.WriteString(s)
 		}
 	}
 
-	return buf.String()
+	str := buf.String()
+	r.trackString(str)
+	return str
 }
 
 func (r *Runtime) arrayproto_toString(call FunctionCall) Value {
@@ -379,6 +390,7 @@ func (r *Runtime) arrayproto_sort(call FunctionCall) Value {
 		sort.Stable(&ctx)
 	} else {
 		length := toLength(o.self.getStr("length", nil))
+		r.checkAlloc(memoryArrays, length, valueSize)
 		a := make([]Value, 0, length)
 		for i := int64(0); i < length; i++ {
 			idx := valueInt(i)
@@ -446,6 +458,7 @@ func (r *Runtime) arrayproto_splice(call FunctionCall) Value {
 				values = src.values[:newLength]
 				copy(values[actualStart+itemCount:], values[actualStart+actualDeleteCount:length])
 			} else {
+				r.checkAlloc(memoryArrays, newLength, valueSize)
 				values = make([]Value, newLength)
 				copy(values, src.values[:actualStart])
 				copy(values[actualStart+itemCount:], src.values[actualStart+actualDeleteCount:])
@@ -519,6 +532,7 @@ func (r *Runtime) arrayproto_unshift(call FunctionCall) Value {
 				arr.values = arr.values[:newSize]
 				copy(arr.values[argCount:], arr.values[:length])
 			} else {
+				r.checkAlloc(memoryArrays, newSize, valueSize)
 				values := make([]Value, newSize)
 				copy(values[argCount:], arr.values)
 				arr.values = values
@@ -1241,6 +1255,7 @@ func (r *Runtime) arrayproto_with(call FunctionCall) Value {
 	}
 
 	if src := r.checkStdArrayObj(o); src != nil {
+		r.checkAlloc(memoryArrays, length, valueSize)
 		a := make([]Value, 0, length)
 		for k := int64(0); k < length; k++ {
 			pk := valueInt(k)
@@ -1274,6 +1289,7 @@ func (r *Runtime) arrayproto_toReversed(call FunctionCall) Value {
 	length := toLength(o.self.getStr("length", nil))
 
 	if src := r.checkStdArrayObj(o); src != nil {
+		r.checkAlloc(memoryArrays, length, valueSize)
 		a := make([]Value, 0, length)
 		for k := int64(0); k < length; k++ {
 			from := valueInt(length - k - 1)
@@ -1310,6 +1326,7 @@ func (r *Runtime) arrayproto_toSorted(call FunctionCall) Value {
 	if length >= math.MaxUint32 {
 		panic(r.newError(r.getRangeError(), "Invalid array length"))
 	}
+	r.checkAlloc(memoryArrays, length, valueSize)
 	var a []Value
 
 	if src := r.checkStdArrayObj(o); src != nil {
@@ -1355,6 +1372,7 @@ func (r *Runtime) arrayproto_toSpliced(call FunctionCall) Value {
 			values = make([]Value, len(src.values))
 			copy(values, src.values)
 		} else {
+			r.checkAlloc(memoryArrays, newLength, valueSize)
 			values = make([]Value, newLength)
 			copy(values, src.values[:actualStart])
 			copy(values[actualStart+itemCount:], src.values[actualStart+actualSkipCount:])
@@ -1506,6 +1524,7 @@ func (r *Runtime) array_from(call FunctionCall) Value {
 		}
 		if mapFn == nil {
 			if a := r.checkStdArrayObjWithProto(arr); a != nil {
+				r.checkAlloc(memoryArrays, l, valueSize)
 				values := make([]Value, l)
 				for k := int64(0); k < l; k++ {
 					values[k] = nilSafe(arrayLike.self.getIdx(valueInt(k), nil))
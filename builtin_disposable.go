@@ -0,0 +1,481 @@
+package goja
+
+// Disposable can be implemented by the Go values exposed to JavaScript through the reflection bridge (see
+// ToValue) to make them usable with the 'using' declarations and the DisposableStack. Dispose becomes the
+// object's [Symbol.dispose]() method, a non-nil error is thrown as a GoError.
+type Disposable interface {
+	Dispose() error
+}
+
+type disposableResource struct {
+	value  Value
+	method func(FunctionCall) Value // nil for a null or undefined value of an 'await using' declaration
+	async  bool
+}
+
+// disposeCapability holds the resources of a DisposableStack, an AsyncDisposableStack or a block containing
+// 'using' declarations, in the order they were added.
+type disposeCapability struct {
+	resources []disposableResource
+}
+
+// disposableStackObject is a DisposableStack or an AsyncDisposableStack. The dispose capabilities of the
+// blocks containing 'using' declarations are held by such objects too (with no prototype), in hidden bindings.
+type disposableStackObject struct {
+	baseObject
+	capability disposeCapability
+	async      bool
+	disposed   bool
+}
+
+func (r *Runtime) newDisposableStackObject(proto *Object, async bool) *disposableStackObject {
+	o := &Object{runtime: r}
+	ds := &disposableStackObject{
+		baseObject: baseObject{
+			class:      classObject,
+			val:        o,
+			extensible: true,
+			prototype:  proto,
+		},
+		async: async,
+	}
+	o.self = ds
+	ds.init()
+	return ds
+}
+
+// getDisposeMethod implements GetDisposeMethod().
+func (r *Runtime) getDisposeMethod(v *Object, async bool) func(FunctionCall) Value {
+	if async {
+		if method := toMethod(v.self.getSym(SymAsyncDispose, nil)); method != nil {
+			return method
+		}
+		if method := toMethod(v.self.getSym(SymDispose, nil)); method != nil {
+			return func(call FunctionCall) Value {
+				promiseCap := r.newPromiseCapability(r.getPromise())
+				if promiseCap.try(func() {
+					method(call)
+				}) {
+					promiseCap.resolve(_undefined)
+				}
+				return promiseCap.promise
+			}
+		}
+		return nil
+	}
+	return toMethod(v.self.getSym(SymDispose, nil))
+}
+
+// add implements AddDisposableResource(). If method is nil, the dispose method of v is used.
+func (dc *disposeCapability) add(r *Runtime, v Value, async bool, method func(FunctionCall) Value) {
+	if method == nil {
+		if v == _undefined || v == _null {
+			if !async {
+				return
+			}
+		} else {
+			obj, ok := v.(*Object)
+			if !ok {
+				panic(r.NewTypeError("%s is not an object", v.String()))
+			}
+			method = r.getDisposeMethod(obj, async)
+			if method == nil {
+				panic(r.NewTypeError("%s is not disposable", r.objectproto_toString(FunctionCall{This: obj})))
+			}
+		}
+	}
+	dc.resources = append(dc.resources, disposableResource{
+		value:  v,
+		method: method,
+		async:  async,
+	})
+}
+
+// suppressError returns the exception resulting from ex being thrown while the resources are disposed
+// because of completion (nil if it's a normal completion).
+func (r *Runtime) suppressError(ex, completion *Exception) *Exception {
+	if completion == nil {
+		return ex
+	}
+	return r.vm.exceptionFromValue(r.newSuppressedError(ex.val, completion.val))
+}
+
+// dispose implements DisposeResources() for the capabilities that only hold synchronously disposable
+// resources. completion is the exception thrown out of the block (nil if it's a normal completion), the
+// result is the exception to throw once the resources are disposed (nil if none).
+func (dc *disposeCapability) dispose(r *Runtime, completion *Exception) *Exception {
+	resources := dc.resources
+	dc.resources = nil
+	for i := len(resources) - 1; i >= 0; i-- {
+		res := resources[i]
+		if ex := r.vm.try(func() {
+			res.method(FunctionCall{This: res.value})
+		}); ex != nil {
+			completion = r.suppressError(ex, completion)
+		}
+	}
+	return completion
+}
+
+// disposeAsync implements DisposeResources() for the capabilities that may hold asynchronously disposable
+// resources. The returned promise is rejected if disposing of the resources results in an exception other
+// than completion, otherwise it's fulfilled with undefined.
+func (dc *disposeCapability) disposeAsync(r *Runtime, completion *Exception) *Object {
+	resources := dc.resources
+	dc.resources = nil
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	initial := completion
+	needsAwait, hasAwaited := false, false
+
+	var step func(i int)
+	awaitValue := func(v Value, next int) {
+		var p *Object
+		if ex := r.vm.try(func() {
+			p = r.promiseResolve(r.getPromise(), v)
+		}); ex != nil {
+			completion = r.suppressError(ex, completion)
+			step(next)
+			return
+		}
+		r.performPromiseThen(p.self.(*Promise), r.newNativeFunc(func(FunctionCall) Value {
+			step(next)
+			return _undefined
+		}, "", 1), r.newNativeFunc(func(call FunctionCall) Value {
+			completion = r.suppressError(&Exception{val: call.Argument(0)}, completion)
+			step(next)
+			return _undefined
+		}, "", 1), nil)
+	}
+
+	step = func(i int) {
+		for ; i >= 0; i-- {
+			res := resources[i]
+			if !res.async && needsAwait && !hasAwaited {
+				needsAwait = false
+				awaitValue(_undefined, i)
+				return
+			}
+			if res.method == nil {
+				needsAwait = true
+				continue
+			}
+			var result Value
+			if ex := r.vm.try(func() {
+				result = res.method(FunctionCall{This: res.value})
+			}); ex != nil {
+				completion = r.suppressError(ex, completion)
+				continue
+			}
+			if res.async {
+				hasAwaited = true
+				awaitValue(result, i-1)
+				return
+			}
+		}
+		if needsAwait && !hasAwaited {
+			needsAwait = false
+			awaitValue(_undefined, -1)
+			return
+		}
+		if completion != initial {
+			promiseCap.reject(completion.val)
+		} else {
+			promiseCap.resolve(_undefined)
+		}
+	}
+
+	step(len(resources) - 1)
+	return promiseCap.promise
+}
+
+func (r *Runtime) newSuppressedError(err, suppressed Value) *Object {
+	return r.builtin_new(r.getSuppressedError(), []Value{err, suppressed})
+}
+
+func (r *Runtime) builtin_SuppressedError(args []Value, proto *Object) *Object {
+	obj := r.newErrorObject(proto, classError)
+	if len(args) > 2 && args[2] != _undefined {
+		obj._putProp("message", args[2].toString(), true, false, true)
+	}
+	var err, suppressed Value = _undefined, _undefined
+	if len(args) > 0 {
+		err = args[0]
+	}
+	if len(args) > 1 {
+		suppressed = args[1]
+	}
+	obj._putProp("error", err, true, false, true)
+	obj._putProp("suppressed", suppressed, true, false, true)
+	return obj.val
+}
+
+func (r *Runtime) getSuppressedError() *Object {
+	ret := r.global.SuppressedError
+	if ret == nil {
+		ret = &Object{runtime: r}
+		r.global.SuppressedError = ret
+		r.newNativeFuncConstructProto(ret, r.builtin_SuppressedError, "SuppressedError", r.createErrorPrototype(stringSuppressedError, ret), r.getError(), 3)
+	}
+	return ret
+}
+
+func (r *Runtime) toDisposableStackObject(v Value, method string, async bool) *disposableStackObject {
+	thisObj := r.toObject(v)
+	if ds, ok := thisObj.self.(*disposableStackObject); ok && ds.async == async {
+		return ds
+	}
+	ctor := "DisposableStack"
+	if async {
+		ctor = "AsyncDisposableStack"
+	}
+	panic(r.NewTypeError("Method %s.prototype.%s called on incompatible receiver %s", ctor, method, r.objectproto_toString(FunctionCall{This: thisObj})))
+}
+
+func (ds *disposableStackObject) checkNotDisposed() {
+	if ds.disposed {
+		r := ds.val.runtime
+		if ds.async {
+			panic(r.newError(r.getReferenceError(), "AsyncDisposableStack already disposed"))
+		}
+		panic(r.newError(r.getReferenceError(), "DisposableStack already disposed"))
+	}
+}
+
+// adopt implements the common part of DisposableStack.prototype.adopt() and AsyncDisposableStack.prototype.adopt().
+func (ds *disposableStackObject) adopt(call FunctionCall) Value {
+	r := ds.val.runtime
+	ds.checkNotDisposed()
+	value := call.Argument(0)
+	onDispose, ok := assertCallable(call.Argument(1))
+	if !ok {
+		panic(r.NewTypeError("%s is not a function", call.Argument(1).String()))
+	}
+	ds.capability.add(r, _undefined, ds.async, func(FunctionCall) Value {
+		return onDispose(FunctionCall{This: _undefined, Arguments: []Value{value}})
+	})
+	return value
+}
+
+func (ds *disposableStackObject) deferFunc(call FunctionCall) Value {
+	r := ds.val.runtime
+	ds.checkNotDisposed()
+	onDispose, ok := assertCallable(call.Argument(0))
+	if !ok {
+		panic(r.NewTypeError("%s is not a function", call.Argument(0).String()))
+	}
+	ds.capability.add(r, _undefined, ds.async, onDispose)
+	return _undefined
+}
+
+func (ds *disposableStackObject) move(proto *Object) Value {
+	r := ds.val.runtime
+	ds.checkNotDisposed()
+	newStack := r.newDisposableStackObject(proto, ds.async)
+	newStack.capability = ds.capability
+	ds.capability = disposeCapability{}
+	ds.disposed = true
+	return newStack.val
+}
+
+func (ds *disposableStackObject) use(call FunctionCall) Value {
+	ds.checkNotDisposed()
+	value := call.Argument(0)
+	ds.capability.add(ds.val.runtime, value, ds.async, nil)
+	return value
+}
+
+func (r *Runtime) builtin_newDisposableStack(args []Value, newTarget *Object) *Object {
+	if newTarget == nil {
+		panic(r.needNew("DisposableStack"))
+	}
+	proto := r.getPrototypeFromCtor(newTarget, r.getDisposableStack(), r.getDisposableStackPrototype())
+	return r.newDisposableStackObject(proto, false).val
+}
+
+func (r *Runtime) disposableStackProto_adopt(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "adopt", false).adopt(call)
+}
+
+func (r *Runtime) disposableStackProto_defer(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "defer", false).deferFunc(call)
+}
+
+func (r *Runtime) disposableStackProto_dispose(call FunctionCall) Value {
+	ds := r.toDisposableStackObject(call.This, "dispose", false)
+	if ds.disposed {
+		return _undefined
+	}
+	ds.disposed = true
+	if ex := ds.capability.dispose(r, nil); ex != nil {
+		panic(ex)
+	}
+	return _undefined
+}
+
+func (r *Runtime) disposableStackProto_getDisposed(call FunctionCall) Value {
+	return r.toBoolean(r.toDisposableStackObject(call.This, "disposed", false).disposed)
+}
+
+func (r *Runtime) disposableStackProto_move(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "move", false).move(r.getDisposableStackPrototype())
+}
+
+func (r *Runtime) disposableStackProto_use(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "use", false).use(call)
+}
+
+func (r *Runtime) createDisposableStackProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.global.ObjectPrototype, classObject)
+
+	o._putProp("constructor", r.getDisposableStack(), true, false, true)
+	o._putProp("adopt", r.newNativeFunc(r.disposableStackProto_adopt, "adopt", 2), true, false, true)
+	o._putProp("defer", r.newNativeFunc(r.disposableStackProto_defer, "defer", 1), true, false, true)
+	disposeFunc := r.newNativeFunc(r.disposableStackProto_dispose, "dispose", 0)
+	o._putProp("dispose", disposeFunc, true, false, true)
+	o.setOwnStr("disposed", &valueProperty{
+		getterFunc:   r.newNativeFunc(r.disposableStackProto_getDisposed, "get disposed", 0),
+		accessor:     true,
+		configurable: true,
+	}, true)
+	o._putProp("move", r.newNativeFunc(r.disposableStackProto_move, "move", 0), true, false, true)
+	o._putProp("use", r.newNativeFunc(r.disposableStackProto_use, "use", 1), true, false, true)
+	o._putSym(SymDispose, valueProp(disposeFunc, true, false, true))
+	o._putSym(SymToStringTag, valueProp(asciiString(classDisposableStack), false, false, true))
+
+	return o
+}
+
+func (r *Runtime) getDisposableStackPrototype() *Object {
+	ret := r.global.DisposableStackPrototype
+	if ret == nil {
+		ret = &Object{runtime: r}
+		r.global.DisposableStackPrototype = ret
+		ret.self = r.createDisposableStackProto(ret)
+	}
+	return ret
+}
+
+func (r *Runtime) getDisposableStack() *Object {
+	ret := r.global.DisposableStack
+	if ret == nil {
+		ret = &Object{runtime: r}
+		r.global.DisposableStack = ret
+		ret.self = r.newNativeConstructOnly(ret, r.builtin_newDisposableStack, r.getDisposableStackPrototype(), "DisposableStack", 0)
+	}
+	return ret
+}
+
+func (r *Runtime) builtin_newAsyncDisposableStack(args []Value, newTarget *Object) *Object {
+	if newTarget == nil {
+		panic(r.needNew("AsyncDisposableStack"))
+	}
+	proto := r.getPrototypeFromCtor(newTarget, r.getAsyncDisposableStack(), r.getAsyncDisposableStackPrototype())
+	return r.newDisposableStackObject(proto, true).val
+}
+
+func (r *Runtime) asyncDisposableStackProto_adopt(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "adopt", true).adopt(call)
+}
+
+func (r *Runtime) asyncDisposableStackProto_defer(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "defer", true).deferFunc(call)
+}
+
+func (r *Runtime) asyncDisposableStackProto_disposeAsync(call FunctionCall) Value {
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	var ds *disposableStackObject
+	if !promiseCap.try(func() {
+		ds = r.toDisposableStackObject(call.This, "disposeAsync", true)
+	}) {
+		return promiseCap.promise
+	}
+	if ds.disposed {
+		promiseCap.resolve(_undefined)
+		return promiseCap.promise
+	}
+	ds.disposed = true
+	promiseCap.resolve(ds.capability.disposeAsync(r, nil))
+	return promiseCap.promise
+}
+
+func (r *Runtime) asyncDisposableStackProto_getDisposed(call FunctionCall) Value {
+	return r.toBoolean(r.toDisposableStackObject(call.This, "disposed", true).disposed)
+}
+
+func (r *Runtime) asyncDisposableStackProto_move(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "move", true).move(r.getAsyncDisposableStackPrototype())
+}
+
+func (r *Runtime) asyncDisposableStackProto_use(call FunctionCall) Value {
+	return r.toDisposableStackObject(call.This, "use", true).use(call)
+}
+
+func (r *Runtime) createAsyncDisposableStackProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.global.ObjectPrototype, classObject)
+
+	o._putProp("constructor", r.getAsyncDisposableStack(), true, false, true)
+	o._putProp("adopt", r.newNativeFunc(r.asyncDisposableStackProto_adopt, "adopt", 2), true, false, true)
+	o._putProp("defer", r.newNativeFunc(r.asyncDisposableStackProto_defer, "defer", 1), true, false, true)
+	disposeAsyncFunc := r.newNativeFunc(r.asyncDisposableStackProto_disposeAsync, "disposeAsync", 0)
+	o._putProp("disposeAsync", disposeAsyncFunc, true, false, true)
+	o.setOwnStr("disposed", &valueProperty{
+		getterFunc:   r.newNativeFunc(r.asyncDisposableStackProto_getDisposed, "get disposed", 0),
+		accessor:     true,
+		configurable: true,
+	}, true)
+	o._putProp("move", r.newNativeFunc(r.asyncDisposableStackProto_move, "move", 0), true, false, true)
+	o._putProp("use", r.newNativeFunc(r.asyncDisposableStackProto_use, "use", 1), true, false, true)
+	o._putSym(SymAsyncDispose, valueProp(disposeAsyncFunc, true, false, true))
+	o._putSym(SymToStringTag, valueProp(asciiString(classAsyncDisposableStack), false, false, true))
+
+	return o
+}
+
+func (r *Runtime) getAsyncDisposableStackPrototype() *Object {
+	ret := r.global.AsyncDisposableStackPrototype
+	if ret == nil {
+		ret = &Object{runtime: r}
+		r.global.AsyncDisposableStackPrototype = ret
+		ret.self = r.createAsyncDisposableStackProto(ret)
+	}
+	return ret
+}
+
+func (r *Runtime) getAsyncDisposableStack() *Object {
+	ret := r.global.AsyncDisposableStack
+	if ret == nil {
+		ret = &Object{runtime: r}
+		r.global.AsyncDisposableStack = ret
+		ret.self = r.newNativeConstructOnly(ret, r.builtin_newAsyncDisposableStack, r.getAsyncDisposableStackPrototype(), "AsyncDisposableStack", 0)
+	}
+	return ret
+}
+
+func (r *Runtime) iteratorProto_dispose(call FunctionCall) Value {
+	o := r.toObject(call.This)
+	if ret := toMethod(o.self.getStr("return", nil)); ret != nil {
+		ret(FunctionCall{This: o})
+	}
+	return _undefined
+}
+
+func (r *Runtime) asyncIteratorProto_asyncDispose(call FunctionCall) Value {
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	var resultWrapper *Object
+	if !promiseCap.try(func() {
+		o := r.toObject(call.This)
+		if ret := toMethod(o.self.getStr("return", nil)); ret != nil {
+			resultWrapper = r.promiseResolve(r.getPromise(), ret(FunctionCall{This: o, Arguments: []Value{_undefined}}))
+		}
+	}) {
+		return promiseCap.promise
+	}
+	if resultWrapper == nil {
+		promiseCap.resolve(_undefined)
+		return promiseCap.promise
+	}
+	unwrap := r.newNativeFunc(func(FunctionCall) Value {
+		return _undefined
+	}, "", 1)
+	return r.performPromiseThen(resultWrapper.self.(*Promise), unwrap, _undefined, promiseCap)
+}
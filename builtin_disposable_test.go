@@ -0,0 +1,206 @@
+package goja
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDisposableStack(t *testing.T) {
+	const SCRIPT = `
+	const log = [];
+	const stack = new DisposableStack();
+	assert.sameValue(Object.prototype.toString.call(stack), "[object DisposableStack]");
+	assert.sameValue(stack.disposed, false);
+
+	const res = {
+		[Symbol.dispose]() {
+			log.push("use");
+		}
+	};
+	assert.sameValue(stack.use(res), res);
+	assert.sameValue(stack.use(null), null);
+	assert.sameValue(stack.adopt(42, function(v) {
+		log.push("adopt " + v);
+	}), 42);
+	assert.sameValue(stack.defer(function() {
+		log.push("defer");
+	}), undefined);
+
+	assert.throws(TypeError, function() {
+		stack.use({});
+	});
+	assert.throws(TypeError, function() {
+		stack.defer(1);
+	});
+
+	const moved = stack.move();
+	assert.sameValue(stack.disposed, true);
+	assert.sameValue(Object.getPrototypeOf(moved), DisposableStack.prototype);
+	assert.throws(ReferenceError, function() {
+		stack.use(res);
+	});
+	assert.sameValue(stack.dispose(), undefined, "dispose() on a disposed stack");
+	assert.sameValue(log.length, 0);
+
+	assert.sameValue(moved[Symbol.dispose], moved.dispose);
+	moved.dispose();
+	assert(compareArray(log, ["defer", "adopt 42", "use"]), "order");
+	assert.sameValue(moved.disposed, true);
+	moved.dispose();
+	assert.sameValue(log.length, 3);
+
+	const failing = new DisposableStack();
+	failing.defer(function() {
+		throw new Error("first");
+	});
+	failing.defer(function() {
+		throw new Error("second");
+	});
+	try {
+		failing.dispose();
+		throw new Test262Error("dispose() should throw");
+	} catch (e) {
+		assert(e instanceof SuppressedError, "SuppressedError");
+		assert.sameValue(e.error.message, "first");
+		assert.sameValue(e.suppressed.message, "second");
+	}
+
+	assert.throws(TypeError, function() {
+		DisposableStack();
+	});
+	assert.throws(TypeError, function() {
+		DisposableStack.prototype.dispose.call({});
+	});
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestAsyncDisposableStack(t *testing.T) {
+	const SCRIPT = `
+	const log = [];
+	const stack = new AsyncDisposableStack();
+	assert.sameValue(Object.prototype.toString.call(stack), "[object AsyncDisposableStack]");
+	stack.use({
+		async [Symbol.asyncDispose]() {
+			await null;
+			log.push("async");
+		}
+	});
+	stack.use({
+		[Symbol.dispose]() {
+			log.push("sync");
+		}
+	});
+	stack.defer(async function() {
+		log.push("defer");
+	});
+	const p = stack.disposeAsync();
+	assert(p instanceof Promise, "promise");
+	assert.sameValue(stack.disposed, true);
+	await p;
+	assert(compareArray(log, ["defer", "sync", "async"]), "order");
+	assert.sameValue(stack[Symbol.asyncDispose], stack.disposeAsync);
+
+	const failing = new AsyncDisposableStack();
+	failing.defer(function() {
+		return Promise.reject(new Error("rejected"));
+	});
+	let err;
+	try {
+		await failing.disposeAsync();
+	} catch (e) {
+		err = e;
+	}
+	assert.sameValue(err.message, "rejected");
+
+	err = undefined;
+	try {
+		await AsyncDisposableStack.prototype.disposeAsync.call(new DisposableStack());
+	} catch (e) {
+		err = e;
+	}
+	assert(err instanceof TypeError, "wrong receiver rejects");
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestSuppressedError(t *testing.T) {
+	const SCRIPT = `
+	const e = new SuppressedError("err", "suppressed", "msg");
+	assert(e instanceof Error, "instanceof Error");
+	assert.sameValue(Object.getPrototypeOf(SuppressedError), Error);
+	assert.sameValue(SuppressedError.length, 3);
+	assert.sameValue(e.name, "SuppressedError");
+	assert.sameValue(e.message, "msg");
+	assert.sameValue(e.error, "err");
+	assert.sameValue(e.suppressed, "suppressed");
+	assert.sameValue(SuppressedError().hasOwnProperty("message"), false);
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestIteratorDispose(t *testing.T) {
+	const SCRIPT = `
+	let closed = false;
+	const iter = {
+		__proto__: Iterator.prototype,
+		next() {
+			return { done: false };
+		},
+		return() {
+			closed = true;
+			return {};
+		}
+	};
+	{
+		using it = iter;
+	}
+	assert(closed, "return() is called");
+	assert.sameValue(typeof (async function*() {})()[Symbol.asyncDispose], "function");
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+type testDisposable struct {
+	disposed int
+	err      error
+}
+
+func (d *testDisposable) Dispose() error {
+	d.disposed++
+	return d.err
+}
+
+func TestGoDisposable(t *testing.T) {
+	vm := New()
+	d := &testDisposable{}
+	vm.Set("d", d)
+	_, err := vm.RunString(`
+	{
+		using x = d;
+	}
+	const stack = new DisposableStack();
+	stack.use(d);
+	stack.dispose();
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.disposed != 2 {
+		t.Fatalf("disposed: %d", d.disposed)
+	}
+
+	d.err = errors.New("dispose failed")
+	_, err = vm.RunString(`
+	{
+		using x = d;
+	}
+	`)
+	var ex *Exception
+	if !errors.As(err, &ex) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !errors.Is(ex, d.err) {
+		t.Fatalf("Unexpected error: %v", ex)
+	}
+}
@@ -52,6 +52,10 @@ func (r *Runtime) builtin_generatorFunction(args []Value, proto *Object) *Object
 	return r.functionCtor(args, proto, false, true)
 }
 
+func (r *Runtime) builtin_asyncGeneratorFunction(args []Value, proto *Object) *Object {
+	return r.functionCtor(args, proto, true, true)
+}
+
 func (r *Runtime) functionproto_toString(call FunctionCall) Value {
 	obj := r.toObject(call.This)
 	switch f := obj.self.(type) {
@@ -307,6 +311,7 @@ func (r *Runtime) getAsyncFunctionPrototype() *Object {
 
 func (r *Runtime) createAsyncFunction(val *Object) objectImpl {
 	o := r.newNativeFuncConstructObj(val, r.builtin_asyncFunction, "AsyncFunction", r.getAsyncFunctionPrototype(), 1)
+	o.prototype = r.getFunction()
 
 	return o
 }
@@ -370,6 +375,7 @@ func (r *Runtime) getGeneratorFunctionPrototype() *Object {
 
 func (r *Runtime) createGeneratorFunction(val *Object) objectImpl {
 	o := r.newNativeFuncConstructObj(val, r.builtin_generatorFunction, "GeneratorFunction", r.getGeneratorFunctionPrototype(), 1)
+	o.prototype = r.getFunction()
 	return o
 }
 
@@ -406,6 +412,88 @@ func (r *Runtime) getGeneratorPrototype() *Object {
 	return o
 }
 
+func (r *Runtime) asyncGenproto_enqueue(call FunctionCall, typ completionType, name string) Value {
+	if o, ok := call.This.(*Object); ok {
+		if gen, ok := o.self.(*asyncGeneratorObject); ok {
+			return gen.enqueue(typ, call.Argument(0))
+		}
+	}
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	promiseCap.reject(r.NewTypeError("Method [AsyncGenerator].prototype.%s called on incompatible receiver", name))
+	return promiseCap.promise
+}
+
+func (r *Runtime) builtin_asyncGenproto_next(call FunctionCall) Value {
+	return r.asyncGenproto_enqueue(call, completionNormal, "next")
+}
+
+func (r *Runtime) builtin_asyncGenproto_return(call FunctionCall) Value {
+	return r.asyncGenproto_enqueue(call, completionReturn, "return")
+}
+
+func (r *Runtime) builtin_asyncGenproto_throw(call FunctionCall) Value {
+	return r.asyncGenproto_enqueue(call, completionThrow, "throw")
+}
+
+func (r *Runtime) createAsyncGeneratorFunctionProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.getFunctionPrototype(), classObject)
+
+	o._putProp("constructor", r.getAsyncGeneratorFunction(), false, false, true)
+	o._putProp("prototype", r.getAsyncGeneratorPrototype(), false, false, true)
+	o._putSym(SymToStringTag, valueProp(asciiString(classAsyncGeneratorFunction), false, false, true))
+
+	return o
+}
+
+func (r *Runtime) getAsyncGeneratorFunctionPrototype() *Object {
+	var o *Object
+	if o = r.global.AsyncGeneratorFunctionPrototype; o == nil {
+		o = &Object{runtime: r}
+		r.global.AsyncGeneratorFunctionPrototype = o
+		o.self = r.createAsyncGeneratorFunctionProto(o)
+	}
+	return o
+}
+
+func (r *Runtime) createAsyncGeneratorFunction(val *Object) objectImpl {
+	o := r.newNativeFuncConstructObj(val, r.builtin_asyncGeneratorFunction, "AsyncGeneratorFunction", r.getAsyncGeneratorFunctionPrototype(), 1)
+	o.prototype = r.getFunction()
+	return o
+}
+
+func (r *Runtime) getAsyncGeneratorFunction() *Object {
+	var o *Object
+	if o = r.global.AsyncGeneratorFunction; o == nil {
+		o = &Object{runtime: r}
+		r.global.AsyncGeneratorFunction = o
+		o.self = r.createAsyncGeneratorFunction(o)
+	}
+	return o
+}
+
+func (r *Runtime) createAsyncGeneratorProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.getAsyncIteratorPrototype(), classObject)
+
+	o._putProp("constructor", r.getAsyncGeneratorFunctionPrototype(), false, false, true)
+	o._putProp("next", r.newNativeFunc(r.builtin_asyncGenproto_next, "next", 1), true, false, true)
+	o._putProp("return", r.newNativeFunc(r.builtin_asyncGenproto_return, "return", 1), true, false, true)
+	o._putProp("throw", r.newNativeFunc(r.builtin_asyncGenproto_throw, "throw", 1), true, false, true)
+
+	o._putSym(SymToStringTag, valueProp(asciiString(classAsyncGenerator), false, false, true))
+
+	return o
+}
+
+func (r *Runtime) getAsyncGeneratorPrototype() *Object {
+	var o *Object
+	if o = r.global.AsyncGeneratorPrototype; o == nil {
+		o = &Object{runtime: r}
+		r.global.AsyncGeneratorPrototype = o
+		o.self = r.createAsyncGeneratorProto(o)
+	}
+	return o
+}
+
 func (r *Runtime) getFunction() *Object {
 	ret := r.global.Function
 	if ret == nil {
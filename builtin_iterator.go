@@ -0,0 +1,569 @@
+package goja
+
+import (
+	"math"
+)
+
+type iteratorHelperObject struct {
+	baseObject
+	iterated *iteratorRecord
+	inner    *iteratorRecord // the current inner iterator of flatMap()
+	step     func() Value    // returns the next value, or nil when the helper is done
+	state    generatorState
+}
+
+type iteratorWrapObject struct {
+	baseObject
+	iterated *iteratorRecord
+}
+
+// stepResult implements IteratorStep(). It returns nil if the iterator is done.
+func (ir *iteratorRecord) stepResult() *Object {
+	r := ir.iterator.runtime
+	res := r.toObject(ir.next(FunctionCall{This: ir.iterator}))
+	if iteratorComplete(res) {
+		ir.close()
+		return nil
+	}
+	return res
+}
+
+// stepValue implements IteratorStepValue(). It returns nil if the iterator is done.
+func (ir *iteratorRecord) stepValue() Value {
+	if res := ir.stepResult(); res != nil {
+		return iteratorValue(res)
+	}
+	return nil
+}
+
+// closeOnError implements IfAbruptCloseIterator(): if f throws, the iterator is closed (ignoring any
+// errors that may occur in the process) and the exception is re-thrown.
+func (ir *iteratorRecord) closeOnError(f func()) {
+	if ret := tryFunc(f); ret != nil {
+		_ = tryFunc(ir.returnIter)
+		panic(ret)
+	}
+}
+
+// getIteratorDirect implements GetIteratorDirect(). Unlike getIterator(), the 'next' method is not checked
+// until it's called.
+func (r *Runtime) getIteratorDirect(obj *Object) *iteratorRecord {
+	nextMethod := obj.self.getStr("next", nil)
+	next, ok := assertCallable(nextMethod)
+	if !ok {
+		next = func(FunctionCall) Value {
+			panic(r.NewTypeError("%s is not a function", nilSafe(nextMethod).String()))
+		}
+	}
+	return &iteratorRecord{
+		iterator: obj,
+		next:     next,
+	}
+}
+
+// getIteratorFlattenable implements GetIteratorFlattenable(). If allowStrings is true, string primitives
+// are iterated, all other primitives are rejected.
+func (r *Runtime) getIteratorFlattenable(v Value, allowStrings bool) *iteratorRecord {
+	obj, ok := v.(*Object)
+	if !ok {
+		if _, isString := v.(String); !isString || !allowStrings {
+			panic(r.NewTypeError("%s is not an object", v.String()))
+		}
+	}
+	var iterator Value = obj
+	if method := toMethod(r.getV(v, SymIterator)); method != nil {
+		iterator = method(FunctionCall{This: v})
+	} else if obj == nil {
+		panic(r.NewTypeError("%s is not iterable", v.String()))
+	}
+	return r.getIteratorDirect(r.toObject(iterator))
+}
+
+func (r *Runtime) toIteratorThis(v Value, method string) *Object {
+	if obj, ok := v.(*Object); ok {
+		return obj
+	}
+	panic(r.NewTypeError("Iterator.prototype.%s called on non-object", method))
+}
+
+// toIteratorCallback converts the callback argument of an iterator method. If it's not callable the
+// iterator is closed before the TypeError is thrown.
+func (r *Runtime) toIteratorCallback(iterator *Object, v Value) func(FunctionCall) Value {
+	if call, ok := assertCallable(v); ok {
+		return call
+	}
+	ir := &iteratorRecord{iterator: iterator}
+	_ = tryFunc(ir.returnIter)
+	panic(r.NewTypeError("%s is not a function", v.String()))
+}
+
+// toIteratorLimit converts the limit argument of take() and drop(). If the conversion fails the iterator is
+// closed.
+func (r *Runtime) toIteratorLimit(iterator *Object, v Value) (limit float64) {
+	ir := &iteratorRecord{iterator: iterator}
+	ir.closeOnError(func() {
+		limit = v.ToNumber().ToFloat()
+		if math.IsNaN(limit) {
+			panic(r.newError(r.getRangeError(), "%s must be positive", v.String()))
+		}
+		limit = math.Trunc(limit)
+		if limit < 0 {
+			panic(r.newError(r.getRangeError(), "%s must be positive", v.String()))
+		}
+	})
+	return
+}
+
+func (r *Runtime) createIteratorHelper(iterated *iteratorRecord) *iteratorHelperObject {
+	o := &Object{runtime: r}
+	h := &iteratorHelperObject{
+		baseObject: baseObject{
+			class:      classObject,
+			val:        o,
+			extensible: true,
+			prototype:  r.getIteratorHelperPrototype(),
+		},
+		iterated: iterated,
+		state:    genStateSuspendedStart,
+	}
+	o.self = h
+	h.init()
+	return h
+}
+
+func (r *Runtime) toIteratorHelper(v Value, method string) *iteratorHelperObject {
+	if obj, ok := v.(*Object); ok {
+		if h, ok := obj.self.(*iteratorHelperObject); ok {
+			return h
+		}
+	}
+	panic(r.NewTypeError("Method Iterator Helper.prototype.%s called on incompatible receiver %s", method, r.objectproto_toString(FunctionCall{This: v})))
+}
+
+func (r *Runtime) iteratorHelperProto_next(call FunctionCall) Value {
+	h := r.toIteratorHelper(call.This, "next")
+	switch h.state {
+	case genStateExecuting:
+		panic(r.NewTypeError("Generator is already running"))
+	case genStateCompleted:
+		return r.createIterResultObject(_undefined, true)
+	}
+	h.state = genStateExecuting
+	var value Value
+	if ex := r.vm.try(func() {
+		value = h.step()
+	}); ex != nil {
+		h.state = genStateCompleted
+		panic(ex)
+	}
+	if value == nil {
+		h.state = genStateCompleted
+		return r.createIterResultObject(_undefined, true)
+	}
+	h.state = genStateSuspendedYield
+	return r.createIterResultObject(value, false)
+}
+
+func (r *Runtime) iteratorHelperProto_return(call FunctionCall) Value {
+	h := r.toIteratorHelper(call.This, "return")
+	switch h.state {
+	case genStateExecuting:
+		panic(r.NewTypeError("Generator is already running"))
+	case genStateSuspendedStart, genStateSuspendedYield:
+		h.state = genStateCompleted
+		if inner := h.inner; inner != nil {
+			h.inner = nil
+			h.iterated.closeOnError(inner.returnIter)
+		}
+		h.iterated.returnIter()
+	}
+	return r.createIterResultObject(_undefined, true)
+}
+
+func (r *Runtime) iteratorProto_map(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "map")
+	mapper := r.toIteratorCallback(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	h := r.createIteratorHelper(iterated)
+	var counter int64
+	h.step = func() Value {
+		value := iterated.stepValue()
+		if value == nil {
+			return nil
+		}
+		iterated.closeOnError(func() {
+			value = mapper(FunctionCall{This: _undefined, Arguments: []Value{value, valueInt(counter)}})
+		})
+		counter++
+		return value
+	}
+	return h.val
+}
+
+func (r *Runtime) iteratorProto_filter(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "filter")
+	predicate := r.toIteratorCallback(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	h := r.createIteratorHelper(iterated)
+	var counter int64
+	h.step = func() Value {
+		for {
+			value := iterated.stepValue()
+			if value == nil {
+				return nil
+			}
+			var selected bool
+			iterated.closeOnError(func() {
+				selected = predicate(FunctionCall{This: _undefined, Arguments: []Value{value, valueInt(counter)}}).ToBoolean()
+			})
+			counter++
+			if selected {
+				return value
+			}
+		}
+	}
+	return h.val
+}
+
+func (r *Runtime) iteratorProto_take(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "take")
+	remaining := r.toIteratorLimit(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	h := r.createIteratorHelper(iterated)
+	h.step = func() Value {
+		if remaining == 0 {
+			iterated.returnIter()
+			return nil
+		}
+		if !math.IsInf(remaining, 1) {
+			remaining--
+		}
+		return iterated.stepValue()
+	}
+	return h.val
+}
+
+func (r *Runtime) iteratorProto_drop(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "drop")
+	remaining := r.toIteratorLimit(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	h := r.createIteratorHelper(iterated)
+	h.step = func() Value {
+		for ; remaining > 0; remaining-- {
+			if iterated.stepResult() == nil {
+				return nil
+			}
+		}
+		return iterated.stepValue()
+	}
+	return h.val
+}
+
+func (r *Runtime) iteratorProto_flatMap(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "flatMap")
+	mapper := r.toIteratorCallback(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	h := r.createIteratorHelper(iterated)
+	var counter int64
+	h.step = func() Value {
+		for {
+			if inner := h.inner; inner != nil {
+				var value Value
+				iterated.closeOnError(func() {
+					value = inner.stepValue()
+				})
+				if value != nil {
+					return value
+				}
+				h.inner = nil
+			}
+			value := iterated.stepValue()
+			if value == nil {
+				return nil
+			}
+			iterated.closeOnError(func() {
+				mapped := mapper(FunctionCall{This: _undefined, Arguments: []Value{value, valueInt(counter)}})
+				h.inner = r.getIteratorFlattenable(mapped, false)
+			})
+			counter++
+		}
+	}
+	return h.val
+}
+
+func (r *Runtime) iteratorProto_reduce(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "reduce")
+	reducer := r.toIteratorCallback(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	var accumulator Value
+	var counter int64
+	if len(call.Arguments) > 1 {
+		accumulator = call.Arguments[1]
+	} else {
+		accumulator = iterated.stepValue()
+		if accumulator == nil {
+			panic(r.NewTypeError("Reduce of empty iterator with no initial value"))
+		}
+		counter = 1
+	}
+	for {
+		value := iterated.stepValue()
+		if value == nil {
+			return accumulator
+		}
+		iterated.closeOnError(func() {
+			accumulator = reducer(FunctionCall{This: _undefined, Arguments: []Value{accumulator, value, valueInt(counter)}})
+		})
+		counter++
+	}
+}
+
+func (r *Runtime) iteratorProto_toArray(call FunctionCall) Value {
+	o := r.toIteratorThis(call.This, "toArray")
+	iterated := r.getIteratorDirect(o)
+	var items []Value
+	for {
+		value := iterated.stepValue()
+		if value == nil {
+			return r.newArrayValues(items)
+		}
+		items = append(items, value)
+	}
+}
+
+// iteratorForEach calls f for each value of the iterator until it returns false, in which case the iterator
+// is closed. f is called with the callback's arguments.
+func (r *Runtime) iteratorForEach(call FunctionCall, method string, f func(value, res Value) bool) {
+	o := r.toIteratorThis(call.This, method)
+	fn := r.toIteratorCallback(o, call.Argument(0))
+	iterated := r.getIteratorDirect(o)
+	var counter int64
+	for {
+		value := iterated.stepValue()
+		if value == nil {
+			return
+		}
+		var res Value
+		iterated.closeOnError(func() {
+			res = fn(FunctionCall{This: _undefined, Arguments: []Value{value, valueInt(counter)}})
+		})
+		if !f(value, res) {
+			iterated.returnIter()
+			return
+		}
+		counter++
+	}
+}
+
+func (r *Runtime) iteratorProto_forEach(call FunctionCall) Value {
+	r.iteratorForEach(call, "forEach", func(Value, Value) bool {
+		return true
+	})
+	return _undefined
+}
+
+func (r *Runtime) iteratorProto_some(call FunctionCall) Value {
+	found := false
+	r.iteratorForEach(call, "some", func(_, res Value) bool {
+		found = res.ToBoolean()
+		return !found
+	})
+	return r.toBoolean(found)
+}
+
+func (r *Runtime) iteratorProto_every(call FunctionCall) Value {
+	all := true
+	r.iteratorForEach(call, "every", func(_, res Value) bool {
+		all = res.ToBoolean()
+		return all
+	})
+	return r.toBoolean(all)
+}
+
+func (r *Runtime) iteratorProto_find(call FunctionCall) Value {
+	var found Value = _undefined
+	r.iteratorForEach(call, "find", func(value, res Value) bool {
+		if res.ToBoolean() {
+			found = value
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// setterIgnoringPrototype implements SetterThatIgnoresPrototypeProperties() for the accessor properties of
+// %Iterator.prototype%, which are accessors for web compatibility.
+func (r *Runtime) setterIgnoringPrototype(name Value, call FunctionCall) {
+	this, ok := call.This.(*Object)
+	if !ok {
+		panic(r.NewTypeError("Cannot set %s on a non-object", name.String()))
+	}
+	if this == r.getIteratorPrototype() {
+		panic(r.NewTypeError("Cannot assign to read only property '%s'", name.String()))
+	}
+	if this.getOwnProp(name) == nil {
+		createDataPropertyOrThrow(this, name, call.Argument(0))
+	} else {
+		this.set(name, call.Argument(0), this, true)
+	}
+}
+
+func (r *Runtime) createIterProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.global.ObjectPrototype, classObject)
+
+	o.setOwnStr("constructor", &valueProperty{
+		getterFunc: r.newNativeFunc(func(FunctionCall) Value {
+			return r.getIteratorCtor()
+		}, "get constructor", 0),
+		setterFunc: r.newNativeFunc(func(call FunctionCall) Value {
+			r.setterIgnoringPrototype(asciiString("constructor"), call)
+			return _undefined
+		}, "set constructor", 1),
+		accessor:     true,
+		configurable: true,
+	}, true)
+	o._putProp("drop", r.newNativeFunc(r.iteratorProto_drop, "drop", 1), true, false, true)
+	o._putProp("every", r.newNativeFunc(r.iteratorProto_every, "every", 1), true, false, true)
+	o._putProp("filter", r.newNativeFunc(r.iteratorProto_filter, "filter", 1), true, false, true)
+	o._putProp("find", r.newNativeFunc(r.iteratorProto_find, "find", 1), true, false, true)
+	o._putProp("flatMap", r.newNativeFunc(r.iteratorProto_flatMap, "flatMap", 1), true, false, true)
+	o._putProp("forEach", r.newNativeFunc(r.iteratorProto_forEach, "forEach", 1), true, false, true)
+	o._putProp("map", r.newNativeFunc(r.iteratorProto_map, "map", 1), true, false, true)
+	o._putProp("reduce", r.newNativeFunc(r.iteratorProto_reduce, "reduce", 1), true, false, true)
+	o._putProp("some", r.newNativeFunc(r.iteratorProto_some, "some", 1), true, false, true)
+	o._putProp("take", r.newNativeFunc(r.iteratorProto_take, "take", 1), true, false, true)
+	o._putProp("toArray", r.newNativeFunc(r.iteratorProto_toArray, "toArray", 0), true, false, true)
+
+	o._putSym(SymIterator, valueProp(r.newNativeFunc(r.returnThis, "[Symbol.iterator]", 0), true, false, true))
+	o._putSym(SymDispose, valueProp(r.newNativeFunc(r.iteratorProto_dispose, "[Symbol.dispose]", 0), true, false, true))
+	o._putSym(SymToStringTag, &valueProperty{
+		getterFunc: r.newNativeFunc(func(FunctionCall) Value {
+			return asciiString(classIterator)
+		}, "get [Symbol.toStringTag]", 0),
+		setterFunc: r.newNativeFunc(func(call FunctionCall) Value {
+			r.setterIgnoringPrototype(SymToStringTag, call)
+			return _undefined
+		}, "set [Symbol.toStringTag]", 1),
+		accessor:     true,
+		configurable: true,
+	})
+	return o
+}
+
+func (r *Runtime) getIteratorPrototype() *Object {
+	var o *Object
+	if o = r.global.IteratorPrototype; o == nil {
+		o = &Object{runtime: r}
+		r.global.IteratorPrototype = o
+		o.self = r.createIterProto(o)
+	}
+	return o
+}
+
+func (r *Runtime) createIteratorHelperProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.getIteratorPrototype(), classObject)
+
+	o._putProp("next", r.newNativeFunc(r.iteratorHelperProto_next, "next", 0), true, false, true)
+	o._putProp("return", r.newNativeFunc(r.iteratorHelperProto_return, "return", 0), true, false, true)
+	o._putSym(SymToStringTag, valueProp(asciiString(classIteratorHelper), false, false, true))
+
+	return o
+}
+
+func (r *Runtime) getIteratorHelperPrototype() *Object {
+	var o *Object
+	if o = r.global.IteratorHelperPrototype; o == nil {
+		o = &Object{runtime: r}
+		r.global.IteratorHelperPrototype = o
+		o.self = r.createIteratorHelperProto(o)
+	}
+	return o
+}
+
+func (r *Runtime) toIteratorWrap(v Value, method string) *iteratorWrapObject {
+	if obj, ok := v.(*Object); ok {
+		if w, ok := obj.self.(*iteratorWrapObject); ok {
+			return w
+		}
+	}
+	panic(r.NewTypeError("Method %%WrapForValidIteratorPrototype%%.%s called on incompatible receiver %s", method, r.objectproto_toString(FunctionCall{This: v})))
+}
+
+func (r *Runtime) iteratorWrapProto_next(call FunctionCall) Value {
+	iterated := r.toIteratorWrap(call.This, "next").iterated
+	return iterated.next(FunctionCall{This: iterated.iterator})
+}
+
+func (r *Runtime) iteratorWrapProto_return(call FunctionCall) Value {
+	iterator := r.toIteratorWrap(call.This, "return").iterated.iterator
+	method := toMethod(iterator.self.getStr("return", nil))
+	if method == nil {
+		return r.createIterResultObject(_undefined, true)
+	}
+	return method(FunctionCall{This: iterator})
+}
+
+func (r *Runtime) createIteratorWrapProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.getIteratorPrototype(), classObject)
+
+	o._putProp("next", r.newNativeFunc(r.iteratorWrapProto_next, "next", 0), true, false, true)
+	o._putProp("return", r.newNativeFunc(r.iteratorWrapProto_return, "return", 0), true, false, true)
+
+	return o
+}
+
+func (r *Runtime) getIteratorWrapPrototype() *Object {
+	var o *Object
+	if o = r.global.IteratorWrapPrototype; o == nil {
+		o = &Object{runtime: r}
+		r.global.IteratorWrapPrototype = o
+		o.self = r.createIteratorWrapProto(o)
+	}
+	return o
+}
+
+func (r *Runtime) builtin_newIterator(_ []Value, newTarget *Object) *Object {
+	if newTarget == nil || newTarget == r.getIteratorCtor() {
+		panic(r.NewTypeError("Abstract class Iterator not directly constructable"))
+	}
+	return r.newBaseObject(r.getPrototypeFromCtor(newTarget, nil, r.getIteratorPrototype()), classObject).val
+}
+
+func (r *Runtime) iterator_from(call FunctionCall) Value {
+	iterated := r.getIteratorFlattenable(call.Argument(0), true)
+	if hasInstance(r.getIteratorCtor(), iterated.iterator) {
+		return iterated.iterator
+	}
+	o := &Object{runtime: r}
+	w := &iteratorWrapObject{
+		baseObject: baseObject{
+			class:      classObject,
+			val:        o,
+			extensible: true,
+			prototype:  r.getIteratorWrapPrototype(),
+		},
+		iterated: iterated,
+	}
+	o.self = w
+	w.init()
+	return o
+}
+
+func (r *Runtime) createIteratorCtor(val *Object) objectImpl {
+	o := r.newNativeConstructOnly(val, r.builtin_newIterator, r.getIteratorPrototype(), "Iterator", 0)
+	o._putProp("from", r.newNativeFunc(r.iterator_from, "from", 1), true, false, true)
+
+	return o
+}
+
+// getIteratorCtor returns the Iterator constructor (getIterator() implements the GetIterator() operation).
+func (r *Runtime) getIteratorCtor() *Object {
+	ret := r.global.Iterator
+	if ret == nil {
+		ret = &Object{runtime: r}
+		r.global.Iterator = ret
+		ret.self = r.createIteratorCtor(ret)
+	}
+	return ret
+}
@@ -0,0 +1,111 @@
+package goja
+
+import (
+	"testing"
+)
+
+func TestIteratorHelpers(t *testing.T) {
+	const SCRIPT = `
+	let closed = 0;
+	function* naturals() {
+		try {
+			let i = 0;
+			while (true) {
+				yield i++;
+			}
+		} finally {
+			closed++;
+		}
+	}
+
+	let res = naturals().map(x => x * 2).filter(x => x % 3 === 0).drop(1).take(3).toArray();
+	assert(compareArray(res, [6, 12, 18]), res);
+	assert.sameValue(closed, 1, "take() closes the iterator");
+
+	assert.sameValue([1, 2, 3].values().reduce((a, b) => a + b), 6);
+	assert.sameValue([1, 2, 3].values().reduce((a, b) => a + b, 10), 16);
+	assert.throws(TypeError, () => [].values().reduce((a, b) => a + b));
+
+	res = [1, 2].values().flatMap(x => naturals().take(x)).toArray();
+	assert(compareArray(res, [0, 0, 1]), res);
+	assert.throws(TypeError, () => [1].values().flatMap(x => 1).toArray(), "flatMap() rejects primitives");
+
+	assert.sameValue(naturals().some(x => x > 3), true);
+	assert.sameValue(naturals().every(x => x < 3), false);
+	assert.sameValue(naturals().find(x => x > 5), 6);
+	let sum = 0;
+	[1, 2].values().forEach(x => sum += x);
+	assert.sameValue(sum, 3);
+
+	closed = 0;
+	const helper = naturals().map(x => x);
+	assert.sameValue(helper.next().value, 0);
+	let r = helper.return();
+	assert.sameValue(r.done, true);
+	assert.sameValue(closed, 1, "return() closes the underlying iterator");
+	assert.sameValue(helper.next().done, true);
+	assert.sameValue(Object.prototype.toString.call(helper), "[object Iterator Helper]");
+
+	assert.throws(RangeError, () => naturals().take(-1));
+	assert.throws(RangeError, () => naturals().drop(NaN));
+	assert.throws(TypeError, () => naturals().map(1));
+	assert.throws(TypeError, () => Iterator.prototype.map.call(1, x => x));
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestIteratorHelpersBuiltinIterators(t *testing.T) {
+	const SCRIPT = `
+	let res = new Map([[1, "a"], [2, "b"]]).entries().map(([k, v]) => k + v).toArray();
+	assert(compareArray(res, ["1a", "2b"]), res);
+	res = new Set([1, 2]).values().flatMap(x => [x, x]).toArray();
+	assert(compareArray(res, [1, 1, 2, 2]), res);
+	res = "abc"[Symbol.iterator]().map(c => c.toUpperCase()).toArray();
+	assert(compareArray(res, ["A", "B", "C"]), res);
+	res = "a1b2".matchAll(/\d/g).map(m => m[0]).toArray();
+	assert(compareArray(res, ["1", "2"]), res);
+	res = [1, 2, 3].keys().filter(k => k > 0).toArray();
+	assert(compareArray(res, [1, 2]), res);
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestIteratorConstructor(t *testing.T) {
+	const SCRIPT = `
+	assert.sameValue(Iterator.prototype, Object.getPrototypeOf(Object.getPrototypeOf([].values())));
+	assert.sameValue(Iterator.prototype.constructor, Iterator);
+	assert.sameValue(Iterator.prototype[Symbol.toStringTag], "Iterator");
+	assert.throws(TypeError, () => new Iterator());
+	assert.throws(TypeError, () => Iterator());
+
+	class Empty extends Iterator {
+		next() {
+			return {done: true};
+		}
+	}
+	assert(new Empty() instanceof Iterator);
+	assert.sameValue(new Empty().toArray().length, 0);
+
+	const o = Object.create(Iterator.prototype);
+	o.constructor = 1;
+	assert(o.hasOwnProperty("constructor"), "setting constructor on an inheriting object creates an own property");
+	assert.throws(TypeError, () => {
+		Iterator.prototype.constructor = 1;
+	});
+
+	const wrapped = Iterator.from({
+		next() {
+			return {value: 1, done: false};
+		}
+	});
+	assert(Object.getPrototypeOf(wrapped) !== Iterator.prototype, "wrapped");
+	assert(compareArray(wrapped.take(2).toArray(), [1, 1]));
+	assert.sameValue(wrapped.return().done, true);
+
+	const it = [1].values();
+	assert.sameValue(Iterator.from(it), it, "iterators inheriting from Iterator.prototype are not wrapped");
+	assert(compareArray(Iterator.from("xy").toArray(), ["x", "y"]));
+	assert.throws(TypeError, () => Iterator.from(1));
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
@@ -138,13 +138,20 @@ func (r *Runtime) mapProto_has(call FunctionCall) Value {
 	return valueFalse
 }
 
+// setMapEntry sets an entry of a Map or a Set, accounting it if it's new (see trackAlloc).
+func (r *Runtime) setMapEntry(m *orderedMap, key, value Value) {
+	if m.set(key, value) {
+		r.trackAlloc(memoryCollections, 1, mapEntrySize)
+	}
+}
+
 func (r *Runtime) mapProto_set(call FunctionCall) Value {
 	thisObj := r.toObject(call.This)
 	mo, ok := thisObj.self.(*mapObject)
 	if !ok {
 		panic(r.NewTypeError("Method Map.prototype.set called on incompatible receiver %s", r.objectproto_toString(FunctionCall{This: thisObj})))
 	}
-	mo.m.set(call.Argument(0), call.Argument(1))
+	r.setMapEntry(mo.m, call.Argument(0), call.Argument(1))
 	return call.This
 }
 
@@ -221,7 +228,7 @@ func (r *Runtime) builtin_newMap(args []Value, newTarget *Object) *Object {
 					itemObj := r.toObject(item)
 					k := nilSafe(itemObj.self.getIdx(i0, nil))
 					v := nilSafe(itemObj.self.getIdx(i1, nil))
-					mo.m.set(k, v)
+					r.setMapEntry(mo.m, k, v)
 				})
 			} else {
 				iter.iterate(func(item Value) {
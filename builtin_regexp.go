@@ -183,7 +183,7 @@ func compileRegexpFromValueString(patternStr String, flags string) (*regexpPatte
 }
 
 func compileRegexp(patternStr, flags string) (p *regexpPattern, err error) {
-	var global, ignoreCase, multiline, dotAll, sticky, unicode bool
+	var global, ignoreCase, multiline, dotAll, sticky, unicode, unicodeSets, hasIndices bool
 	var wrapper *regexpWrapper
 	var wrapper2 *regexp2Wrapper
 
@@ -224,10 +224,23 @@ func compileRegexp(patternStr, flags string) (p *regexpPattern, err error) {
 				}
 				sticky = true
 			case 'u':
-				if unicode {
+				if unicode || unicodeSets {
 					invalidFlags()
+					return
 				}
 				unicode = true
+			case 'v':
+				if unicode || unicodeSets {
+					invalidFlags()
+					return
+				}
+				unicodeSets = true
+			case 'd':
+				if hasIndices {
+					invalidFlags()
+					return
+				}
+				hasIndices = true
 			default:
 				invalidFlags()
 				return
@@ -235,12 +248,25 @@ func compileRegexp(patternStr, flags string) (p *regexpPattern, err error) {
 		}
 	}
 
-	if unicode {
+	if unicode || unicodeSets {
+		// the 'v' mode is a stricter variant of the 'u' mode, once the classes are transformed, it's handled the
+		// same way
 		patternStr = convertRegexpToUnicode(patternStr)
+		patternStr, err = parser.TransformRegExpUnicodeClasses(patternStr, unicodeSets)
+		if err != nil {
+			return
+		}
+		unicode = true
 	} else {
 		patternStr = convertRegexpToUtf16(patternStr)
 	}
 
+	patternStr, groupNames, err1 := parser.TransformRegExpNamedGroups(patternStr, unicode)
+	if err1 != nil {
+		err = err1
+		return
+	}
+
 	re2Str, err1 := parser.TransformRegExp(patternStr, dotAll, unicode)
 	if err1 == nil {
 		re2flags := ""
@@ -277,6 +303,7 @@ func compileRegexp(patternStr, flags string) (p *regexpPattern, err error) {
 
 	p = &regexpPattern{
 		src:            patternStr,
+		groupNames:     groupNames,
 		regexpWrapper:  wrapper,
 		regexp2Wrapper: wrapper2,
 		global:         global,
@@ -285,6 +312,8 @@ func compileRegexp(patternStr, flags string) (p *regexpPattern, err error) {
 		dotAll:         dotAll,
 		sticky:         sticky,
 		unicode:        unicode,
+		unicodeSets:    unicodeSets,
+		hasIndices:     hasIndices,
 	}
 	return
 }
@@ -360,6 +389,67 @@ func (r *Runtime) builtin_RegExp(call FunctionCall) Value {
 	return r.newRegExp(pattern, flags, r.getRegExpPrototype()).val
 }
 
+// regexp_escape implements RegExp.escape().
+func (r *Runtime) regexp_escape(call FunctionCall) Value {
+	str, ok := call.Argument(0).(String)
+	if !ok {
+		panic(r.NewTypeError("RegExp.escape requires a string"))
+	}
+	var sb StringBuilder
+	rd := &lenientUtf16Decoder{utf16Reader: str.utf16Reader()}
+	for first := true; ; first = false {
+		c, _, err := rd.ReadRune()
+		if err != nil {
+			break
+		}
+		switch {
+		case first && ('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'):
+			writeRegexpHexEscape(&sb, c)
+		case strings.ContainsRune(`^$\.*+?()[]{}|/`, c):
+			sb.WriteRune('\\')
+			sb.WriteRune(c)
+		case c == '\t':
+			sb.writeASCII(`\t`)
+		case c == '\n':
+			sb.writeASCII(`\n`)
+		case c == '\v':
+			sb.writeASCII(`\v`)
+		case c == '\f':
+			sb.writeASCII(`\f`)
+		case c == '\r':
+			sb.writeASCII(`\r`)
+		case strings.ContainsRune(",-=<>#&!%:;@~'`\"", c) || strings.ContainsRune(parser.WhitespaceChars, c) ||
+			0xD800 <= c && c <= 0xDFFF: // lone surrogates
+			writeRegexpHexEscape(&sb, c)
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// writeRegexpHexEscape writes c as \xXX or as one or two \uXXXX escapes.
+func writeRegexpHexEscape(sb *StringBuilder, c rune) {
+	if c <= 0xFF {
+		sb.writeASCII(`\x`)
+		sb.WriteRune(rune(hex[c>>4]))
+		sb.WriteRune(rune(hex[c&0xF]))
+		return
+	}
+	units := []rune{c}
+	if c > 0xFFFF {
+		c1, c2 := utf16.EncodeRune(c)
+		units = []rune{c1, c2}
+	}
+	for _, u := range units {
+		sb.writeASCII(`\u`)
+		sb.WriteRune(rune(hex[u>>12]))
+		sb.WriteRune(rune(hex[(u>>8)&0xF]))
+		sb.WriteRune(rune(hex[(u>>4)&0xF]))
+		sb.WriteRune(rune(hex[u&0xF]))
+	}
+}
+
 func (r *Runtime) regexpproto_compile(call FunctionCall) Value {
 	if this, ok := r.toObject(call.This).self.(*regexpObject); ok {
 		var (
@@ -432,6 +522,9 @@ func (r *Runtime) regexpproto_toString(call FunctionCall) Value {
 			sb.WriteString(this.source)
 		}
 		sb.WriteRune('/')
+		if this.pattern.hasIndices {
+			sb.WriteRune('d')
+		}
 		if this.pattern.global {
 			sb.WriteRune('g')
 		}
@@ -444,7 +537,9 @@ func (r *Runtime) regexpproto_toString(call FunctionCall) Value {
 		if this.pattern.dotAll {
 			sb.WriteRune('s')
 		}
-		if this.pattern.unicode {
+		if this.pattern.unicodeSets {
+			sb.WriteRune('v')
+		} else if this.pattern.unicode {
 			sb.WriteRune('u')
 		}
 		if this.pattern.sticky {
@@ -581,7 +676,7 @@ func (r *Runtime) regexpproto_getIgnoreCase(call FunctionCall) Value {
 
 func (r *Runtime) regexpproto_getUnicode(call FunctionCall) Value {
 	if this, ok := r.toObject(call.This).self.(*regexpObject); ok {
-		if this.pattern.unicode {
+		if this.pattern.unicode && !this.pattern.unicodeSets {
 			return valueTrue
 		} else {
 			return valueFalse
@@ -593,6 +688,20 @@ func (r *Runtime) regexpproto_getUnicode(call FunctionCall) Value {
 	}
 }
 
+func (r *Runtime) regexpproto_getUnicodeSets(call FunctionCall) Value {
+	if this, ok := r.toObject(call.This).self.(*regexpObject); ok {
+		if this.pattern.unicodeSets {
+			return valueTrue
+		} else {
+			return valueFalse
+		}
+	} else if call.This == r.global.RegExpPrototype {
+		return _undefined
+	} else {
+		panic(r.NewTypeError("Method RegExp.prototype.unicodeSets getter called on incompatible receiver %s", r.objectproto_toString(FunctionCall{This: call.This})))
+	}
+}
+
 func (r *Runtime) regexpproto_getSticky(call FunctionCall) Value {
 	if this, ok := r.toObject(call.This).self.(*regexpObject); ok {
 		if this.pattern.sticky {
@@ -607,11 +716,31 @@ func (r *Runtime) regexpproto_getSticky(call FunctionCall) Value {
 	}
 }
 
+func (r *Runtime) regexpproto_getHasIndices(call FunctionCall) Value {
+	if this, ok := r.toObject(call.This).self.(*regexpObject); ok {
+		if this.pattern.hasIndices {
+			return valueTrue
+		} else {
+			return valueFalse
+		}
+	} else if call.This == r.global.RegExpPrototype {
+		return _undefined
+	} else {
+		panic(r.NewTypeError("Method RegExp.prototype.hasIndices getter called on incompatible receiver %s", r.objectproto_toString(FunctionCall{This: call.This})))
+	}
+}
+
 func (r *Runtime) regexpproto_getFlags(call FunctionCall) Value {
-	var global, ignoreCase, multiline, dotAll, sticky, unicode bool
+	var hasIndices, global, ignoreCase, multiline, dotAll, sticky, unicode, unicodeSets bool
 
 	thisObj := r.toObject(call.This)
 	size := 0
+	if v := thisObj.self.getStr("hasIndices", nil); v != nil {
+		hasIndices = v.ToBoolean()
+		if hasIndices {
+			size++
+		}
+	}
 	if v := thisObj.self.getStr("global", nil); v != nil {
 		global = v.ToBoolean()
 		if global {
@@ -636,21 +765,30 @@ func (r *Runtime) regexpproto_getFlags(call FunctionCall) Value {
 			size++
 		}
 	}
-	if v := thisObj.self.getStr("sticky", nil); v != nil {
-		sticky = v.ToBoolean()
-		if sticky {
-			size++
-		}
-	}
 	if v := thisObj.self.getStr("unicode", nil); v != nil {
 		unicode = v.ToBoolean()
 		if unicode {
 			size++
 		}
 	}
+	if v := thisObj.self.getStr("unicodeSets", nil); v != nil {
+		unicodeSets = v.ToBoolean()
+		if unicodeSets {
+			size++
+		}
+	}
+	if v := thisObj.self.getStr("sticky", nil); v != nil {
+		sticky = v.ToBoolean()
+		if sticky {
+			size++
+		}
+	}
 
 	var sb strings.Builder
 	sb.Grow(size)
+	if hasIndices {
+		sb.WriteByte('d')
+	}
 	if global {
 		sb.WriteByte('g')
 	}
@@ -666,6 +804,9 @@ func (r *Runtime) regexpproto_getFlags(call FunctionCall) Value {
 	if unicode {
 		sb.WriteByte('u')
 	}
+	if unicodeSets {
+		sb.WriteByte('v')
+	}
 	if sticky {
 		sb.WriteByte('y')
 	}
@@ -716,7 +857,7 @@ func (r *Runtime) regexpproto_stdMatcherGeneric(rxObj *Object, s String) Value {
 	flags := nilSafe(rx.getStr("flags", nil)).String()
 	global := strings.ContainsRune(flags, 'g')
 	if global {
-		a := r.getGlobalRegexpMatches(rxObj, s, strings.ContainsRune(flags, 'u'))
+		a := r.getGlobalRegexpMatches(rxObj, s, strings.ContainsAny(flags, "uv"))
 		if len(a) == 0 {
 			return _null
 		}
@@ -812,7 +953,7 @@ func (r *Runtime) regexpproto_stdMatcherAll(call FunctionCall) Value {
 	matcher.self.setOwnStr("lastIndex", valueInt(toLength(thisObj.self.getStr("lastIndex", nil))), true)
 	flagsStr := flags.String()
 	global := strings.Contains(flagsStr, "g")
-	fullUnicode := strings.Contains(flagsStr, "u")
+	fullUnicode := strings.ContainsAny(flagsStr, "uv")
 	return r.createRegExpStringIterator(matcher, s, global, fullUnicode)
 }
 
@@ -1011,7 +1152,7 @@ func (r *Runtime) regexpproto_stdSplitter(call FunctionCall) Value {
 		splitter = r.toConstructor(c)([]Value{rxObj, flags}, nil)
 		search = r.checkStdRegexp(splitter)
 		if search == nil {
-			return r.regexpproto_stdSplitterGeneric(splitter, s, limitValue, strings.Contains(flagsStr, "u"))
+			return r.regexpproto_stdSplitterGeneric(splitter, s, limitValue, strings.ContainsAny(flagsStr, "uv"))
 		}
 	}
 
@@ -1094,7 +1235,7 @@ func (r *Runtime) regexpproto_stdReplacerGeneric(rxObj *Object, s, replaceStr St
 	var results []Value
 	flags := nilSafe(rxObj.self.getStr("flags", nil)).String()
 	isGlobal := strings.ContainsRune(flags, 'g')
-	isUnicode := strings.ContainsRune(flags, 'u')
+	isUnicode := strings.ContainsAny(flags, "uv")
 	if isGlobal {
 		results = r.getGlobalRegexpMatches(rxObj, s, isUnicode)
 	} else {
@@ -1127,9 +1268,13 @@ func (r *Runtime) regexpproto_stdReplacerGeneric(rxObj *Object, s, replaceStr St
 			}
 			captures = append(captures, capN)
 		}
+		namedCaptures := nilSafe(obj.self.getStr("groups", nil))
 		var replacement String
 		if rcall != nil {
 			captures = append(captures, intToValue(int64(position)), s)
+			if namedCaptures != _undefined {
+				captures = append(captures, namedCaptures)
+			}
 			replacement = rcall(FunctionCall{
 				This:      _undefined,
 				Arguments: captures,
@@ -1142,13 +1287,23 @@ func (r *Runtime) regexpproto_stdReplacerGeneric(rxObj *Object, s, replaceStr St
 		} else {
 			if position >= nextSourcePosition {
 				resultBuf.WriteString(s.Substring(nextSourcePosition, position))
+				var getNamedCapture func(String) String
+				if namedCaptures != _undefined {
+					groups := r.toObject(namedCaptures)
+					getNamedCapture = func(name String) String {
+						if capture := nilSafe(groups.self.getStr(name.string(), nil)); capture != _undefined {
+							return capture.toString()
+						}
+						return stringEmpty
+					}
+				}
 				writeSubstitution(s, position, len(captures), func(idx int) String {
 					capture := captures[idx]
 					if capture != _undefined {
 						return capture.toString()
 					}
 					return stringEmpty
-				}, replaceStr, &resultBuf)
+				}, getNamedCapture, replaceStr, &resultBuf)
 				nextSourcePosition = position + matchLength
 			}
 		}
@@ -1159,7 +1314,9 @@ func (r *Runtime) regexpproto_stdReplacerGeneric(rxObj *Object, s, replaceStr St
 	return resultBuf.String()
 }
 
-func writeSubstitution(s String, position int, numCaptures int, getCapture func(int) String, replaceStr String, buf *StringBuilder) {
+// writeSubstitution implements GetSubstitution(). getNamedCapture returns the value of a named capturing group
+// for '$<name>', it's nil if there are no named captures.
+func writeSubstitution(s String, position int, numCaptures int, getCapture func(int) String, getNamedCapture func(String) String, replaceStr String, buf *StringBuilder) {
 	l := s.Length()
 	rl := replaceStr.Length()
 	matched := getCapture(0)
@@ -1180,6 +1337,16 @@ func writeSubstitution(s String, position int, numCaptures int, getCapture func(
 				}
 			case '&':
 				buf.WriteString(matched)
+			case '<':
+				if getNamedCapture != nil {
+					if end := replaceStr.index(asciiString(">"), i+2); end != -1 {
+						buf.WriteString(getNamedCapture(replaceStr.Substring(i+2, end)))
+						i = end
+						continue
+					}
+				}
+				buf.WriteRune('$')
+				buf.WriteRune('<')
 			default:
 				matchNumber := 0
 				j := i + 1
@@ -1239,7 +1406,7 @@ func (r *Runtime) regexpproto_stdReplacer(call FunctionCall) Value {
 		rx.updateLastIndex(index, nil, nil)
 	}
 
-	return stringReplace(s, found, replaceStr, rcall)
+	return r.stringReplace(s, found, replaceStr, rcall, rx.pattern)
 }
 
 func (r *Runtime) regExpStringIteratorProto_next(call FunctionCall) Value {
@@ -1279,6 +1446,7 @@ func (r *Runtime) getRegExp() *Object {
 			r.wrapNativeConstruct(r.builtin_newRegExp, ret, proto), proto, "RegExp", intToValue(2))
 		rx := ret.self
 		r.putSpeciesReturnThis(rx)
+		rx._putProp("escape", r.newNativeFunc(r.regexp_escape, "escape", 1), true, false, true)
 	}
 	return ret
 }
@@ -1306,6 +1474,11 @@ func (r *Runtime) getRegExpPrototype() *Object {
 			getterFunc:   r.newNativeFunc(r.regexpproto_getGlobal, "get global", 0),
 			accessor:     true,
 		}, false)
+		o.setOwnStr("hasIndices", &valueProperty{
+			configurable: true,
+			getterFunc:   r.newNativeFunc(r.regexpproto_getHasIndices, "get hasIndices", 0),
+			accessor:     true,
+		}, false)
 		o.setOwnStr("multiline", &valueProperty{
 			configurable: true,
 			getterFunc:   r.newNativeFunc(r.regexpproto_getMultiline, "get multiline", 0),
@@ -1326,6 +1499,11 @@ func (r *Runtime) getRegExpPrototype() *Object {
 			getterFunc:   r.newNativeFunc(r.regexpproto_getUnicode, "get unicode", 0),
 			accessor:     true,
 		}, false)
+		o.setOwnStr("unicodeSets", &valueProperty{
+			configurable: true,
+			getterFunc:   r.newNativeFunc(r.regexpproto_getUnicodeSets, "get unicodeSets", 0),
+			accessor:     true,
+		}, false)
 		o.setOwnStr("sticky", &valueProperty{
 			configurable: true,
 			getterFunc:   r.newNativeFunc(r.regexpproto_getSticky, "get sticky", 0),
@@ -1342,7 +1520,7 @@ func (r *Runtime) getRegExpPrototype() *Object {
 		o._putSym(SymSearch, valueProp(r.newNativeFunc(r.regexpproto_stdSearch, "[Symbol.search]", 1), true, false, true))
 		o._putSym(SymSplit, valueProp(r.newNativeFunc(r.regexpproto_stdSplitter, "[Symbol.split]", 2), true, false, true))
 		o._putSym(SymReplace, valueProp(r.newNativeFunc(r.regexpproto_stdReplacer, "[Symbol.replace]", 2), true, false, true))
-		o.guard("exec", "global", "multiline", "ignoreCase", "unicode", "sticky")
+		o.guard("exec", "global", "multiline", "ignoreCase", "unicode", "unicodeSets", "sticky", "hasIndices")
 	}
 	return ret
 }
@@ -2,6 +2,7 @@ package goja
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 )
 
@@ -116,7 +117,7 @@ func (r *Runtime) setProto_add(call FunctionCall) Value {
 		panic(r.NewTypeError("Method Set.prototype.add called on incompatible receiver %s", r.objectproto_toString(FunctionCall{This: thisObj})))
 	}
 
-	so.m.set(call.Argument(0), nil)
+	r.setMapEntry(so.m, call.Argument(0), nil)
 	return call.This
 }
 
@@ -192,6 +193,237 @@ func (r *Runtime) setProto_values(call FunctionCall) Value {
 	return r.createSetIterator(call.This, iterationKindValue)
 }
 
+// setRecord is the result of GetSetRecord(), it allows the set methods to operate on any set-like object.
+type setRecord struct {
+	set  *Object
+	size float64
+	has  func(FunctionCall) Value
+	keys func(FunctionCall) Value
+}
+
+func (sr *setRecord) hasKey(key Value) bool {
+	return sr.has(FunctionCall{This: sr.set, Arguments: []Value{key}}).ToBoolean()
+}
+
+// keysIter implements GetIteratorFromMethod(set, keys).
+func (sr *setRecord) keysIter() *iteratorRecord {
+	r := sr.set.runtime
+	iter, ok := sr.keys(FunctionCall{This: sr.set}).(*Object)
+	if !ok {
+		panic(r.NewTypeError("keys() result is not an object"))
+	}
+	return r.getIteratorDirect(iter)
+}
+
+// getSetRecord implements GetSetRecord().
+func (r *Runtime) getSetRecord(v Value) *setRecord {
+	obj, ok := v.(*Object)
+	if !ok {
+		panic(r.NewTypeError("%s is not an object", v.String()))
+	}
+	rawSize := obj.self.getStr("size", nil)
+	numSize := nilSafe(rawSize).ToNumber().ToFloat()
+	if math.IsNaN(numSize) {
+		panic(r.NewTypeError("The 'size' property must be a number"))
+	}
+	intSize := math.Trunc(numSize)
+	if intSize < 0 {
+		panic(r.newError(r.getRangeError(), "The 'size' property must not be negative"))
+	}
+	has, ok := assertCallable(obj.self.getStr("has", nil))
+	if !ok {
+		panic(r.NewTypeError("The 'has' property must be a function"))
+	}
+	keys, ok := assertCallable(obj.self.getStr("keys", nil))
+	if !ok {
+		panic(r.NewTypeError("The 'keys' property must be a function"))
+	}
+	return &setRecord{
+		set:  obj,
+		size: intSize,
+		has:  has,
+		keys: keys,
+	}
+}
+
+func (r *Runtime) toSetObject(v Value, method string) *setObject {
+	thisObj := r.toObject(v)
+	so, ok := thisObj.self.(*setObject)
+	if !ok {
+		panic(r.NewTypeError("Method Set.prototype.%s called on incompatible receiver %s", method, r.objectproto_toString(FunctionCall{This: thisObj})))
+	}
+	return so
+}
+
+// newSetFromMap creates a Set (with %Set.prototype% as the prototype) holding the keys of m.
+func (r *Runtime) newSetFromMap(m *orderedMap) *Object {
+	o := &Object{runtime: r}
+	so := &setObject{}
+	so.class = classObject
+	so.val = o
+	so.extensible = true
+	o.self = so
+	so.prototype = r.getSetPrototype()
+	so.baseObject.init()
+	so.m = m
+	return o
+}
+
+// copy returns a copy of the set data, the new entries are accounted (see trackAlloc).
+func (so *setObject) copy() *orderedMap {
+	r := so.val.runtime
+	m := newOrderedMap(r.getHash())
+	for item := so.m.iterFirst; item != nil; item = item.iterNext {
+		r.setMapEntry(m, item.key, nil)
+	}
+	return m
+}
+
+func (r *Runtime) setProto_union(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "union")
+	other := r.getSetRecord(call.Argument(0))
+	keysIter := other.keysIter()
+	m := so.copy()
+	for {
+		next := keysIter.stepValue()
+		if next == nil {
+			break
+		}
+		r.setMapEntry(m, next, nil)
+	}
+	return r.newSetFromMap(m)
+}
+
+func (r *Runtime) setProto_intersection(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "intersection")
+	other := r.getSetRecord(call.Argument(0))
+	m := newOrderedMap(r.getHash())
+	if float64(so.m.size) <= other.size {
+		iter := so.m.newIter()
+		for entry := iter.next(); entry != nil; entry = iter.next() {
+			key := entry.key
+			if other.hasKey(key) {
+				r.setMapEntry(m, key, nil)
+			}
+		}
+	} else {
+		keysIter := other.keysIter()
+		for {
+			next := keysIter.stepValue()
+			if next == nil {
+				break
+			}
+			if so.m.has(next) {
+				r.setMapEntry(m, next, nil)
+			}
+		}
+	}
+	return r.newSetFromMap(m)
+}
+
+func (r *Runtime) setProto_difference(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "difference")
+	other := r.getSetRecord(call.Argument(0))
+	m := so.copy()
+	if float64(so.m.size) <= other.size {
+		iter := m.newIter()
+		for entry := iter.next(); entry != nil; entry = iter.next() {
+			if other.hasKey(entry.key) {
+				m.remove(entry.key)
+			}
+		}
+	} else {
+		keysIter := other.keysIter()
+		for {
+			next := keysIter.stepValue()
+			if next == nil {
+				break
+			}
+			m.remove(next)
+		}
+	}
+	return r.newSetFromMap(m)
+}
+
+func (r *Runtime) setProto_symmetricDifference(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "symmetricDifference")
+	other := r.getSetRecord(call.Argument(0))
+	keysIter := other.keysIter()
+	m := so.copy()
+	for {
+		next := keysIter.stepValue()
+		if next == nil {
+			break
+		}
+		if so.m.has(next) {
+			m.remove(next)
+		} else {
+			r.setMapEntry(m, next, nil)
+		}
+	}
+	return r.newSetFromMap(m)
+}
+
+func (r *Runtime) setProto_isSubsetOf(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "isSubsetOf")
+	other := r.getSetRecord(call.Argument(0))
+	if float64(so.m.size) > other.size {
+		return valueFalse
+	}
+	iter := so.m.newIter()
+	for entry := iter.next(); entry != nil; entry = iter.next() {
+		if !other.hasKey(entry.key) {
+			return valueFalse
+		}
+	}
+	return valueTrue
+}
+
+func (r *Runtime) setProto_isSupersetOf(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "isSupersetOf")
+	other := r.getSetRecord(call.Argument(0))
+	if float64(so.m.size) < other.size {
+		return valueFalse
+	}
+	keysIter := other.keysIter()
+	for {
+		next := keysIter.stepValue()
+		if next == nil {
+			return valueTrue
+		}
+		if !so.m.has(next) {
+			keysIter.returnIter()
+			return valueFalse
+		}
+	}
+}
+
+func (r *Runtime) setProto_isDisjointFrom(call FunctionCall) Value {
+	so := r.toSetObject(call.This, "isDisjointFrom")
+	other := r.getSetRecord(call.Argument(0))
+	if float64(so.m.size) <= other.size {
+		iter := so.m.newIter()
+		for entry := iter.next(); entry != nil; entry = iter.next() {
+			if other.hasKey(entry.key) {
+				return valueFalse
+			}
+		}
+	} else {
+		keysIter := other.keysIter()
+		for {
+			next := keysIter.stepValue()
+			if next == nil {
+				break
+			}
+			if so.m.has(next) {
+				keysIter.returnIter()
+				return valueFalse
+			}
+		}
+	}
+	return valueTrue
+}
+
 func (r *Runtime) builtin_newSet(args []Value, newTarget *Object) *Object {
 	if newTarget == nil {
 		panic(r.needNew("Set"))
@@ -213,11 +445,11 @@ func (r *Runtime) builtin_newSet(args []Value, newTarget *Object) *Object {
 			if adder == r.global.setAdder {
 				if stdArr != nil {
 					for _, v := range stdArr.values {
-						so.m.set(v, nil)
+						r.setMapEntry(so.m, v, nil)
 					}
 				} else {
 					r.getIterator(arg, nil).iterate(func(item Value) {
-						so.m.set(item, nil)
+						r.setMapEntry(so.m, item, nil)
 					})
 				}
 			} else {
@@ -282,6 +514,13 @@ func (r *Runtime) createSetProto(val *Object) objectImpl {
 	o._putProp("delete", r.newNativeFunc(r.setProto_delete, "delete", 1), true, false, true)
 	o._putProp("forEach", r.newNativeFunc(r.setProto_forEach, "forEach", 1), true, false, true)
 	o._putProp("has", r.newNativeFunc(r.setProto_has, "has", 1), true, false, true)
+	o._putProp("union", r.newNativeFunc(r.setProto_union, "union", 1), true, false, true)
+	o._putProp("intersection", r.newNativeFunc(r.setProto_intersection, "intersection", 1), true, false, true)
+	o._putProp("difference", r.newNativeFunc(r.setProto_difference, "difference", 1), true, false, true)
+	o._putProp("symmetricDifference", r.newNativeFunc(r.setProto_symmetricDifference, "symmetricDifference", 1), true, false, true)
+	o._putProp("isSubsetOf", r.newNativeFunc(r.setProto_isSubsetOf, "isSubsetOf", 1), true, false, true)
+	o._putProp("isSupersetOf", r.newNativeFunc(r.setProto_isSupersetOf, "isSupersetOf", 1), true, false, true)
+	o._putProp("isDisjointFrom", r.newNativeFunc(r.setProto_isDisjointFrom, "isDisjointFrom", 1), true, false, true)
 	o.setOwnStr("size", &valueProperty{
 		getterFunc:   r.newNativeFunc(r.setProto_getSize, "get size", 0),
 		accessor:     true,
@@ -178,3 +178,99 @@ func TestSetGetAdderGetIteratorOrder(t *testing.T) {
 	`
 	testScript(SCRIPT, valueTrue, t)
 }
+
+func TestSetMethods(t *testing.T) {
+	const SCRIPT = `
+	const a = new Set([1, 2, 3]);
+	const b = new Set([3, 4, -0]);
+	assert(compareArray([...a.union(b)], [1, 2, 3, 4, 0]), "union");
+	assert(compareArray([...a.intersection(b)], [3]), "intersection");
+	assert(compareArray([...b.intersection(new Set([0, 1, 2, 3, 4, 5]))], [3, 4, 0]), "intersection (other is bigger)");
+	assert(compareArray([...a.difference(b)], [1, 2]), "difference");
+	assert(compareArray([...new Set([1, 2, 3, 4, 5]).difference(b)], [1, 2, 5]), "difference (other is smaller)");
+	assert(compareArray([...a.symmetricDifference(b)], [1, 2, 4, 0]), "symmetricDifference");
+	assert(new Set([1, 3]).isSubsetOf(a), "isSubsetOf");
+	assert(!b.isSubsetOf(a), "!isSubsetOf");
+	assert(a.isSupersetOf(new Set([2])), "isSupersetOf");
+	assert(!a.isSupersetOf(b), "!isSupersetOf");
+	assert(a.isDisjointFrom(new Set([5, 6])), "isDisjointFrom");
+	assert(!a.isDisjointFrom(b), "!isDisjointFrom");
+
+	const res = a.union(b);
+	assert.sameValue(Object.getPrototypeOf(res), Set.prototype, "result prototype");
+	assert(res !== a, "new set");
+
+	class S extends Set {}
+	assert.sameValue(Object.getPrototypeOf(new S([1]).union(a)), Set.prototype, "no species");
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestSetMethodsSetLike(t *testing.T) {
+	const SCRIPT = `
+	const log = [];
+	const setLike = {
+		get size() {
+			log.push("size");
+			return 2;
+		},
+		get has() {
+			log.push("get has");
+			return function(v) {
+				log.push("has " + v);
+				return v === 1 || v === 5;
+			};
+		},
+		get keys() {
+			log.push("get keys");
+			return function() {
+				log.push("keys");
+				const values = [1, 5];
+				let i = 0;
+				return {
+					next() {
+						return i < values.length ? {value: values[i++], done: false} : {done: true};
+					},
+					return() {
+						log.push("return");
+						return {};
+					}
+				};
+			};
+		}
+	};
+
+	assert(compareArray([...new Set([1, 2, 3]).intersection(setLike)], [1]));
+	assert(compareArray(log, ["size", "get has", "get keys", "keys"]), log.join());
+
+	log.length = 0;
+	assert(compareArray([...new Set([1]).intersection(setLike)], [1]));
+	assert(compareArray(log, ["size", "get has", "get keys", "has 1"]), log.join());
+
+	log.length = 0;
+	assert(!new Set([1, 2]).isSupersetOf(setLike));
+	assert(compareArray(log, ["size", "get has", "get keys", "keys", "return"]), log.join());
+
+	assert.throws(TypeError, function() {
+		new Set().union({size: undefined, has() {}, keys() {}});
+	});
+	assert.throws(RangeError, function() {
+		new Set().union({size: -1, has() {}, keys() {}});
+	});
+	assert.throws(TypeError, function() {
+		new Set().union({size: 1, has: 1, keys() {}});
+	});
+	assert.throws(TypeError, function() {
+		new Set().union({size: 1, has() {}, keys() { return 1; }});
+	});
+	assert.throws(TypeError, function() {
+		new Set().union([1, 2]);
+	});
+	assert.throws(TypeError, function() {
+		Set.prototype.union.call(new Map(), new Set());
+	});
+
+	assert(compareArray([...new Set([1, 2]).union(new Map([[3, "a"]]))], [1, 2, 3]), "Map is set-like");
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
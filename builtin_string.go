@@ -251,14 +251,16 @@ func (r *Runtime) stringproto_concat(call FunctionCall) Value {
 			strs[i+1] = a
 		}
 	}
+	r.checkStringAlloc(int64(totalLen), 1, !allAscii)
 
+	var ret String
 	if allAscii {
 		var buf strings.Builder
 		buf.Grow(totalLen)
 		for _, s := range strs {
 			buf.WriteString(s.String())
 		}
-		return asciiString(buf.String())
+		ret = asciiString(buf.String())
 	} else {
 		buf := make([]uint16, totalLen+1)
 		buf[0] = unistring.BOM
@@ -275,8 +277,10 @@ func (r *Runtime) stringproto_concat(call FunctionCall) Value {
 				pos += s.Length()
 			}
 		}
-		return unicodeString(buf)
+		ret = unicodeString(buf)
 	}
+	r.trackString(ret)
+	return ret
 }
 
 func (r *Runtime) stringproto_endsWith(call FunctionCall) Value {
@@ -504,6 +508,7 @@ func (r *Runtime) _stringPad(call FunctionCall, start bool) Value {
 		filler = fillerAscii
 	}
 	remaining := toIntStrict(maxLength - stringLength)
+	r.checkStringAlloc(maxLength, 1, fillerUnicode != nil || strUnicode != nil)
 	if fillerUnicode == nil && strUnicode == nil {
 		fl := fillerAscii.Length()
 		var sb strings.Builder
@@ -521,7 +526,9 @@ func (r *Runtime) _stringPad(call FunctionCall, start bool) Value {
 		if start {
 			sb.WriteString(string(strAscii))
 		}
-		return asciiString(sb.String())
+		ret := asciiString(sb.String())
+		r.trackString(ret)
+		return ret
 	}
 	var sb unicodeStringBuilder
 	sb.ensureStarted(toIntStrict(maxLength))
@@ -540,7 +547,9 @@ func (r *Runtime) _stringPad(call FunctionCall, start bool) Value {
 		sb.writeString(s)
 	}
 
-	return sb.String()
+	ret := sb.String()
+	r.trackString(ret)
+	return ret
 }
 
 func (r *Runtime) stringproto_padEnd(call FunctionCall) Value {
@@ -567,13 +576,16 @@ func (r *Runtime) stringproto_repeat(call FunctionCall) Value {
 	}
 	num := toIntStrict(numInt)
 	a, u := devirtualizeString(s)
+	r.checkStringAlloc(int64(s.Length()), numInt, u != nil)
 	if u == nil {
 		var sb strings.Builder
 		sb.Grow(len(a) * num)
 		for i := 0; i < num; i++ {
 			sb.WriteString(string(a))
 		}
-		return asciiString(sb.String())
+		ret := asciiString(sb.String())
+		r.trackString(ret)
+		return ret
 	}
 
 	var sb unicodeStringBuilder
@@ -581,7 +593,9 @@ func (r *Runtime) stringproto_repeat(call FunctionCall) Value {
 	for i := 0; i < num; i++ {
 		sb.writeUnicodeString(u)
 	}
-	return sb.String()
+	ret := sb.String()
+	r.trackString(ret)
+	return ret
 }
 
 func getReplaceValue(replaceValue Value) (str String, rcall func(FunctionCall) Value) {
@@ -595,12 +609,20 @@ func getReplaceValue(replaceValue Value) (str String, rcall func(FunctionCall) V
 	return
 }
 
-func stringReplace(s String, found [][]int, newstring String, rcall func(FunctionCall) Value) Value {
+// stringReplace replaces the matches found in s. The pattern is nil if they were found by a string search.
+func (r *Runtime) stringReplace(s String, found [][]int, newstring String, rcall func(FunctionCall) Value, pattern *regexpPattern) Value {
 	if len(found) == 0 {
 		return s
 	}
 
 	a, u := devirtualizeString(s)
+	substring := func(start, end int) String {
+		if u == nil {
+			return a[start:end]
+		}
+		return u.Substring(start, end)
+	}
+	hasNamedGroups := pattern != nil && pattern.groupNames != nil
 
 	var buf StringBuilder
 
@@ -612,21 +634,22 @@ func stringReplace(s String, found [][]int, newstring String, rcall func(Functio
 				buf.WriteSubstring(s, lastIndex, item[0])
 			}
 			matchCount := len(item) / 2
-			argumentList := make([]Value, matchCount+2)
+			argumentList := make([]Value, matchCount+2, matchCount+3)
 			for index := 0; index < matchCount; index++ {
 				offset := 2 * index
 				if item[offset] != -1 {
-					if u == nil {
-						argumentList[index] = a[item[offset]:item[offset+1]]
-					} else {
-						argumentList[index] = u.Substring(item[offset], item[offset+1])
-					}
+					argumentList[index] = substring(item[offset], item[offset+1])
 				} else {
 					argumentList[index] = _undefined
 				}
 			}
 			argumentList[matchCount] = valueInt(item[0])
 			argumentList[matchCount+1] = s
+			if hasNamedGroups {
+				argumentList = append(argumentList, pattern.newGroupsObject(r, func(idx int) Value {
+					return argumentList[idx]
+				}))
+			}
 			replacement := rcall(FunctionCall{
 				This:      _undefined,
 				Arguments: argumentList,
@@ -640,15 +663,25 @@ func stringReplace(s String, found [][]int, newstring String, rcall func(Functio
 				buf.WriteString(s.Substring(lastIndex, item[0]))
 			}
 			matchCount := len(item) / 2
-			writeSubstitution(s, item[0], matchCount, func(idx int) String {
+			getCapture := func(idx int) String {
 				if item[idx*2] != -1 {
-					if u == nil {
-						return a[item[idx*2]:item[idx*2+1]]
-					}
-					return u.Substring(item[idx*2], item[idx*2+1])
+					return substring(item[idx*2], item[idx*2+1])
 				}
 				return stringEmpty
-			}, newstring, &buf)
+			}
+			var getNamedCapture func(String) String
+			if hasNamedGroups {
+				getNamedCapture = func(name String) String {
+					nameStr := name.String()
+					for idx, n := range pattern.groupNames {
+						if n == nameStr && item[idx*2] != -1 {
+							return getCapture(idx)
+						}
+					}
+					return stringEmpty
+				}
+			}
+			writeSubstitution(s, item[0], matchCount, getCapture, getNamedCapture, newstring, &buf)
 			lastIndex = item[1]
 		}
 	}
@@ -682,7 +715,7 @@ func (r *Runtime) stringproto_replace(call FunctionCall) Value {
 	}
 
 	str, rcall := getReplaceValue(replaceValue)
-	return stringReplace(s, found, str, rcall)
+	return r.stringReplace(s, found, str, rcall, nil)
 }
 
 func (r *Runtime) stringproto_replaceAll(call FunctionCall) Value {
@@ -720,7 +753,7 @@ func (r *Runtime) stringproto_replaceAll(call FunctionCall) Value {
 	}
 
 	str, rcall := getReplaceValue(replaceValue)
-	return stringReplace(s, found, str, rcall)
+	return r.stringReplace(s, found, str, rcall, nil)
 }
 
 func (r *Runtime) stringproto_search(call FunctionCall) Value {
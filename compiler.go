@@ -3,7 +3,9 @@ package goja
 import (
 	"fmt"
 	"github.com/dop251/goja/token"
+	"math"
 	"sort"
+	"sync/atomic"
 
 	"github.com/dop251/goja/ast"
 	"github.com/dop251/goja/file"
@@ -44,6 +46,10 @@ const (
 
 const thisBindingName = " this" // must not be a valid identifier
 
+// disposeCapabilityBindingName is the name of the hidden binding holding the dispose capability of a
+// scope with 'using' declarations.
+const disposeCapabilityBindingName = " dispose" // must not be a valid identifier
+
 type CompilerError struct {
 	Message string
 	File    *file.File
@@ -72,6 +78,39 @@ type Program struct {
 	funcName unistring.String
 	src      *file.File
 	srcMap   []srcMapItem
+
+	// Variables allocated on the stack, for the debugger
+	stackVars []stackVar
+
+	// The declarations of the variables and the Program of the enclosing function, for the debugger to
+	// find the names a source map gives to the variables. Only recorded with the debug information.
+	varDecls []varDecl
+	outer    *Program
+
+	// The first instruction and the position of each statement, for the coverage. Only recorded with the
+	// debug information, see compiler.debugInfo.
+	stmts []srcMapItem
+
+	gasCosts atomic.Pointer[programGasCosts] // The gas charged for each instruction, see meter.instructionCosts
+}
+
+// stackVar describes a variable that lives on the VM stack rather than in a stash. It's addressed the
+// same way as by the loadStack (or loadStack1, if stack1 is set) instruction with index idx and is in
+// scope for the instructions in [start, end). flags are the ones it would have in a stash names map.
+type stackVar struct {
+	name       unistring.String
+	idx        int
+	start, end int
+	stack1     bool
+	flags      uint32
+}
+
+// varDecl is the declaration of a variable: offset is the position of the identifier declaring it. Like
+// for a stackVar, the variable is in scope for the instructions in [start, end).
+type varDecl struct {
+	name       unistring.String
+	offset     int
+	start, end int
 }
 
 type compiler struct {
@@ -86,11 +125,14 @@ type compiler struct {
 	evalVM *vm // VM used to evaluate constant expressions
 	ctxVM  *vm // VM in which an eval() code is compiled
 
+	module *ModuleRecord // Module being compiled, the referrer of import() calls
+
 	codeScratchpad []instruction
 
 	stringCache map[unistring.String]Value
 	
 	debugMode bool // Force all variables to stash for debugging
+	debugInfo bool // Record the names of the stash and stack variables and the statements, for the debugger and the coverage
 }
 
 type binding struct {
@@ -102,6 +144,15 @@ type binding struct {
 	isArg        bool
 	isVar        bool
 	inStash      bool
+	offset       int // The position of the identifier declaring the binding, -1 if there is none
+}
+
+// declare records the position of the identifier declaring the binding, the first one if it's declared
+// more than once (e.g. a var)
+func (b *binding) declare(offset int) {
+	if b != nil && b.offset < 0 {
+		b.offset = offset
+	}
 }
 
 func (b *binding) getAccessPointsForScope(s *scope) *[]int {
@@ -289,6 +340,7 @@ type scope struct {
 	boundNames map[unistring.String]*binding
 	bindings   []*binding
 	base       int
+	end        int // set when the scope is popped, 0 if it extends to the end of the program
 	numArgs    int
 
 	// function type. If not funcNone, this is a function or a top-level lexical environment
@@ -311,6 +363,8 @@ type scope struct {
 	argsInStash bool
 	// need 'arguments' object (functions only)
 	argsNeeded bool
+	// is an async generator (functions only)
+	asyncGenerator bool
 }
 
 type block struct {
@@ -322,6 +376,7 @@ type block struct {
 	outer      *block
 	breaking   *block // set when the 'finally' block is an empty break statement sequence
 	needResult bool
+	asyncIter  bool // blockLoopEnum of a 'for await' loop
 }
 
 func (c *compiler) leaveScopeBlock(enter *enterBlock) {
@@ -384,6 +439,7 @@ func (c *compiler) newBlockScope() {
 }
 
 func (c *compiler) popScope() {
+	c.scope.end = len(c.p.code)
 	c.scope = c.scope.outer
 }
 
@@ -418,6 +474,7 @@ func newCompilerWithDebugMode(debugMode bool) *compiler {
 	c := &compiler{
 		p:         &Program{},
 		debugMode: debugMode,
+		debugInfo: debugMode,
 	}
 
 	c.enumGetExpr.init(c, file.Idx(0))
@@ -481,6 +538,10 @@ func (p *Program) addSrcMap(srcPos int) {
 	p.srcMap = append(p.srcMap, srcMapItem{pc: len(p.code), srcPos: srcPos})
 }
 
+func (p *Program) addStatement(srcPos int) {
+	p.stmts = append(p.stmts, srcMapItem{pc: len(p.code), srcPos: srcPos})
+}
+
 func (s *scope) lookupName(name unistring.String) (binding *binding, noDynamics bool) {
 	noDynamics = true
 	toStash := false
@@ -500,7 +561,7 @@ func (s *scope) lookupName(name unistring.String) (binding *binding, noDynamics
 		if curScope.dynamic {
 			noDynamics = false
 		}
-		if name == "arguments" && curScope.funcType != funcNone && curScope.funcType != funcArrow {
+		if name == "arguments" && curScope.funcType != funcNone && curScope.funcType != funcArrow && curScope.funcType != funcModule {
 			if curScope.funcType == funcClsInit {
 				s.c.throwSyntaxError(0, "'arguments' is not allowed in class field initializer or static initialization block")
 			}
@@ -546,7 +607,8 @@ func (s *scope) addBinding(offset int) *binding {
 		s.c.throwSyntaxError(offset, "Too many variables")
 	}
 	b := &binding{
-		scope: s,
+		scope:  s,
+		offset: -1,
 	}
 	s.bindings = append(s.bindings, b)
 	return b
@@ -590,8 +652,9 @@ func (s *scope) bindNameShadow(name unistring.String) (*binding, bool) {
 
 	_, exists := s.boundNames[name]
 	b := &binding{
-		scope: s,
-		name:  name,
+		scope:  s,
+		name:   name,
+		offset: -1,
 	}
 	s.bindings = append(s.bindings, b)
 	s.ensureBoundNamesCreated()
@@ -633,6 +696,9 @@ func (s *scope) finaliseVarAlloc(stackOffset int) (stashSize, stackSize int) {
 		if b.name == thisBindingName {
 			this = true
 		}
+		if s.c.debugInfo && b.offset >= 0 {
+			s.addVarDecl(b)
+		}
 		if allInStash || b.inStash {
 			for scope, aps := range b.accessPoints {
 				var level uint32
@@ -723,6 +789,9 @@ func (s *scope) finaliseVarAlloc(stackOffset int) (stashSize, stackSize int) {
 					stackIdx++
 					idx = stackIdx + stackOffset
 				}
+				if s.c.debugInfo {
+					s.addStackVar(b, idx, argsInStash)
+				}
 			}
 			for scope, aps := range b.accessPoints {
 				var level int
@@ -828,6 +897,34 @@ func (s *scope) finaliseVarAlloc(stackOffset int) (stashSize, stackSize int) {
 	return stashIdx, stackIdx
 }
 
+func (s *scope) addStackVar(b *binding, idx int, stack1 bool) {
+	end := s.end
+	if end == 0 {
+		end = math.MaxInt32
+	}
+	s.prg.stackVars = append(s.prg.stackVars, stackVar{
+		name:   b.name,
+		idx:    idx,
+		start:  s.base,
+		end:    end,
+		stack1: stack1,
+		flags:  b.namesMapFlags(),
+	})
+}
+
+func (s *scope) addVarDecl(b *binding) {
+	end := s.end
+	if end == 0 {
+		end = math.MaxInt32
+	}
+	s.prg.varDecls = append(s.prg.varDecls, varDecl{
+		name:   b.name,
+		offset: b.offset,
+		start:  s.base,
+		end:    end,
+	})
+}
+
 func (s *scope) moveArgsToStash() {
 	for _, b := range s.bindings {
 		if !b.isArg {
@@ -856,6 +953,30 @@ func (s *scope) trimCode(delta int) {
 		for i := range srcMap {
 			srcMap[i].pc -= delta
 		}
+		stmts := s.c.p.stmts
+		for i := range stmts {
+			stmts[i].pc -= delta
+		}
+		stackVars := s.c.p.stackVars
+		for i := range stackVars {
+			v := &stackVars[i]
+			if v.start -= delta; v.start < 0 {
+				v.start = 0
+			}
+			if v.end != math.MaxInt32 {
+				v.end -= delta
+			}
+		}
+		varDecls := s.c.p.varDecls
+		for i := range varDecls {
+			v := &varDecls[i]
+			if v.start -= delta; v.start < 0 {
+				v.start = 0
+			}
+			if v.end != math.MaxInt32 {
+				v.end -= delta
+			}
+		}
 		s.adjustBase(-delta)
 	}
 }
@@ -867,6 +988,20 @@ func (s *scope) adjustBase(delta int) {
 	}
 }
 
+func (b *binding) namesMapFlags() uint32 {
+	var flags uint32
+	if b.isConst {
+		flags |= maskConst
+		if b.isStrict {
+			flags |= maskStrict
+		}
+	}
+	if b.isVar {
+		flags |= maskVar
+	}
+	return flags
+}
+
 func (s *scope) makeNamesMap() map[unistring.String]uint32 {
 	l := len(s.bindings)
 	if l == 0 {
@@ -874,17 +1009,26 @@ func (s *scope) makeNamesMap() map[unistring.String]uint32 {
 	}
 	names := make(map[unistring.String]uint32, l)
 	for i, b := range s.bindings {
-		idx := uint32(i)
-		if b.isConst {
-			idx |= maskConst
-			if b.isStrict {
-				idx |= maskStrict
+		names[b.name] = uint32(i) | b.namesMapFlags()
+	}
+	return names
+}
+
+// makeStashNamesMap is like makeNamesMap, but only includes the bindings that are allocated in the stash
+// (see finaliseVarAlloc). The names are not needed for non-dynamic scopes, the debugger uses them to find
+// the variables, so they are only made for those if the compiler records debug info.
+func (s *scope) makeStashNamesMap() map[unistring.String]uint32 {
+	allInStash := s.isDynamic() || s.c.debugMode
+	var names map[unistring.String]uint32
+	idx := uint32(0)
+	for _, b := range s.bindings {
+		if allInStash || b.inStash {
+			if names == nil {
+				names = make(map[unistring.String]uint32)
 			}
+			names[b.name] = idx | b.namesMapFlags()
+			idx++
 		}
-		if b.isVar {
-			idx |= maskVar
-		}
-		names[b.name] = idx
 	}
 	return names
 }
@@ -1051,7 +1195,8 @@ func (c *compiler) createFunctionBindings(funcs []*ast.FunctionDeclaration) {
 			hasNonStandard := false
 			for _, decl := range funcs {
 				if !decl.Function.Async && !decl.Function.Generator {
-					s.bindNameLexical(decl.Function.Name.Name, false, int(decl.Function.Name.Idx1())-1)
+					b, _ := s.bindNameLexical(decl.Function.Name.Name, false, int(decl.Function.Name.Idx1())-1)
+					b.declare(int(decl.Function.Name.Idx) - 1)
 				} else {
 					hasNonStandard = true
 				}
@@ -1059,18 +1204,21 @@ func (c *compiler) createFunctionBindings(funcs []*ast.FunctionDeclaration) {
 			if hasNonStandard {
 				for _, decl := range funcs {
 					if decl.Function.Async || decl.Function.Generator {
-						s.bindNameLexical(decl.Function.Name.Name, true, int(decl.Function.Name.Idx1())-1)
+						b, _ := s.bindNameLexical(decl.Function.Name.Name, true, int(decl.Function.Name.Idx1())-1)
+						b.declare(int(decl.Function.Name.Idx) - 1)
 					}
 				}
 			}
 		} else {
 			for _, decl := range funcs {
-				s.bindNameLexical(decl.Function.Name.Name, true, int(decl.Function.Name.Idx1())-1)
+				b, _ := s.bindNameLexical(decl.Function.Name.Name, true, int(decl.Function.Name.Idx1())-1)
+				b.declare(int(decl.Function.Name.Idx) - 1)
 			}
 		}
 	} else {
 		for _, decl := range funcs {
-			s.bindName(decl.Function.Name.Name)
+			b, _ := s.bindName(decl.Function.Name.Name)
+			b.declare(int(decl.Function.Name.Idx) - 1)
 		}
 	}
 }
@@ -1117,6 +1265,7 @@ func (c *compiler) createVarIdBinding(name unistring.String, offset int, inFunc
 	}
 	if !inFunc || name != "arguments" {
 		b, _ := c.scope.bindName(name)
+		b.declare(offset)
 		if c.debugMode && b != nil {
 			b.moveToStash()
 		}
@@ -1178,6 +1327,7 @@ func (c *compiler) createLexicalIdBinding(name unistring.String, isConst bool, o
 		c.checkIdentifierName(name, offset)
 	}
 	b, _ := c.scope.bindNameLexical(name, true, offset)
+	b.declare(offset)
 	if isConst {
 		b.isConst, b.isStrict = true, true
 	}
@@ -1203,6 +1353,7 @@ func (c *compiler) createLexicalIdBindingFuncBody(name unistring.String, isConst
 		}
 	}
 	b, _ := c.scope.bindNameLexical(name, true, offset)
+	b.declare(offset)
 	if isConst {
 		b.isConst, b.isStrict = true, true
 	}
@@ -1220,10 +1371,19 @@ func (c *compiler) createLexicalBinding(target ast.Expression, isConst bool) {
 
 func (c *compiler) createLexicalBindings(lex *ast.LexicalDeclaration) {
 	for _, d := range lex.List {
-		c.createLexicalBinding(d.Target, lex.Token == token.CONST)
+		c.createLexicalBinding(d.Target, lex.Token != token.LET)
+	}
+	if lex.Token == token.USING {
+		c.createDisposeCapabilityBinding(int(lex.Idx) - 1)
 	}
 }
 
+// createDisposeCapabilityBinding creates the hidden binding for the dispose capability of the current scope
+// (unless it already exists).
+func (c *compiler) createDisposeCapabilityBinding(offset int) {
+	c.scope.bindNameLexical(disposeCapabilityBindingName, false, offset)
+}
+
 func (c *compiler) compileLexicalDeclarations(list []ast.Statement, scopeDeclared bool) bool {
 	for _, st := range list {
 		if lex, ok := st.(*ast.LexicalDeclaration); ok {
@@ -1246,12 +1406,15 @@ func (c *compiler) compileLexicalDeclarations(list []ast.Statement, scopeDeclare
 func (c *compiler) compileLexicalDeclarationsFuncBody(list []ast.Statement, calleeBinding *binding) {
 	for _, st := range list {
 		if lex, ok := st.(*ast.LexicalDeclaration); ok {
-			isConst := lex.Token == token.CONST
+			isConst := lex.Token != token.LET
 			for _, d := range lex.List {
 				c.createBindings(d.Target, func(name unistring.String, offset int) {
 					c.createLexicalIdBindingFuncBody(name, isConst, offset, calleeBinding)
 				})
 			}
+			if lex.Token == token.USING {
+				c.createDisposeCapabilityBinding(int(lex.Idx) - 1)
+			}
 		} else if cls, ok := st.(*ast.ClassDeclaration); ok {
 			c.createLexicalIdBindingFuncBody(cls.Class.Name.Name, false, int(cls.Class.Name.Idx)-1, calleeBinding)
 		}
@@ -136,6 +136,7 @@ const (
 	funcClsInit
 	funcCtor
 	funcDerivedCtor
+	funcModule
 )
 
 type compiledFunctionLiteral struct {
@@ -302,6 +303,8 @@ func (c *compiler) compileExpression(v ast.Expression) compiledExpr {
 		return c.compileNewExpression(v)
 	case *ast.MetaProperty:
 		return c.compileMetaProperty(v)
+	case *ast.ImportCall:
+		return c.compileImportCall(v)
 	case *ast.ObjectPattern:
 		return c.compileObjectAssignmentPattern(v)
 	case *ast.ArrayPattern:
@@ -1381,7 +1384,9 @@ func (c *compiler) compileParameterBindingIdentifier(name unistring.String, offs
 		c.checkIdentifierName(name, offset)
 		c.checkIdentifierLName(name, offset)
 	}
-	return c.scope.bindNameShadow(name)
+	b, unique := c.scope.bindNameShadow(name)
+	b.declare(offset)
+	return b, unique
 }
 
 func (c *compiler) compileParameterPatternIdBinding(name unistring.String, offset int) {
@@ -1417,9 +1422,13 @@ func (e *compiledFunctionLiteral) compile() (prg *Program, name unistring.String
 		code:   e.c.newCode(preambleLen, 16),
 		srcMap: []srcMapItem{{srcPos: e.offset}},
 	}
+	if e.c.debugInfo {
+		e.c.p.outer = savedPrg
+	}
 	e.c.newScope()
 	s := e.c.scope
 	s.funcType = e.typ
+	s.asyncGenerator = e.isAsync && e.isGenerator
 
 	if e.name != nil {
 		name = e.name.Name
@@ -1630,11 +1639,17 @@ func (e *compiledFunctionLiteral) compile() (prg *Program, name unistring.String
 	if e.isGenerator {
 		e.c.emit(yieldEmpty)
 	}
-	e.c.compileStatements(body, false)
-
 	var last ast.Statement
-	if l := len(body); l > 0 {
-		last = body[l-1]
+	if found, async := hasUsingDeclarations(body); found {
+		e.c.emitNewDisposeCapability()
+		e.c.compileDisposeBlock(async, func() {
+			e.c.compileStatements(body, false)
+		})
+	} else {
+		e.c.compileStatements(body, false)
+		if l := len(body); l > 0 {
+			last = body[l-1]
+		}
 	}
 	if _, ok := last.(*ast.ReturnStatement); !ok {
 		if e.typ == funcDerivedCtor {
@@ -1730,8 +1745,8 @@ func (e *compiledFunctionLiteral) compile() (prg *Program, name unistring.String
 				extensible:  s.dynamic,
 				funcType:    e.typ,
 			}
-			if s.isDynamic() || e.c.debugMode {
-				enter1.names = s.makeNamesMap()
+			if s.isDynamic() || e.c.debugInfo {
+				enter1.names = s.makeStashNamesMap()
 			}
 			enter = &enter1
 			if enterFunc2Mark != -1 {
@@ -1750,8 +1765,8 @@ func (e *compiledFunctionLiteral) compile() (prg *Program, name unistring.String
 				extensible: s.dynamic,
 				funcType:   e.typ,
 			}
-			if s.isDynamic() || e.c.debugMode {
-				enter1.names = s.makeNamesMap()
+			if s.isDynamic() || e.c.debugInfo {
+				enter1.names = s.makeStashNamesMap()
 			}
 			enter = &enter1
 			if enterFunc2Mark != -1 {
@@ -1808,6 +1823,10 @@ func (e *compiledFunctionLiteral) emitGetter(putOnStack bool) {
 		}
 	case funcMethod, funcClsInit:
 		if e.isAsync {
+			if e.isGenerator {
+				e.c.emit(&newAsyncGeneratorMethod{newMethod: newMethod{newFunc: newFunc{prg: p, length: length, name: name, source: e.source, strict: strict}, homeObjOffset: e.homeObjOffset}})
+				break
+			}
 			e.c.emit(&newAsyncMethod{newMethod: newMethod{newFunc: newFunc{prg: p, length: length, name: name, source: e.source, strict: strict}, homeObjOffset: e.homeObjOffset}})
 		} else {
 			if e.isGenerator {
@@ -1818,6 +1837,10 @@ func (e *compiledFunctionLiteral) emitGetter(putOnStack bool) {
 		}
 	case funcRegular:
 		if e.isAsync {
+			if e.isGenerator {
+				e.c.emit(&newAsyncGeneratorFunc{newFunc: newFunc{prg: p, length: length, name: name, source: e.source, strict: strict}})
+				break
+			}
 			e.c.emit(&newAsyncFunc{newFunc: newFunc{prg: p, length: length, name: name, source: e.source, strict: strict}})
 		} else {
 			if e.isGenerator {
@@ -1840,9 +1863,6 @@ func (c *compiler) compileFunctionLiteral(v *ast.FunctionLiteral, isExpr bool) *
 		c.checkIdentifierName(v.Name.Name, int(v.Name.Idx)-1)
 		c.checkIdentifierLName(v.Name.Name, int(v.Name.Idx)-1)
 	}
-	if v.Async && v.Generator {
-		c.throwSyntaxError(int(v.Function)-1, "Async generators are not supported yet")
-	}
 	r := &compiledFunctionLiteral{
 		name:            v.Name,
 		parameterList:   v.ParameterList,
@@ -2251,6 +2271,9 @@ func (e *compiledClassLiteral) compileFieldsAndStaticBlocks(elements []clsElemen
 		funcName: funcName,
 		code:     e.c.newCode(2, 16),
 	}
+	if e.c.debugInfo {
+		e.c.p.outer = savedPrg
+	}
 
 	e.c.newScope()
 	s := e.c.scope
@@ -2447,7 +2470,7 @@ func (c *compiler) compileNewExpression(v *ast.NewExpression) compiledExpr {
 }
 
 func (e *compiledNewTarget) emitGetter(putOnStack bool) {
-	if s := e.c.scope.nearestThis(); s == nil || s.funcType == funcNone {
+	if s := e.c.scope.nearestThis(); s == nil || s.funcType == funcNone || s.funcType == funcModule {
 		e.c.throwSyntaxError(e.offset, "new.target expression is not allowed here")
 	}
 	if putOnStack {
@@ -2457,6 +2480,12 @@ func (e *compiledNewTarget) emitGetter(putOnStack bool) {
 }
 
 func (c *compiler) compileMetaProperty(v *ast.MetaProperty) compiledExpr {
+	if v.Meta.Name == "import" && v.Property.Name == "meta" {
+		r := &compiledImportMeta{}
+		r.init(c, v.Idx0())
+		c.bindImportMeta(int(v.Idx) - 1)
+		return r
+	}
 	if v.Meta.Name == "new" || v.Property.Name != "target" {
 		r := &compiledNewTarget{}
 		r.init(c, v.Idx0())
@@ -2645,7 +2674,7 @@ func (e *compiledConditionalExpr) emitGetter(putOnStack bool) {
 	e.consequent.emitGetter(putOnStack)
 	j1 := len(e.c.p.code)
 	e.c.emit(nil)
-	e.c.p.code[j] = jneP(len(e.c.p.code) - j)
+	e.c.p.code[j] = jcond(len(e.c.p.code) - j)
 	e.alternate.emitGetter(putOnStack)
 	e.c.p.code[j1] = jump(len(e.c.p.code) - j1)
 }
@@ -3644,6 +3673,10 @@ func (e *compiledYieldExpression) emitGetter(putOnStack bool) {
 	} else {
 		e.c.emit(loadUndef)
 	}
+	if s := e.c.scope.nearestFunction(); s != nil && s.asyncGenerator {
+		e.emitAsync(putOnStack)
+		return
+	}
 	if putOnStack {
 		if e.delegate {
 			e.c.emit(yieldDelegateRes)
@@ -3658,3 +3691,22 @@ func (e *compiledYieldExpression) emitGetter(putOnStack bool) {
 		}
 	}
 }
+
+// emitAsync emits a yield in an async generator. The value of a yield is awaited. When the generator is
+// resumed with return() the (awaited) value is returned from the point of the yield, so the code that
+// follows the yield includes the return sequence.
+func (e *compiledYieldExpression) emitAsync(putOnStack bool) {
+	c := e.c
+	if e.delegate {
+		c.emit(yieldDelegateRes)
+	} else {
+		c.emit(await, yieldRes)
+	}
+	lbl := len(c.p.code)
+	c.emit(nil, await)
+	c.emitReturn(e.offset)
+	c.p.code[lbl] = asyncGenResume(len(c.p.code) - lbl)
+	if !putOnStack {
+		c.emit(pop)
+	}
+}
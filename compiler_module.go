@@ -0,0 +1,315 @@
+package goja
+
+import (
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/unistring"
+)
+
+const (
+	// The name of the binding holding the value of 'export default <expression>' and of anonymous default
+	// function and class declarations.
+	defaultExportBindingName unistring.String = "*default*"
+	// The name of the binding holding the import.meta object of the module.
+	importMetaBindingName unistring.String = " import.meta"
+)
+
+type compiledImportMeta struct {
+	baseCompiledExpr
+}
+
+func (e *compiledImportMeta) emitGetter(putOnStack bool) {
+	if putOnStack {
+		e.addSrcMap()
+		b, _ := e.c.scope.lookupName(importMetaBindingName)
+		b.emitGet()
+	}
+}
+
+type compiledImportCall struct {
+	baseCompiledExpr
+	argument compiledExpr
+}
+
+func (e *compiledImportCall) emitGetter(putOnStack bool) {
+	e.argument.emitGetter(true)
+	e.addSrcMap()
+	e.c.emit(&importCall{referrer: e.c.module})
+	if !putOnStack {
+		e.c.emit(pop)
+	}
+}
+
+func (c *compiler) compileImportCall(v *ast.ImportCall) compiledExpr {
+	r := &compiledImportCall{
+		argument: c.compileExpression(v.Argument),
+	}
+	r.init(c, v.Idx0())
+	return r
+}
+
+// bindImportMeta creates the binding for the import.meta object in the enclosing module scope.
+func (c *compiler) bindImportMeta(offset int) {
+	for s := c.scope; s != nil; s = s.outer {
+		if s.funcType == funcModule && !s.eval {
+			b, _ := s.bindNameLexical(importMetaBindingName, false, offset)
+			b.isVar = true
+			return
+		}
+	}
+	c.throwSyntaxError(offset, "Cannot use 'import.meta' outside a module")
+}
+
+func (c *compiler) compileModule(in *ast.Program, m *ModuleRecord) {
+	c.module = m
+	c.p.src = in.File
+	c.newScope()
+	c.scope.strict = true
+	c.newScope()
+	scope := c.scope
+	scope.funcType = funcModule
+	scope.variable = true
+	scope.dynLookup = true
+	scope.createThisBinding()
+
+	var list []ast.Statement
+	var defaultFunc *ast.FunctionLiteral
+	needDefaultBinding := false
+	var importOffsets, exportOffsets []int
+	exported := make(map[unistring.String]struct{})
+	addExportName := func(name unistring.String, offset int) {
+		if _, exists := exported[name]; exists {
+			c.throwSyntaxError(offset, "Duplicate export of '%s'", name)
+		}
+		exported[name] = struct{}{}
+	}
+	addLocalExport := func(exportName, localName unistring.String, offset int) {
+		addExportName(exportName, offset)
+		exportOffsets = append(exportOffsets, offset)
+		m.localExports = append(m.localExports, localExport{
+			exportName: exportName,
+			localName:  localName,
+		})
+	}
+	addRequest := func(spec *ast.StringLiteral) string {
+		specifier := spec.Value.String()
+		for _, s := range m.requestedModules {
+			if s == specifier {
+				return specifier
+			}
+		}
+		m.requestedModules = append(m.requestedModules, specifier)
+		return specifier
+	}
+
+	for _, st := range in.Body {
+		switch st := st.(type) {
+		case *ast.ImportDeclaration:
+			specifier := addRequest(st.ModuleSpecifier)
+			if st.Default != nil {
+				importOffsets = append(importOffsets, int(st.Default.Idx)-1)
+				m.importEntries = append(m.importEntries, importEntry{
+					moduleRequest: specifier,
+					importName:    "default",
+					localName:     st.Default.Name,
+				})
+			}
+			if st.Namespace != nil {
+				importOffsets = append(importOffsets, int(st.Namespace.Idx)-1)
+				m.importEntries = append(m.importEntries, importEntry{
+					moduleRequest: specifier,
+					localName:     st.Namespace.Name,
+					namespace:     true,
+				})
+			}
+			for _, spec := range st.Specifiers {
+				importOffsets = append(importOffsets, int(spec.Local.Idx)-1)
+				m.importEntries = append(m.importEntries, importEntry{
+					moduleRequest: specifier,
+					importName:    spec.ImportName.Name,
+					localName:     spec.Local.Name,
+				})
+			}
+		case *ast.ExportDeclaration:
+			switch {
+			case st.Star:
+				specifier := addRequest(st.ModuleSpecifier)
+				if st.Namespace != nil {
+					addExportName(st.Namespace.Name, int(st.Namespace.Idx)-1)
+					m.indirectExports = append(m.indirectExports, indirectExport{
+						exportName:    st.Namespace.Name,
+						moduleRequest: specifier,
+						namespace:     true,
+					})
+				} else {
+					m.starExports = append(m.starExports, specifier)
+				}
+			case st.ModuleSpecifier != nil:
+				specifier := addRequest(st.ModuleSpecifier)
+				for _, spec := range st.Specifiers {
+					addExportName(spec.Exported.Name, int(spec.Exported.Idx)-1)
+					m.indirectExports = append(m.indirectExports, indirectExport{
+						exportName:    spec.Exported.Name,
+						moduleRequest: specifier,
+						importName:    spec.Local.Name,
+					})
+				}
+			case st.Expression != nil:
+				addLocalExport("default", defaultExportBindingName, int(st.Export)-1)
+				needDefaultBinding = true
+			case st.Declaration != nil:
+				switch decl := st.Declaration.(type) {
+				case *ast.VariableStatement:
+					for _, item := range decl.List {
+						c.createBindings(item.Target, func(name unistring.String, offset int) {
+							addLocalExport(name, name, offset)
+						})
+					}
+				case *ast.LexicalDeclaration:
+					for _, item := range decl.List {
+						c.createBindings(item.Target, func(name unistring.String, offset int) {
+							addLocalExport(name, name, offset)
+						})
+					}
+				case *ast.FunctionDeclaration:
+					if name := decl.Function.Name; name != nil {
+						if st.Default {
+							addLocalExport("default", name.Name, int(st.Export)-1)
+						} else {
+							addLocalExport(name.Name, name.Name, int(name.Idx)-1)
+						}
+					} else {
+						addLocalExport("default", defaultExportBindingName, int(st.Export)-1)
+						defaultFunc = decl.Function
+						needDefaultBinding = true
+						continue
+					}
+				case *ast.ClassDeclaration:
+					if name := decl.Class.Name; name != nil {
+						if st.Default {
+							addLocalExport("default", name.Name, int(st.Export)-1)
+						} else {
+							addLocalExport(name.Name, name.Name, int(name.Idx)-1)
+						}
+					} else {
+						addLocalExport("default", defaultExportBindingName, int(st.Export)-1)
+						needDefaultBinding = true
+						continue
+					}
+				}
+				list = append(list, st.Declaration)
+			default:
+				for _, spec := range st.Specifiers {
+					addLocalExport(spec.Exported.Name, spec.Local.Name, int(spec.Exported.Idx)-1)
+				}
+			}
+		default:
+			list = append(list, st)
+		}
+	}
+
+	c.compileDeclList(in.DeclarationList, false)
+	funcs := c.extractFunctions(list)
+	c.createFunctionBindings(funcs)
+	c.compileLexicalDeclarations(list, true)
+	if needDefaultBinding {
+		scope.bindNameLexical(defaultExportBindingName, true, 0)
+	}
+
+	m.importNames = make(map[unistring.String]uint32, len(m.importEntries))
+	for i, e := range m.importEntries {
+		offset := importOffsets[i]
+		if _, exists := scope.boundNames[e.localName]; exists {
+			c.throwSyntaxError(offset, "Identifier '%s' has already been declared", e.localName)
+		}
+		if _, exists := m.importNames[e.localName]; exists {
+			c.throwSyntaxError(offset, "Identifier '%s' has already been declared", e.localName)
+		}
+		c.checkIdentifierLName(e.localName, offset)
+		c.checkIdentifierName(e.localName, offset)
+		m.importNames[e.localName] = uint32(i) | maskConst | maskStrict
+	}
+
+	// Exports of imported bindings are re-exports of the imported modules
+	localExports := m.localExports[:0]
+	for i, e := range m.localExports {
+		if _, exists := scope.boundNames[e.localName]; exists {
+			localExports = append(localExports, e)
+			continue
+		}
+		idx, exists := m.importNames[e.localName]
+		if !exists {
+			c.throwSyntaxError(exportOffsets[i], "Export '%s' is not defined in module", e.localName)
+		}
+		ie := m.importEntries[idx&^maskTyp]
+		m.indirectExports = append(m.indirectExports, indirectExport{
+			exportName:    e.exportName,
+			moduleRequest: ie.moduleRequest,
+			importName:    ie.importName,
+			namespace:     ie.namespace,
+		})
+	}
+	m.localExports = localExports
+
+	if found, async := hasUsingDeclarations(in.Body); found {
+		c.emitNewDisposeCapability()
+		c.compileDisposeBlock(async, func() {
+			c.compileStatements(in.Body, false)
+		})
+	} else {
+		c.compileStatements(in.Body, false)
+	}
+
+	// The code of the functions is not part of the module code, so any await in it is a top-level one
+	for _, ins := range c.p.code {
+		if ins == await {
+			m.hasTLA = true
+			// The code runs as the body of an async function, see executeAsyncModule
+			c.emit(loadUndef, ret)
+			break
+		}
+	}
+
+	// The functions are created when the module is linked, so that they can be called by the modules
+	// importing it before it's evaluated
+	jmp := len(c.p.code)
+	c.emit(nil)
+	m.initPc = len(c.p.code)
+	c.compileFunctions(funcs)
+	if defaultFunc != nil {
+		c.emitNamed(c.compileFunctionLiteral(defaultFunc, false), "default")
+		scope.boundNames[defaultExportBindingName].emitInitP()
+	}
+	c.p.code[jmp] = jump(len(c.p.code) - jmp)
+
+	stashSize, _ := scope.finaliseVarAlloc(0)
+	m.stashSize = stashSize
+	m.names = scope.makeStashNamesMap()
+	for i := range m.localExports {
+		e := &m.localExports[i]
+		e.idx = m.names[e.localName]
+	}
+	m.prg = c.p
+	c.stringCache = nil
+}
+
+func (c *compiler) compileExportDeclaration(v *ast.ExportDeclaration) {
+	switch decl := v.Declaration.(type) {
+	case nil:
+		if v.Expression != nil {
+			c.emitNamedOrConst(c.compileExpression(v.Expression), "default")
+			c.p.addSrcMap(int(v.Expression.Idx0()) - 1)
+			c.scope.boundNames[defaultExportBindingName].emitInitP()
+		}
+	case *ast.FunctionDeclaration:
+		// hoisted, see compileModule
+	case *ast.ClassDeclaration:
+		if decl.Class.Name == nil {
+			c.emitNamed(c.compileClassLiteral(decl.Class, false), "default")
+			c.scope.boundNames[defaultExportBindingName].emitInitP()
+		} else {
+			c.compileClassDeclaration(decl)
+		}
+	default:
+		c.compileStatement(decl, false)
+	}
+}
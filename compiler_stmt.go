@@ -8,6 +8,14 @@ import (
 )
 
 func (c *compiler) compileStatement(v ast.Statement, needResult bool) {
+	if c.debugInfo {
+		switch v.(type) {
+		case *ast.BlockStatement, *ast.EmptyStatement, *ast.LabelledStatement, *ast.FunctionDeclaration, *ast.ImportDeclaration:
+			// Only their content is executed
+		default:
+			c.p.addStatement(int(v.Idx0()) - 1)
+		}
+	}
 
 	switch v := v.(type) {
 	case *ast.BlockStatement:
@@ -53,6 +61,10 @@ func (c *compiler) compileStatement(v ast.Statement, needResult bool) {
 		c.compileWithStatement(v, needResult)
 	case *ast.DebuggerStatement:
 		c.emit(debugger)
+	case *ast.ImportDeclaration:
+		// the imports are bound when the module is linked
+	case *ast.ExportDeclaration:
+		c.compileExportDeclaration(v)
 	default:
 		c.assert(false, int(v.Idx0())-1, "Unknown statement type: %T", v)
 		panic("unreachable")
@@ -99,6 +111,9 @@ func (c *compiler) updateEnterBlock(enter *enterBlock) {
 				stackSize++
 			}
 		}
+		if stashSize > 0 && c.debugInfo {
+			enter.names = scope.makeStashNamesMap()
+		}
 	}
 	enter.stashSize, enter.stackSize = uint32(stashSize), uint32(stackSize)
 }
@@ -152,7 +167,8 @@ func (c *compiler) compileTryStatement(v *ast.TryStatement, needResult bool) {
 						c.throwSyntaxError(offset, "Catch variable may not be eval or arguments in strict mode")
 					}
 				}
-				c.scope.bindNameLexical(name, true, offset)
+				b, _ := c.scope.bindNameLexical(name, true, offset)
+				b.declare(offset)
 			})
 			enter := &enterBlock{}
 			c.emit(enter)
@@ -163,7 +179,8 @@ func (c *compiler) compileTryStatement(v *ast.TryStatement, needResult bool) {
 				}, false)
 			}
 			for _, decl := range funcs {
-				c.scope.bindNameLexical(decl.Function.Name.Name, true, int(decl.Function.Name.Idx1())-1)
+				b, _ := c.scope.bindNameLexical(decl.Function.Name.Name, true, int(decl.Function.Name.Idx1())-1)
+				b.declare(int(decl.Function.Name.Idx) - 1)
 			}
 			c.compileLexicalDeclarations(list, true)
 			c.compileFunctions(funcs)
@@ -201,6 +218,49 @@ func (c *compiler) compileTryStatement(v *ast.TryStatement, needResult bool) {
 	c.leaveBlock()
 }
 
+// hasUsingDeclarations reports whether the statement list directly contains 'using' declarations and
+// whether any of them is an 'await using' declaration.
+func hasUsingDeclarations(list []ast.Statement) (found, async bool) {
+	for _, st := range list {
+		if lex, ok := st.(*ast.LexicalDeclaration); ok && lex.Token == token.USING {
+			found = true
+			if lex.Await {
+				async = true
+			}
+		}
+	}
+	return
+}
+
+// emitNewDisposeCapability initialises the dispose capability binding of the current scope.
+func (c *compiler) emitNewDisposeCapability() {
+	c.emit(newDisposeCapability)
+	c.scope.boundNames[disposeCapabilityBindingName].emitInitP()
+}
+
+// compileDisposeBlock compiles the body of a scope with 'using' declarations. The body is wrapped into
+// a try block, the resources held by the dispose capability of the scope are disposed of in its 'finally'
+// block (asynchronously if async is set).
+func (c *compiler) compileDisposeBlock(async bool, body func()) {
+	c.block = &block{
+		typ:   blockTry,
+		outer: c.block,
+	}
+	lbl := len(c.p.code)
+	c.emit(nil)
+	body()
+	c.emit(enterFinally{})
+	finallyOffset := len(c.p.code) - lbl
+	c.scope.boundNames[disposeCapabilityBindingName].emitGet()
+	c.emit(disposeResources(async))
+	if async {
+		c.emit(await, pop)
+	}
+	c.emit(leaveFinally{})
+	c.p.code[lbl] = try{finallyOffset: int32(finallyOffset)}
+	c.leaveBlock()
+}
+
 func (c *compiler) addSrcMap(node ast.Node) {
 	c.p.addSrcMap(int(node.Idx0()) - 1)
 }
@@ -251,6 +311,10 @@ func (c *compiler) compileForHeadLexDecl(decl *ast.LexicalDeclaration, needResul
 }
 
 func (c *compiler) compileLabeledForStatement(v *ast.ForStatement, needResult bool, label unistring.String) {
+	if init, ok := v.Initializer.(*ast.ForLoopInitializerLexicalDecl); ok && init.LexicalDeclaration.Token == token.USING {
+		c.compileForUsingStatement(v, &init.LexicalDeclaration, needResult, label)
+		return
+	}
 	loopBlock := &block{
 		typ:        blockLoop,
 		outer:      c.block,
@@ -351,6 +415,33 @@ end:
 	c.leaveBlock()
 }
 
+// compileForUsingStatement compiles a 'for' loop with a 'using' declaration in its head. The bindings are
+// constant, so there is no need to copy them for each iteration, and the resources are disposed of when
+// the loop is finished.
+func (c *compiler) compileForUsingStatement(v *ast.ForStatement, decl *ast.LexicalDeclaration, needResult bool, label unistring.String) {
+	c.block = &block{
+		typ:        blockScope,
+		outer:      c.block,
+		needResult: needResult,
+	}
+	c.newBlockScope()
+	enter := &enterBlock{}
+	c.emit(enter)
+	c.createLexicalBindings(decl)
+	c.emitNewDisposeCapability()
+	c.compileDisposeBlock(decl.Await, func() {
+		c.compileLexicalDeclaration(decl)
+		c.compileLabeledForStatement(&ast.ForStatement{
+			For:    v.For,
+			Test:   v.Test,
+			Update: v.Update,
+			Body:   v.Body,
+		}, needResult, label)
+	})
+	c.leaveScopeBlock(enter)
+	c.popScope()
+}
+
 func (c *compiler) compileForInStatement(v *ast.ForInStatement, needResult bool) {
 	c.compileLabeledForInStatement(v, needResult, "")
 }
@@ -386,7 +477,14 @@ func (c *compiler) compileForInto(into ast.ForInto, needResult bool) (enter *ent
 		switch target := into.Target.(type) {
 		case *ast.Identifier:
 			b := c.createLexicalIdBinding(target.Name, into.IsConst, int(into.Idx)-1)
-			c.emit(enumGet)
+			if into.Using {
+				c.createDisposeCapabilityBinding(int(into.Idx) - 1)
+				c.emitNewDisposeCapability()
+				c.emit(enumGet)
+				c.emitAddDisposableResource(into.Await)
+			} else {
+				c.emit(enumGet)
+			}
 			b.emitInitP()
 		case ast.Pattern:
 			c.createLexicalBinding(target, into.IsConst)
@@ -412,6 +510,53 @@ func (c *compiler) compileLabeledForInOfStatement(into ast.ForInto, source ast.E
 		label:      label,
 		needResult: needResult,
 	}
+	c.compileForInOfSource(into, source)
+	if iter {
+		c.emit(iterateP)
+	} else {
+		c.emit(enumerate)
+	}
+	if needResult {
+		c.emit(clearResult)
+	}
+	start := len(c.p.code)
+	c.block.cont = start
+	c.emit(nil)
+	enterIterBlock := c.compileForInto(into, needResult)
+	if needResult {
+		c.emit(clearResult)
+	}
+	c.compileForBody(into, body, needResult)
+	if enterIterBlock != nil {
+		c.leaveScopeBlock(enterIterBlock)
+		c.popScope()
+	}
+	c.emit(jump(start - len(c.p.code)))
+	if iter {
+		c.p.code[start] = iterNext(len(c.p.code) - start)
+	} else {
+		c.p.code[start] = enumNext(len(c.p.code) - start)
+	}
+	c.emit(enumPop, jump(2))
+	c.leaveBlock()
+	c.emit(enumPopClose)
+}
+
+// compileForBody compiles the body of a for-in or for-of loop. If the loop declares a 'using' binding, the
+// resource is disposed of at the end of each iteration.
+func (c *compiler) compileForBody(into ast.ForInto, body ast.Statement, needResult bool) {
+	if forDecl, ok := into.(*ast.ForDeclaration); ok && forDecl.Using {
+		c.compileDisposeBlock(forDecl.Await, func() {
+			c.compileStatement(body, needResult)
+		})
+		return
+	}
+	c.compileStatement(body, needResult)
+}
+
+// compileForInOfSource emits the source expression of a for-in or for-of loop. If the loop declares
+// lexical bindings, the expression is evaluated in a scope where they are in TDZ.
+func (c *compiler) compileForInOfSource(into ast.ForInto, source ast.Expression) {
 	enterPos := -1
 	if forDecl, ok := into.(*ast.ForDeclaration); ok {
 		c.block = &block{
@@ -450,35 +595,51 @@ func (c *compiler) compileLabeledForInOfStatement(into ast.ForInto, source ast.E
 		}
 		c.popScope()
 	}
-	if iter {
-		c.emit(iterateP)
-	} else {
-		c.emit(enumerate)
+}
+
+// compileLabeledForAwaitStatement compiles a 'for await' loop. The body is wrapped into a try block, so
+// that the iterator can be closed (asynchronously) when the body throws.
+func (c *compiler) compileLabeledForAwaitStatement(into ast.ForInto, source ast.Expression, body ast.Statement, needResult bool, label unistring.String) {
+	c.block = &block{
+		typ:        blockLoopEnum,
+		outer:      c.block,
+		label:      label,
+		needResult: needResult,
+		asyncIter:  true,
 	}
+	c.compileForInOfSource(into, source)
+	c.emit(iterateAsyncP)
 	if needResult {
 		c.emit(clearResult)
 	}
 	start := len(c.p.code)
 	c.block.cont = start
+	c.emit(iterNextAsync, await, nil)
+	tryPos := len(c.p.code)
 	c.emit(nil)
+	c.block = &block{
+		typ:   blockTry,
+		outer: c.block,
+	}
 	enterIterBlock := c.compileForInto(into, needResult)
 	if needResult {
 		c.emit(clearResult)
 	}
-	c.compileStatement(body, needResult)
+	c.compileForBody(into, body, needResult)
 	if enterIterBlock != nil {
 		c.leaveScopeBlock(enterIterBlock)
 		c.popScope()
 	}
+	c.leaveBlock()
+	c.emit(leaveTry{})
 	c.emit(jump(start - len(c.p.code)))
-	if iter {
-		c.p.code[start] = iterNext(len(c.p.code) - start)
-	} else {
-		c.p.code[start] = enumNext(len(c.p.code) - start)
-	}
-	c.emit(enumPop, jump(2))
+	c.p.code[tryPos] = try{catchOffset: int32(len(c.p.code) - tryPos)}
+	// the exception is on the stack, close the iterator ignoring any errors and re-throw
+	c.emit(iterPopCloseAsyncQuiet(8), try{catchOffset: 5}, await, leaveTry{}, pop, jump(3), pop, pop, throw)
+	c.p.code[start+2] = iterResultAsync(len(c.p.code) - start - 2)
+	c.emit(enumPop, jump(4))
 	c.leaveBlock()
-	c.emit(enumPopClose)
+	c.emit(iterPopCloseAsync(3), await, iterCheckReturnResult)
 }
 
 func (c *compiler) compileLabeledForInStatement(v *ast.ForInStatement, needResult bool, label unistring.String) {
@@ -490,6 +651,10 @@ func (c *compiler) compileForOfStatement(v *ast.ForOfStatement, needResult bool)
 }
 
 func (c *compiler) compileLabeledForOfStatement(v *ast.ForOfStatement, needResult bool, label unistring.String) {
+	if v.Await {
+		c.compileLabeledForAwaitStatement(v.Into, v.Source, v.Body, needResult, label)
+		return
+	}
 	c.compileLabeledForInOfStatement(v.Into, v.Source, v.Body, true, needResult, label)
 }
 
@@ -641,12 +806,22 @@ L:
 		case blockWith:
 			c.emit(leaveWith)
 		case blockLoopEnum:
-			c.emit(enumPopClose)
+			c.emitIterClose(b)
 		}
 	}
 	return block
 }
 
+// emitIterClose emits the code that pops and closes the iterator of a for-in or for-of loop when the loop
+// is exited before it's finished. The iterator of a 'for await' loop is closed asynchronously.
+func (c *compiler) emitIterClose(b *block) {
+	if b.asyncIter {
+		c.emit(iterPopCloseAsync(3), await, iterCheckReturnResult)
+	} else {
+		c.emit(enumPopClose)
+	}
+}
+
 func (c *compiler) compileBreak(label *ast.Identifier, idx file.Idx) {
 	block := c.emitBlockExitCode(label, idx, true)
 	block.breaks = append(block.breaks, len(c.p.code))
@@ -734,20 +909,28 @@ func (c *compiler) compileReturnStatement(v *ast.ReturnStatement) {
 	}
 	if v.Argument != nil {
 		c.emitExpr(c.compileExpression(v.Argument), true)
+		if s := c.scope.nearestFunction(); s != nil && s.asyncGenerator {
+			c.emit(await)
+		}
 	} else {
 		c.emit(loadUndef)
 	}
+	c.emitReturn(int(v.Return) - 1)
+}
+
+// emitReturn emits the code that leaves all enclosing blocks and returns the value on top of the stack.
+func (c *compiler) emitReturn(offset int) {
 	for b := c.block; b != nil; b = b.outer {
 		switch b.typ {
 		case blockTry:
 			c.emit(saveResult, leaveTry{}, loadResult)
 		case blockLoopEnum:
-			c.emit(enumPopClose)
+			c.emitIterClose(b)
 		}
 	}
 	if s := c.scope.nearestFunction(); s != nil && s.funcType == funcDerivedCtor {
 		b := s.boundNames[thisBindingName]
-		c.assert(b != nil, int(v.Return)-1, "Derived constructor, but no 'this' binding")
+		c.assert(b != nil, offset, "Derived constructor, but no 'this' binding")
 		b.markAccessPoint()
 	}
 	c.emit(ret)
@@ -857,11 +1040,39 @@ func (c *compiler) compileVariableStatement(v *ast.VariableStatement) {
 }
 
 func (c *compiler) compileLexicalDeclaration(v *ast.LexicalDeclaration) {
+	if v.Token == token.USING {
+		for _, e := range v.List {
+			c.compileUsingBinding(e, v.Await)
+		}
+		return
+	}
 	for _, e := range v.List {
 		c.compileLexicalBinding(e)
 	}
 }
 
+// compileUsingBinding compiles a binding of a 'using' declaration. The value is added to the dispose
+// capability of the current scope before the binding is initialised.
+func (c *compiler) compileUsingBinding(expr *ast.Binding, async bool) {
+	target, ok := expr.Target.(*ast.Identifier)
+	if !ok {
+		c.throwSyntaxError(int(expr.Target.Idx0()-1), "unsupported using binding target: %T", expr.Target)
+		return
+	}
+	b := c.scope.boundNames[target.Name]
+	c.assert(b != nil, int(target.Idx)-1, "Using declaration for an unbound name")
+	c.emitNamedOrConst(c.compileExpression(expr.Initializer), target.Name)
+	c.p.addSrcMap(int(target.Idx) - 1)
+	c.emitAddDisposableResource(async)
+	b.emitInitP()
+}
+
+// emitAddDisposableResource adds the value on top of the stack to the dispose capability of the current scope.
+func (c *compiler) emitAddDisposableResource(async bool) {
+	c.scope.boundNames[disposeCapabilityBindingName].emitGet()
+	c.emit(addDisposableResource(async))
+}
+
 func (c *compiler) isEmptyResult(st ast.Statement) bool {
 	switch st := st.(type) {
 	case *ast.EmptyStatement, *ast.VariableStatement, *ast.LexicalDeclaration, *ast.FunctionDeclaration,
@@ -977,7 +1188,14 @@ func (c *compiler) compileBlockStatement(v *ast.BlockStatement, needResult bool)
 		c.emit(enter)
 	}
 	c.compileFunctions(funcs)
-	c.compileStatements(v.List, needResult)
+	if found, async := hasUsingDeclarations(v.List); found {
+		c.emitNewDisposeCapability()
+		c.compileDisposeBlock(async, func() {
+			c.compileStatements(v.List, needResult)
+		})
+	} else {
+		c.compileStatements(v.List, needResult)
+	}
 	if scopeDeclared {
 		c.leaveScopeBlock(enter)
 		c.popScope()
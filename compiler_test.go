@@ -5854,6 +5854,303 @@ func TestGeneratorMethods(t *testing.T) {
 	testScriptWithTestLib(SCRIPT, _undefined, t)
 }
 
+func TestAsyncGeneratorFunc(t *testing.T) {
+	const SCRIPT = `
+	let trace = "";
+	async function* g(param = trace += "1") {
+		trace += "2";
+		const x = yield Promise.resolve(1);
+		assert.sameValue(x, "next");
+		yield* [2, Promise.resolve(3)];
+		return Promise.resolve(4);
+	}
+	const iter = g();
+	assert.sameValue(trace, "1");
+	assert.sameValue(Object.prototype.toString.call(iter), "[object AsyncGenerator]");
+
+	let res = await iter.next();
+	assert.sameValue(trace, "12");
+	assert.sameValue(res.value, 1);
+	assert.sameValue(res.done, false);
+	res = await iter.next("next");
+	assert.sameValue(res.value, 2);
+	res = await iter.next();
+	assert.sameValue(res.value, 3, "yield* awaits the values of sync iterators");
+	res = await iter.next();
+	assert.sameValue(res.value, 4);
+	assert.sameValue(res.done, true);
+	res = await iter.next();
+	assert.sameValue(res.value, undefined);
+	assert.sameValue(res.done, true);
+
+	class C {
+		async *m() {
+			yield super.constructor.name;
+		}
+	}
+	res = await new C().m().next();
+	assert.sameValue(res.value, "Object");
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestAsyncGeneratorReturnThrow(t *testing.T) {
+	const SCRIPT = `
+	let trace = "";
+	async function* g() {
+		try {
+			yield 1;
+		} catch (e) {
+			trace += e;
+			yield 2;
+		}
+		try {
+			yield 3;
+		} finally {
+			trace += "f";
+			yield 4;
+			trace += "F";
+		}
+	}
+	let iter = g();
+	assert.sameValue((await iter.next()).value, 1);
+	assert.sameValue((await iter.throw("e")).value, 2);
+	assert.sameValue((await iter.next()).value, 3);
+
+	// requests are queued
+	const results = await Promise.all([iter.return(Promise.resolve("r")), iter.next(), iter.next()]);
+	assert.sameValue(trace, "efF");
+	assert.sameValue(results[0].value, 4);
+	assert.sameValue(results[0].done, false);
+	assert.sameValue(results[1].value, "r");
+	assert.sameValue(results[1].done, true);
+	assert.sameValue(results[2].done, true);
+
+	iter = g();
+	let res = await iter.return(Promise.resolve(42));
+	assert.sameValue(res.value, 42);
+	assert.sameValue(res.done, true);
+	try {
+		await iter.throw(new Error("boom"));
+		assert(false, "should have thrown");
+	} catch (e) {
+		assert.sameValue(e.message, "boom");
+	}
+
+	iter = (async function*() {
+		yield Promise.reject("rejected");
+	})();
+	try {
+		await iter.next();
+		assert(false, "should have thrown");
+	} catch (e) {
+		assert.sameValue(e, "rejected");
+	}
+	assert.sameValue((await iter.next()).done, true);
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestAsyncGeneratorDelegate(t *testing.T) {
+	const SCRIPT = `
+	let trace = "";
+	async function* inner() {
+		try {
+			const x = yield 1;
+			trace += x;
+			yield 2;
+		} finally {
+			trace += "f";
+		}
+		return "inner";
+	}
+	async function* outer() {
+		const r = yield* inner();
+		trace += r;
+	}
+	let iter = outer();
+	assert.sameValue((await iter.next()).value, 1);
+	assert.sameValue((await iter.next("x")).value, 2);
+	assert.sameValue((await iter.next()).done, true);
+	assert.sameValue(trace, "xfinner");
+
+	trace = "";
+	iter = outer();
+	await iter.next();
+	let res = await iter.return("r");
+	assert.sameValue(res.value, "r");
+	assert.sameValue(res.done, true);
+	assert.sameValue(trace, "f");
+
+	// the delegated iterator has no throw() method
+	let closed = false;
+	const noThrow = {
+		[Symbol.asyncIterator]() {
+			return {
+				next() {
+					return {value: 1, done: false};
+				},
+				return() {
+					closed = true;
+					return {};
+				}
+			};
+		}
+	};
+	iter = (async function*() {
+		yield* noThrow;
+	})();
+	await iter.next();
+	try {
+		await iter.throw(new Error());
+		assert(false, "should have thrown");
+	} catch (e) {
+		assert(e instanceof TypeError, "TypeError");
+	}
+	assert(closed, "closed");
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestForAwaitOf(t *testing.T) {
+	const SCRIPT = `
+	let trace = "";
+	for await (const x of [Promise.resolve("a"), "b"]) {
+		trace += x;
+	}
+	let v;
+	for await (v of (async function*() { yield "c"; yield "d"; })()) {
+		trace += v;
+	}
+	for await (const [a, b] of [["e", "f"]]) {
+		trace += a + b;
+	}
+	outer: for await (const x of ["g", "h"]) {
+		for (const y of [1, 2]) {
+			trace += x;
+			continue outer;
+		}
+	}
+	assert.sameValue(trace, "abcdefgh");
+
+	let i = 0;
+	const iterable = {
+		[Symbol.asyncIterator]() {
+			return {
+				next() {
+					return Promise.resolve({value: i++, done: i > 3});
+				}
+			};
+		}
+	};
+	const values = [];
+	for await (const x of iterable) {
+		values.push(x);
+	}
+	assert(compareArray(values, [0, 1, 2]), values);
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestForAwaitOfClose(t *testing.T) {
+	const SCRIPT = `
+	let closed = 0;
+	const iterable = {
+		[Symbol.asyncIterator]() {
+			return {
+				next() {
+					return {value: 1, done: false};
+				},
+				return() {
+					closed++;
+					return Promise.resolve({});
+				}
+			};
+		}
+	};
+	for await (const x of iterable) {
+		break;
+	}
+	assert.sameValue(closed, 1, "break");
+
+	try {
+		for await (const x of iterable) {
+			throw new Error("boom");
+		}
+	} catch (e) {
+		assert.sameValue(e.message, "boom");
+	}
+	assert.sameValue(closed, 2, "throw");
+
+	async function f() {
+		for await (const x of iterable) {
+			return x;
+		}
+	}
+	assert.sameValue(await f(), 1);
+	assert.sameValue(closed, 3, "return");
+
+	async function* g() {
+		for await (const x of iterable) {
+			yield x;
+		}
+	}
+	const iter = g();
+	await iter.next();
+	await iter.return();
+	assert.sameValue(closed, 4, "generator return");
+
+	const badReturn = {
+		[Symbol.asyncIterator]() {
+			return {
+				next() {
+					return {value: 1, done: false};
+				},
+				return() {
+					return 42;
+				}
+			};
+		}
+	};
+	try {
+		for await (const x of badReturn) {
+			break;
+		}
+		assert(false, "should have thrown");
+	} catch (e) {
+		assert(e instanceof TypeError, "TypeError");
+	}
+	try {
+		for await (const x of badReturn) {
+			throw new Error("boom");
+		}
+	} catch (e) {
+		assert.sameValue(e.message, "boom", "the error from return() is ignored");
+	}
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestAsyncGeneratorFunctionConstructor(t *testing.T) {
+	const SCRIPT = `
+	const AsyncGeneratorFunction = Object.getPrototypeOf(async function*() {}).constructor;
+	assert.sameValue(AsyncGeneratorFunction.name, "AsyncGeneratorFunction");
+	const f = new AsyncGeneratorFunction("a", "yield a; yield a * 2;");
+	const values = [];
+	for await (const x of f(5)) {
+		values.push(x);
+	}
+	assert(compareArray(values, [5, 10]), values);
+
+	const AsyncIteratorPrototype = Object.getPrototypeOf(AsyncGeneratorFunction.prototype.prototype);
+	assert.sameValue(AsyncIteratorPrototype[Symbol.asyncIterator].call(f), f);
+	assert.sameValue(Object.getPrototypeOf(f.prototype), AsyncGeneratorFunction.prototype.prototype);
+	assert.throws(TypeError, () => new f());
+	assert.sameValue(typeof Symbol.asyncIterator, "symbol");
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
 func TestFunctionBodyClassDecl(t *testing.T) {
 	const SCRIPT = `
 	function as(requiredArgument = {}) {
@@ -6021,6 +6318,163 @@ func TestWrongThisForwardRef(t *testing.T) {
 	testScript(SCRIPT, _undefined, t)
 }
 
+func TestUsingDeclarations(t *testing.T) {
+	const SCRIPT = `
+	let log = [];
+	function res(name, fail) {
+		return {
+			[Symbol.dispose]() {
+				log.push(name);
+				if (fail) {
+					throw new Error(name);
+				}
+			}
+		};
+	}
+
+	{
+		using a = res("a"), b = res("b");
+		using c = null, d = undefined;
+		log.push("body");
+	}
+	assert(compareArray(log, ["body", "b", "a"]), "block");
+
+	log = [];
+	function f() {
+		using x = res("x");
+		return "ret";
+	}
+	assert.sameValue(f(), "ret");
+	assert(compareArray(log, ["x"]), "function");
+
+	log = [];
+	for (using r of [res("r1"), res("r2")]) {
+		log.push("iter");
+	}
+	assert(compareArray(log, ["iter", "r1", "iter", "r2"]), "for-of");
+
+	log = [];
+	for (using r = res("r"); log.length < 2; ) {
+		log.push("loop");
+	}
+	assert(compareArray(log, ["loop", "loop", "r"]), "for");
+
+	log = [];
+	outer: for (const i of [1, 2]) {
+		while (true) {
+			using x = res("x" + i);
+			if (i === 1) {
+				continue outer;
+			}
+			break outer;
+		}
+	}
+	assert(compareArray(log, ["x1", "x2"]), "break and continue");
+
+	log = [];
+	let err;
+	try {
+		using x = res("x", true), y = res("y", true);
+		throw new Error("body");
+	} catch (e) {
+		err = e;
+	}
+	assert(compareArray(log, ["y", "x"]), "throw");
+	assert(err instanceof SuppressedError, "SuppressedError");
+	assert.sameValue(err.error.message, "x");
+	assert(err.suppressed instanceof SuppressedError, "nested SuppressedError");
+	assert.sameValue(err.suppressed.error.message, "y");
+	assert.sameValue(err.suppressed.suppressed.message, "body");
+
+	assert.throws(TypeError, function() {
+		using x = {};
+	});
+	assert.throws(TypeError, function() {
+		using x = 1;
+	});
+
+	log = [];
+	function* g() {
+		using x = res("g");
+		yield 1;
+		yield 2;
+	}
+	const it = g();
+	it.next();
+	it.return();
+	assert(compareArray(log, ["g"]), "generator return()");
+
+	{
+		using x = {
+			[Symbol.dispose]() {
+				assert.sameValue(this, x, "this");
+			}
+		};
+	}
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestAwaitUsingDeclarations(t *testing.T) {
+	const SCRIPT = `
+	let log = [];
+	function asyncRes(name) {
+		return {
+			async [Symbol.asyncDispose]() {
+				await null;
+				log.push(name);
+			}
+		};
+	}
+	function res(name) {
+		return {
+			[Symbol.dispose]() {
+				log.push(name);
+			}
+		};
+	}
+
+	async function f() {
+		await using a = asyncRes("a"), b = res("b"), c = null;
+		using d = res("d");
+		log.push("body");
+		return "ret";
+	}
+	assert.sameValue(await f(), "ret");
+	assert(compareArray(log, ["body", "d", "b", "a"]), "function");
+
+	log = [];
+	for await (await using x of [asyncRes("x1"), asyncRes("x2")]) {
+		log.push("iter");
+	}
+	assert(compareArray(log, ["iter", "x1", "iter", "x2"]), "for await");
+
+	log = [];
+	try {
+		await using x = {
+			async [Symbol.asyncDispose]() {
+				throw new Error("dispose");
+			}
+		};
+		throw new Error("body");
+	} catch (e) {
+		assert(e instanceof SuppressedError, "SuppressedError");
+		assert.sameValue(e.error.message, "dispose");
+		assert.sameValue(e.suppressed.message, "body");
+	}
+
+	let error;
+	try {
+		await using x = res("sync");
+	} catch (e) {
+		error = e;
+	}
+	assert.sameValue(error, undefined);
+	assert(compareArray(log, ["sync"]), "@@dispose fallback");
+	`
+	testAsyncFuncWithTestLib(SCRIPT, _undefined, t)
+}
+
 /*
 func TestBabel(t *testing.T) {
 	src, err := os.ReadFile("babel7.js")
@@ -0,0 +1,486 @@
+package goja
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dop251/goja/file"
+)
+
+// Coverage collects the code coverage of a Runtime: the statements, branches and functions of the code
+// it runs, and how many times each of them was executed.
+//
+// Statements are the statements of the code as recorded by the compiler (blocks and function declarations
+// excluded) when the code is compiled by the Runtime while the coverage is collected or with
+// CompileForDebugging, otherwise the positions the compiler records for the instructions. Branches are the
+// conditional jumps of the compiled code (if, loops, the conditional and logical operators, default parameter
+// values, ?. and ??) and functions are the functions defined by the code that ran, whether they were called or
+// not. Positions are mapped through the source maps of the code, if it has one. Code without a file name, such
+// as the code given to eval(), is not reported.
+//
+// The methods of Coverage must not be called while the Runtime is running code.
+type Coverage struct {
+	programs map[*Program]*coverageCounters
+	order    []*Program // Programs in the order they started running
+
+	lastPrg *Program
+	last    *coverageCounters
+}
+
+type coverageCounters struct {
+	hits   []uint32 // Number of times each instruction has been executed
+	taken  []uint32 // Number of times a branch instruction has jumped
+	branch []string // The kind of branch of the branch instructions, "" for the other instructions
+}
+
+// StartCoverage starts collecting the code coverage of the Runtime and returns the collector. If
+// coverage is already being collected it returns the current collector. It must not be called while the
+// Runtime is running code.
+func (r *Runtime) StartCoverage() *Coverage {
+	if r.vm.coverage == nil {
+		r.vm.coverage = &Coverage{
+			programs: make(map[*Program]*coverageCounters),
+		}
+	}
+	return r.vm.coverage
+}
+
+// StopCoverage stops collecting the code coverage of the Runtime. The Coverage returned by StartCoverage
+// keeps the data collected so far.
+func (r *Runtime) StopCoverage() {
+	r.vm.coverage = nil
+}
+
+func (c *Coverage) counters(prg *Program) *coverageCounters {
+	counters := c.programs[prg]
+	if counters == nil {
+		counters = &coverageCounters{
+			hits:   make([]uint32, len(prg.code)),
+			taken:  make([]uint32, len(prg.code)),
+			branch: make([]string, len(prg.code)),
+		}
+		for pc, ins := range prg.code {
+			counters.branch[pc] = branchKind(ins)
+		}
+		c.programs[prg] = counters
+		c.order = append(c.order, prg)
+	}
+	return counters
+}
+
+// branchKind returns the kind of branch of an instruction in the terms of Istanbul, "" if it's not a
+// conditional jump.
+func branchKind(ins instruction) string {
+	switch ins.(type) {
+	case jneP, jeqP:
+		return "if"
+	case jne, jeq, jcoalesc, jcoalescP:
+		return "binary-expr"
+	case jdef, jdefP:
+		return "default-arg"
+	case jcond, jopt, joptc:
+		return "cond-expr"
+	}
+	return ""
+}
+
+// hit counts the execution of the instruction of prg at pc and returns the counters of prg.
+func (c *Coverage) hit(prg *Program, pc int) *coverageCounters {
+	if prg != c.lastPrg {
+		c.last = c.counters(prg)
+		c.lastPrg = prg
+	}
+	c.last.hits[pc]++
+	return c.last
+}
+
+// branched counts the jump of a branch instruction, nextPc is the pc the VM went on with after
+// executing the instruction at pc.
+func (counters *coverageCounters) branched(pc, nextPc int) {
+	if counters.branch[pc] != "" && nextPc != pc+1 {
+		counters.taken[pc]++
+	}
+}
+
+type coveragePosition struct {
+	line, column int // 1-based
+}
+
+type coverageStatement struct {
+	pos   coveragePosition
+	end   coveragePosition
+	count uint32
+}
+
+type coverageFunction struct {
+	name  string
+	pos   coveragePosition
+	count uint32
+}
+
+type coverageBranch struct {
+	kind  string
+	pos   coveragePosition
+	count uint32 // Number of times the branch was reached
+	taken uint32
+}
+
+// arms returns the number of times each arm of the branch ran, in the order of Istanbul: the consequent and
+// the alternate of an if or a conditional, the left and right operands of a logical operator, the default
+// value of a parameter.
+func (br *coverageBranch) arms() []uint32 {
+	switch br.kind {
+	case "binary-expr":
+		// The left operand always runs, the jump skips the right one
+		return []uint32{br.count, br.count - br.taken}
+	case "default-arg":
+		// The jump skips the default value
+		return []uint32{br.count - br.taken}
+	}
+	// The jump skips the consequent
+	return []uint32{br.count - br.taken, br.taken}
+}
+
+type coverageBranchKey struct {
+	kind string
+	pos  coveragePosition
+	n    int
+}
+
+// coverageFile is the coverage of a source file.
+type coverageFile struct {
+	name       string
+	statements []*coverageStatement
+	functions  []*coverageFunction
+	branches   []*coverageBranch
+}
+
+// files returns the coverage of each source file, sorted by name. The counts of the code compiled more
+// than once, e.g. a script run twice, are added up.
+func (c *Coverage) files() []*coverageFile {
+	// The functions nested in the programs that ran are reported too, even if they have never been called
+	var programs []*Program
+	seen := make(map[*Program]bool)
+	functions := make(map[*Program]bool)
+	for _, prg := range c.order {
+		walkPrograms(prg, func(p *Program) bool {
+			if p != prg {
+				functions[p] = true
+			}
+			if !seen[p] {
+				seen[p] = true
+				programs = append(programs, p)
+			}
+			return true
+		})
+	}
+
+	files := make(map[string]*coverageFile)
+	statements := make(map[string]map[coveragePosition]*coverageStatement)
+	funcs := make(map[string]map[coverageFunction]*coverageFunction)
+	branches := make(map[string]map[coverageBranchKey]*coverageBranch)
+	getFile := func(name string) *coverageFile {
+		f := files[name]
+		if f == nil {
+			f = &coverageFile{name: name}
+			files[name] = f
+			statements[name] = make(map[coveragePosition]*coverageStatement)
+			funcs[name] = make(map[coverageFunction]*coverageFunction)
+			branches[name] = make(map[coverageBranchKey]*coverageBranch)
+		}
+		return f
+	}
+
+	for _, prg := range programs {
+		if prg.src == nil || prg.src.Name() == "" || prg.src.Name() == "<eval>" {
+			continue
+		}
+		counters := c.programs[prg]
+		count := func(pc int) uint32 {
+			if counters == nil || pc >= len(counters.hits) {
+				return 0
+			}
+			return counters.hits[pc]
+		}
+
+		// A statement runs as many times as its first instruction. Statements of the same program sharing a
+		// position (e.g. through a source map) are a single statement.
+		own := make(map[file.Position]uint32)
+		var positions []file.Position
+		// The code compiled without the debug information (e.g. with Compile) has no statements recorded,
+		// the positions of its instructions are used instead
+		items := prg.stmts
+		if items == nil {
+			items = prg.srcMap
+		}
+		for _, item := range items {
+			pos := prg.src.Position(item.srcPos)
+			if pos.Filename == "" || pos.Line == 0 {
+				continue
+			}
+			if n, exists := own[pos]; !exists {
+				positions = append(positions, pos)
+				own[pos] = count(item.pc)
+			} else if hits := count(item.pc); hits > n {
+				own[pos] = hits
+			}
+		}
+		for _, pos := range positions {
+			f := getFile(pos.Filename)
+			key := coveragePosition{pos.Line, pos.Column}
+			st := statements[f.name][key]
+			if st == nil {
+				st = &coverageStatement{pos: key, end: statementEnd(prg, pos)}
+				statements[f.name][key] = st
+				f.statements = append(f.statements, st)
+			}
+			st.count += own[pos]
+		}
+
+		if functions[prg] {
+			pos := prg.src.Position(prg.sourceOffset(0))
+			if pos.Filename != "" {
+				f := getFile(pos.Filename)
+				key := coverageFunction{name: prg.funcName.String(), pos: coveragePosition{pos.Line, pos.Column}}
+				if key.name == "" {
+					key.name = fmt.Sprintf("(anonymous_%d_%d)", pos.Line, pos.Column)
+				}
+				fn := funcs[f.name][key]
+				if fn == nil {
+					fn = &coverageFunction{name: key.name, pos: key.pos}
+					funcs[f.name][key] = fn
+					f.functions = append(f.functions, fn)
+				}
+				fn.count += count(0)
+			}
+		}
+
+		// Branches sharing a position are told apart by their order in the program
+		nth := make(map[coverageBranchKey]int)
+		for pc, ins := range prg.code {
+			kind := branchKind(ins)
+			if kind == "" {
+				continue
+			}
+			pos := prg.src.Position(prg.sourceOffset(pc))
+			if pos.Filename == "" || pos.Line == 0 {
+				continue
+			}
+			f := getFile(pos.Filename)
+			key := coverageBranchKey{kind: kind, pos: coveragePosition{pos.Line, pos.Column}}
+			n := nth[key]
+			nth[key] = n + 1
+			key.n = n
+			br := branches[f.name][key]
+			if br == nil {
+				br = &coverageBranch{kind: kind, pos: key.pos}
+				branches[f.name][key] = br
+				f.branches = append(f.branches, br)
+			}
+			if counters != nil {
+				br.count += counters.hits[pc]
+				br.taken += counters.taken[pc]
+			}
+		}
+	}
+
+	result := make([]*coverageFile, 0, len(files))
+	for _, f := range files {
+		sort.SliceStable(f.statements, func(i, j int) bool {
+			return f.statements[i].pos.before(f.statements[j].pos)
+		})
+		sort.SliceStable(f.functions, func(i, j int) bool {
+			return f.functions[i].pos.before(f.functions[j].pos)
+		})
+		sort.SliceStable(f.branches, func(i, j int) bool {
+			return f.branches[i].pos.before(f.branches[j].pos)
+		})
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+func (p coveragePosition) before(other coveragePosition) bool {
+	return p.line < other.line || p.line == other.line && p.column < other.column
+}
+
+// statementEnd returns the end of the line of a statement if it's in the code of prg itself, otherwise
+// (the line is in the original source of generated code) the position of the statement.
+func statementEnd(prg *Program, pos file.Position) coveragePosition {
+	end := coveragePosition{pos.Line, pos.Column}
+	if pos.Filename != prg.src.Name() {
+		return end
+	}
+	src := prg.src.Source()
+	offset := 0
+	for line := 1; line < pos.Line; line++ {
+		i := strings.IndexByte(src[offset:], '\n')
+		if i < 0 {
+			return end
+		}
+		offset += i + 1
+	}
+	lineLen := strings.IndexByte(src[offset:], '\n')
+	if lineLen < 0 {
+		lineLen = len(src) - offset
+	}
+	end.column = len(strings.TrimRight(src[offset:offset+lineLen], " \t\r")) + 1
+	return end
+}
+
+// WriteLCOV writes the coverage in the LCOV tracefile format, as read by genhtml and most coverage
+// services.
+func (c *Coverage) WriteLCOV(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range c.files() {
+		fmt.Fprintf(bw, "TN:\nSF:%s\n", f.name)
+
+		hit := 0
+		for _, fn := range f.functions {
+			fmt.Fprintf(bw, "FN:%d,%s\n", fn.pos.line, fn.name)
+		}
+		for _, fn := range f.functions {
+			fmt.Fprintf(bw, "FNDA:%d,%s\n", fn.count, fn.name)
+			if fn.count > 0 {
+				hit++
+			}
+		}
+		fmt.Fprintf(bw, "FNF:%d\nFNH:%d\n", len(f.functions), hit)
+
+		found := 0
+		hit = 0
+		for i, br := range f.branches {
+			for arm, n := range br.arms() {
+				found++
+				if br.count == 0 {
+					fmt.Fprintf(bw, "BRDA:%d,%d,%d,-\n", br.pos.line, i, arm)
+					continue
+				}
+				fmt.Fprintf(bw, "BRDA:%d,%d,%d,%d\n", br.pos.line, i, arm, n)
+				if n > 0 {
+					hit++
+				}
+			}
+		}
+		fmt.Fprintf(bw, "BRF:%d\nBRH:%d\n", found, hit)
+
+		// Lines are counted once, with the count of their most executed statement
+		var lines []int
+		counts := make(map[int]uint32)
+		for _, st := range f.statements {
+			n, exists := counts[st.pos.line]
+			if !exists {
+				lines = append(lines, st.pos.line)
+			}
+			if !exists || st.count > n {
+				counts[st.pos.line] = st.count
+			}
+		}
+		hit = 0
+		for _, line := range lines {
+			fmt.Fprintf(bw, "DA:%d,%d\n", line, counts[line])
+			if counts[line] > 0 {
+				hit++
+			}
+		}
+		fmt.Fprintf(bw, "LF:%d\nLH:%d\nend_of_record\n", len(lines), hit)
+	}
+	return bw.Flush()
+}
+
+type istanbulPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type istanbulLocation struct {
+	Start istanbulPosition `json:"start"`
+	End   istanbulPosition `json:"end"`
+}
+
+type istanbulFunction struct {
+	Name string           `json:"name"`
+	Decl istanbulLocation `json:"decl"`
+	Loc  istanbulLocation `json:"loc"`
+	Line int              `json:"line"`
+}
+
+type istanbulBranch struct {
+	Type      string             `json:"type"`
+	Loc       istanbulLocation   `json:"loc"`
+	Locations []istanbulLocation `json:"locations"`
+	Line      int                `json:"line"`
+}
+
+type istanbulFile struct {
+	Path         string                      `json:"path"`
+	StatementMap map[string]istanbulLocation `json:"statementMap"`
+	FnMap        map[string]istanbulFunction `json:"fnMap"`
+	BranchMap    map[string]istanbulBranch   `json:"branchMap"`
+	S            map[string]uint32           `json:"s"`
+	F            map[string]uint32           `json:"f"`
+	B            map[string][]uint32         `json:"b"`
+}
+
+// istanbulLoc converts 1-based positions into an Istanbul location, whose columns are 0-based.
+func istanbulLoc(start, end coveragePosition) istanbulLocation {
+	return istanbulLocation{
+		Start: istanbulPosition{Line: start.line, Column: start.column - 1},
+		End:   istanbulPosition{Line: end.line, Column: end.column - 1},
+	}
+}
+
+// WriteIstanbul writes the coverage in the JSON format of Istanbul (coverage-final.json), as read by nyc
+// and the other tools of the Istanbul ecosystem.
+func (c *Coverage) WriteIstanbul(w io.Writer) error {
+	result := make(map[string]*istanbulFile)
+	for _, f := range c.files() {
+		out := &istanbulFile{
+			Path:         f.name,
+			StatementMap: make(map[string]istanbulLocation, len(f.statements)),
+			FnMap:        make(map[string]istanbulFunction, len(f.functions)),
+			BranchMap:    make(map[string]istanbulBranch, len(f.branches)),
+			S:            make(map[string]uint32, len(f.statements)),
+			F:            make(map[string]uint32, len(f.functions)),
+			B:            make(map[string][]uint32, len(f.branches)),
+		}
+		for i, st := range f.statements {
+			id := strconv.Itoa(i)
+			out.StatementMap[id] = istanbulLoc(st.pos, st.end)
+			out.S[id] = st.count
+		}
+		for i, fn := range f.functions {
+			id := strconv.Itoa(i)
+			loc := istanbulLoc(fn.pos, fn.pos)
+			out.FnMap[id] = istanbulFunction{Name: fn.name, Decl: loc, Loc: loc, Line: fn.pos.line}
+			out.F[id] = fn.count
+		}
+		for i, br := range f.branches {
+			id := strconv.Itoa(i)
+			loc := istanbulLoc(br.pos, br.pos)
+			arms := br.arms()
+			locations := make([]istanbulLocation, len(arms))
+			for j := range locations {
+				locations[j] = loc
+			}
+			out.BranchMap[id] = istanbulBranch{
+				Type:      br.kind,
+				Loc:       loc,
+				Locations: locations,
+				Line:      br.pos.line,
+			}
+			out.B[id] = arms
+		}
+		result[f.name] = out
+	}
+	enc := json.NewEncoder(w)
+	return enc.Encode(result)
+}
@@ -0,0 +1,289 @@
+package goja
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const coverageScript = `function abs(x) {
+	if (x < 0) {
+		return -x;
+	}
+	return x;
+}
+function unused() {
+	return 1;
+}
+var total = 0;
+for (var i = -2; i < 3; i++) {
+	total += abs(i);
+}
+`
+
+func TestCoverageLCOV(t *testing.T) {
+	vm := New()
+	cov := vm.StartCoverage()
+	if vm.StartCoverage() != cov {
+		t.Fatal("StartCoverage returned a new collector")
+	}
+	if _, err := vm.RunScript("abs.js", coverageScript); err != nil {
+		t.Fatal(err)
+	}
+	vm.StopCoverage()
+	// Not collected
+	if _, err := vm.RunScript("other.js", "unused()"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := cov.WriteLCOV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"SF:abs.js\n",
+		"FN:1,abs\n",
+		"FNDA:5,abs\n",
+		"FNDA:0,unused\n",
+		"FNF:2\nFNH:1\n",
+		"DA:3,2\n",
+		"DA:5,3\n",
+		"DA:2,5\n",
+		"DA:8,0\n",
+		"DA:10,1\n",
+		"end_of_record\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected %q in:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, "other.js") {
+		t.Errorf("Code run after StopCoverage was reported:\n%s", out)
+	}
+	// The if of abs is reached 5 times and skips its body 3 times
+	if !strings.Contains(out, "BRDA:2,0,0,2\nBRDA:2,0,1,3\n") {
+		t.Errorf("Unexpected branches:\n%s", out)
+	}
+}
+
+func TestCoverageUntakenBranch(t *testing.T) {
+	vm := New()
+	cov := vm.StartCoverage()
+	if _, err := vm.RunScript("f.js", "function f(x) {\n\tif (x) {\n\t\treturn 1;\n\t}\n\treturn 2;\n}\nf(false);\n"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := cov.WriteLCOV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// The statements that never ran are reported too
+	if out := buf.String(); !strings.Contains(out, "DA:2,1\nDA:3,0\nDA:5,1\nDA:7,1\n") {
+		t.Errorf("Unexpected lines:\n%s", out)
+	}
+}
+
+func TestCoverageIstanbul(t *testing.T) {
+	vm := New()
+	cov := vm.StartCoverage()
+	if _, err := vm.RunScript("abs.js", coverageScript); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := cov.WriteIstanbul(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]struct {
+		Path         string
+		StatementMap map[string]istanbulLocation
+		FnMap        map[string]istanbulFunction
+		BranchMap    map[string]istanbulBranch
+		S            map[string]uint32
+		F            map[string]uint32
+		B            map[string][]uint32
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	f, exists := result["abs.js"]
+	if !exists || f.Path != "abs.js" {
+		t.Fatalf("abs.js is missing: %s", buf.String())
+	}
+	found := false
+	for id, loc := range f.StatementMap {
+		if loc.Start.Line == 3 {
+			found = true
+			if loc.End.Column != 12 {
+				t.Errorf("Unexpected location of line 3: %+v", loc)
+			}
+			if f.S[id] != 2 {
+				t.Errorf("Expected line 3 to run twice, got %d", f.S[id])
+			}
+		}
+	}
+	if !found {
+		t.Errorf("No statement at line 3: %+v", f.StatementMap)
+	}
+	for id, fn := range f.FnMap {
+		if fn.Name == "abs" && f.F[id] != 5 {
+			t.Errorf("Expected abs to be called 5 times, got %d", f.F[id])
+		}
+	}
+	found = false
+	for id, br := range f.BranchMap {
+		if br.Line == 2 && br.Type == "if" {
+			found = true
+			if b := f.B[id]; len(b) != 2 || b[0] != 2 || b[1] != 3 {
+				t.Errorf("Unexpected branch counts %v", b)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("The if is missing: %+v", f.BranchMap)
+	}
+}
+
+func TestCoverageSourceMap(t *testing.T) {
+	vm := New()
+	cov := vm.StartCoverage()
+	if _, err := vm.RunProgram(compileSourceMapped(t)); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := cov.WriteLCOV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "dist/app.js") {
+		t.Errorf("The generated file was reported:\n%s", out)
+	}
+	for _, line := range []string{"SF:src/app.ts\n", "FN:3,add\n", "FNDA:1,add\n", "DA:4,1\n", "DA:7,1\n"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected %q in:\n%s", line, out)
+		}
+	}
+}
+
+func TestCoverageEval(t *testing.T) {
+	vm := New()
+	cov := vm.StartCoverage()
+	if _, err := vm.RunString("eval('1 + 1')"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := cov.WriteLCOV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Code without a file name was reported:\n%s", buf.String())
+	}
+}
+
+func TestCoverageStatements(t *testing.T) {
+	// The statements are only recorded when they are needed
+	prg := MustCompile("abs.js", coverageScript, false)
+	if prg.stmts != nil {
+		t.Fatal("Statements were recorded without the debug information")
+	}
+	prg, err := CompileForDebugging("abs.js", coverageScript, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prg.stmts) == 0 {
+		t.Fatal("Statements were not recorded")
+	}
+
+	// Code compiled beforehand is reported from the positions of its instructions
+	vm := New()
+	cov := vm.StartCoverage()
+	if _, err := vm.RunProgram(MustCompile("abs.js", coverageScript, false)); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := cov.WriteLCOV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"SF:abs.js\n", "DA:3,2\n", "DA:5,3\n"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("Expected %q in:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestCoverageBranchArms(t *testing.T) {
+	const SCRIPT = `var z = 0;
+var y = z || 4;
+var w = z && 5;
+var v = z ?? 3;
+var u = z ? 1 : 2;
+function f(a = 1) {
+	return a;
+}
+f();
+f(2);
+`
+	vm := New()
+	cov := vm.StartCoverage()
+	if _, err := vm.RunScript("arms.js", SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+
+	// The arms of the logical operators are their operands, the left one always runs
+	var buf bytes.Buffer
+	if err := cov.WriteLCOV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"BRDA:2,0,0,1\nBRDA:2,0,1,1\n",
+		"BRDA:3,1,0,1\nBRDA:3,1,1,0\n",
+		"BRDA:4,2,0,1\nBRDA:4,2,1,0\n",
+		"BRDA:5,3,0,0\nBRDA:5,3,1,1\n",
+		"BRDA:6,4,0,1\n",
+		"BRF:9\nBRH:6\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected %q in:\n%s", line, out)
+		}
+	}
+
+	buf.Reset()
+	if err := cov.WriteIstanbul(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]struct {
+		BranchMap map[string]istanbulBranch
+		B         map[string][]uint32
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	f := result["arms.js"]
+	expected := map[int]struct {
+		kind string
+		arms []uint32
+	}{
+		2: {"binary-expr", []uint32{1, 1}},
+		3: {"binary-expr", []uint32{1, 0}},
+		4: {"binary-expr", []uint32{1, 0}},
+		5: {"cond-expr", []uint32{0, 1}},
+		6: {"default-arg", []uint32{1}},
+	}
+	if len(f.BranchMap) != len(expected) {
+		t.Fatalf("Unexpected branches: %+v", f.BranchMap)
+	}
+	for id, br := range f.BranchMap {
+		exp := expected[br.Line]
+		b := f.B[id]
+		if br.Type != exp.kind || len(br.Locations) != len(exp.arms) || len(b) != len(exp.arms) {
+			t.Fatalf("Line %d: unexpected branch %+v %v", br.Line, br, b)
+		}
+		for i := range b {
+			if b[i] != exp.arms[i] {
+				t.Errorf("Line %d: expected %v, got %v", br.Line, exp.arms, b)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// maxMessageSize limits the size of the messages read from the peer.
+const maxMessageSize = 64 << 20
+
+var errMessageTooBig = errors.New("dap: message too big")
+
+// ProtocolMessage is the base of all messages exchanged with the client.
+type ProtocolMessage struct {
+	Seq  int    `json:"seq"`
+	Type string `json:"type"`
+}
+
+// Request is a client to adapter request.
+type Request struct {
+	ProtocolMessage
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// Response is an adapter to client response to a Request.
+type Response struct {
+	ProtocolMessage
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+// Event is an adapter to client notification.
+type Event struct {
+	ProtocolMessage
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+// Capabilities is the body of the initialize response.
+type Capabilities struct {
+	SupportsConfigurationDoneRequest  bool                         `json:"supportsConfigurationDoneRequest,omitempty"`
+	SupportsEvaluateForHovers         bool                         `json:"supportsEvaluateForHovers,omitempty"`
+	SupportTerminateDebuggee          bool                         `json:"supportTerminateDebuggee,omitempty"`
+	SupportsConditionalBreakpoints    bool                         `json:"supportsConditionalBreakpoints,omitempty"`
+	SupportsHitConditionalBreakpoints bool                         `json:"supportsHitConditionalBreakpoints,omitempty"`
+	SupportsLogPoints                 bool                         `json:"supportsLogPoints,omitempty"`
+	SupportsExceptionInfoRequest      bool                         `json:"supportsExceptionInfoRequest,omitempty"`
+	SupportsDataBreakpoints           bool                         `json:"supportsDataBreakpoints,omitempty"`
+	SupportsSetVariable               bool                         `json:"supportsSetVariable,omitempty"`
+	SupportsRestartFrame              bool                         `json:"supportsRestartFrame,omitempty"`
+	ExceptionBreakpointFilters        []ExceptionBreakpointsFilter `json:"exceptionBreakpointFilters,omitempty"`
+}
+
+// ExceptionBreakpointsFilter is an exception breakpoint option shown by the client.
+type ExceptionBreakpointsFilter struct {
+	Filter  string `json:"filter"`
+	Label   string `json:"label"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// DataBreakpointInfoArguments are the arguments of the dataBreakpointInfo request.
+type DataBreakpointInfoArguments struct {
+	VariablesReference int    `json:"variablesReference,omitempty"`
+	Name               string `json:"name"`
+}
+
+// DataBreakpointInfoResponseBody is the body of the dataBreakpointInfo response.
+type DataBreakpointInfoResponseBody struct {
+	DataID      *string  `json:"dataId"`
+	Description string   `json:"description"`
+	AccessTypes []string `json:"accessTypes,omitempty"`
+}
+
+// DataBreakpoint is a data breakpoint requested by the client.
+type DataBreakpoint struct {
+	DataID string `json:"dataId"`
+}
+
+// SetDataBreakpointsArguments are the arguments of the setDataBreakpoints request.
+type SetDataBreakpointsArguments struct {
+	Breakpoints []DataBreakpoint `json:"breakpoints"`
+}
+
+// SetExceptionBreakpointsArguments are the arguments of the setExceptionBreakpoints request.
+type SetExceptionBreakpointsArguments struct {
+	Filters []string `json:"filters"`
+}
+
+// ExceptionInfoResponseBody is the body of the exceptionInfo response.
+type ExceptionInfoResponseBody struct {
+	ExceptionID string `json:"exceptionId"`
+	Description string `json:"description,omitempty"`
+	BreakMode   string `json:"breakMode"`
+}
+
+// LaunchArguments are the arguments of the launch request.
+type LaunchArguments struct {
+	Program     string `json:"program"`
+	StopOnEntry bool   `json:"stopOnEntry,omitempty"`
+	NoDebug     bool   `json:"noDebug,omitempty"`
+}
+
+// DisconnectArguments are the arguments of the disconnect request.
+type DisconnectArguments struct {
+	TerminateDebuggee *bool `json:"terminateDebuggee,omitempty"`
+}
+
+// Source describes a source file.
+type Source struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// SourceBreakpoint is a breakpoint requested by the client.
+type SourceBreakpoint struct {
+	Line         int    `json:"line"`
+	Column       int    `json:"column,omitempty"`
+	Condition    string `json:"condition,omitempty"`
+	HitCondition string `json:"hitCondition,omitempty"`
+	LogMessage   string `json:"logMessage,omitempty"`
+}
+
+// SetBreakpointsArguments are the arguments of the setBreakpoints request.
+type SetBreakpointsArguments struct {
+	Source      Source             `json:"source"`
+	Breakpoints []SourceBreakpoint `json:"breakpoints,omitempty"`
+}
+
+// Breakpoint is a breakpoint as reported to the client.
+type Breakpoint struct {
+	ID       int     `json:"id"`
+	Verified bool    `json:"verified"`
+	Message  string  `json:"message,omitempty"`
+	Source   *Source `json:"source,omitempty"`
+	Line     int     `json:"line,omitempty"`
+	Column   int     `json:"column,omitempty"`
+}
+
+// SetBreakpointsResponseBody is the body of the setBreakpoints response.
+type SetBreakpointsResponseBody struct {
+	Breakpoints []Breakpoint `json:"breakpoints"`
+}
+
+// Thread is a thread as reported to the client. A Runtime only ever has one.
+type Thread struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ThreadsResponseBody is the body of the threads response.
+type ThreadsResponseBody struct {
+	Threads []Thread `json:"threads"`
+}
+
+// StackTraceArguments are the arguments of the stackTrace request.
+type StackTraceArguments struct {
+	ThreadID   int `json:"threadId"`
+	StartFrame int `json:"startFrame,omitempty"`
+	Levels     int `json:"levels,omitempty"`
+}
+
+// StackFrame is a stack frame as reported to the client.
+type StackFrame struct {
+	ID               int     `json:"id"`
+	Name             string  `json:"name"`
+	Source           *Source `json:"source,omitempty"`
+	Line             int     `json:"line"`
+	Column           int     `json:"column"`
+	PresentationHint string  `json:"presentationHint,omitempty"`
+	CanRestart       bool    `json:"canRestart"` // Defaults to true when absent, so never omitted
+}
+
+// StackTraceResponseBody is the body of the stackTrace response.
+type StackTraceResponseBody struct {
+	StackFrames []StackFrame `json:"stackFrames"`
+	TotalFrames int          `json:"totalFrames"`
+}
+
+// ScopesArguments are the arguments of the scopes request.
+type ScopesArguments struct {
+	FrameID int `json:"frameId"`
+}
+
+// Scope is a variable scope as reported to the client.
+type Scope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+// ScopesResponseBody is the body of the scopes response.
+type ScopesResponseBody struct {
+	Scopes []Scope `json:"scopes"`
+}
+
+// VariablesArguments are the arguments of the variables request.
+type VariablesArguments struct {
+	VariablesReference int `json:"variablesReference"`
+}
+
+// Variable is a variable as reported to the client.
+type Variable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	EvaluateName       string `json:"evaluateName,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// VariablesResponseBody is the body of the variables response.
+type VariablesResponseBody struct {
+	Variables []Variable `json:"variables"`
+}
+
+// SetVariableArguments are the arguments of the setVariable request.
+type SetVariableArguments struct {
+	VariablesReference int    `json:"variablesReference"`
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+}
+
+// RestartFrameArguments are the arguments of the restartFrame request.
+type RestartFrameArguments struct {
+	FrameID int `json:"frameId"`
+}
+
+// SetVariableResponseBody is the body of the setVariable response.
+type SetVariableResponseBody struct {
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// EvaluateArguments are the arguments of the evaluate request.
+type EvaluateArguments struct {
+	Expression string `json:"expression"`
+	FrameID    *int   `json:"frameId,omitempty"`
+	Context    string `json:"context,omitempty"`
+}
+
+// EvaluateResponseBody is the body of the evaluate response.
+type EvaluateResponseBody struct {
+	Result             string `json:"result"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// ContinueResponseBody is the body of the continue response.
+type ContinueResponseBody struct {
+	AllThreadsContinued bool `json:"allThreadsContinued"`
+}
+
+// StoppedEventBody is the body of the stopped event.
+type StoppedEventBody struct {
+	Reason            string `json:"reason"`
+	Description       string `json:"description,omitempty"`
+	ThreadID          int    `json:"threadId"`
+	AllThreadsStopped bool   `json:"allThreadsStopped"`
+	HitBreakpointIDs  []int  `json:"hitBreakpointIds,omitempty"`
+}
+
+// OutputEventBody is the body of the output event.
+type OutputEventBody struct {
+	Category string `json:"category,omitempty"`
+	Output   string `json:"output"`
+}
+
+// ExitedEventBody is the body of the exited event.
+type ExitedEventBody struct {
+	ExitCode int `json:"exitCode"`
+}
+
+// ReadMessage reads a single base protocol message (a Content-Length header followed by a JSON payload).
+func ReadMessage(r *bufio.Reader) ([]byte, error) {
+	tp := textproto.NewReader(r)
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	cl := header.Get("Content-Length")
+	if cl == "" {
+		return nil, errors.New("dap: missing Content-Length header")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(cl))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("dap: invalid Content-Length %q", cl)
+	}
+	if n > maxMessageSize {
+		return nil, errMessageTooBig
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// WriteMessage encodes msg as JSON and writes it with the base protocol header.
+func WriteMessage(w io.Writer, msg interface{}) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(b)); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
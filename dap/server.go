@@ -0,0 +1,849 @@
+// Package dap implements a Debug Adapter Protocol (https://microsoft.github.io/debug-adapter-protocol/)
+// server on top of goja's Debugger, so that a Runtime can be debugged from VS Code or any other DAP client.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dop251/goja"
+)
+
+// threadID is the id of the only thread a Runtime has.
+const threadID = 1
+
+var errNotStopped = errors.New("the program is not stopped")
+
+// LaunchFunc runs the program requested by a launch request. It is called on a new goroutine which
+// becomes the VM goroutine, and the session is terminated when it returns.
+type LaunchFunc func(r *goja.Runtime, args *LaunchArguments) error
+
+// Server is a Debug Adapter Protocol server for a single Runtime.
+//
+// In launch mode the server runs the program itself (see SetLauncher). In attach mode it only installs
+// its handler on the Runtime's Debugger and the embedder runs the scripts as usual; execution stops at
+// breakpoints while a client is connected.
+//
+// Requests that inspect the program (stackTrace, scopes, variables, evaluate) are only served while the
+// program is stopped. They are executed on the VM goroutine through a goja.AsyncDebugger.
+type Server struct {
+	r      *goja.Runtime
+	dbg    *goja.Debugger
+	launch LaunchFunc
+
+	wmu sync.Mutex
+	w   io.Writer
+	seq int
+
+	mu             sync.Mutex
+	state          *goja.DebuggerState
+	pauseRequested bool
+	stopOnEntry    bool
+	restarting     bool
+	running        bool
+	lastCmd        goja.DebugCommand
+
+	breakpoints     map[string][]int // breakpoint ids by source path
+	dataBreakpoints []int
+
+	launchArgs *LaunchArguments
+	configured bool
+
+	async *goja.AsyncDebugger
+	done  chan struct{}
+}
+
+// NewServer creates a server for the Runtime. The Runtime's Debugger is enabled if it isn't already.
+// Locals held in registers are only visible if the Runtime was created in debug mode
+// (see RuntimeOptions.EnableDebugMode).
+func NewServer(r *goja.Runtime) *Server {
+	return &Server{
+		r:      r,
+		dbg:    r.EnableDebugger(),
+		launch: runProgramFile,
+	}
+}
+
+// SetLauncher sets the function that runs the program for a launch request. The default one reads
+// the file named by the program argument and runs it with RunScript.
+func (s *Server) SetLauncher(f LaunchFunc) {
+	s.launch = f
+}
+
+func runProgramFile(r *goja.Runtime, args *LaunchArguments) error {
+	src, err := os.ReadFile(args.Program)
+	if err != nil {
+		return err
+	}
+	_, err = r.RunScript(args.Program, string(src))
+	return err
+}
+
+// ListenAndServe listens on the TCP network address addr and serves the debug sessions of the clients
+// that connect to it, one at a time.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		err = s.Serve(conn, conn)
+		conn.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ServeStdio serves a debug session over the process' standard input and output, which is how
+// DAP clients talk to a debug adapter they start themselves.
+func (s *Server) ServeStdio() error {
+	return s.Serve(os.Stdin, os.Stdout)
+}
+
+// Serve serves a single debug session, reading requests from r and writing responses and events to w.
+// It returns when the client disconnects or r is closed.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.wmu.Lock()
+	s.w, s.seq = w, 0
+	s.wmu.Unlock()
+	s.breakpoints = make(map[string][]int)
+	s.done = make(chan struct{})
+	s.launchArgs = nil
+	s.configured = false
+	s.async = s.dbg.EnableAsync()
+	go s.watch(s.async, s.done)
+	s.dbg.SetLogpointHandler(func(bp *goja.Breakpoint, message string) {
+		s.Output("console", message+"\n")
+	})
+
+	defer s.detach()
+
+	br := bufio.NewReader(r)
+	for {
+		b, err := ReadMessage(br)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var req Request
+		if err := json.Unmarshal(b, &req); err != nil {
+			return fmt.Errorf("dap: malformed message: %w", err)
+		}
+		if req.Type != "request" {
+			continue
+		}
+		if !s.dispatch(&req) {
+			return nil
+		}
+	}
+}
+
+// detach removes the server's breakpoints and handler, letting a stopped program go on.
+func (s *Server) detach() {
+	for _, ids := range s.breakpoints {
+		for _, id := range ids {
+			s.dbg.RemoveBreakpoint(id)
+		}
+	}
+	s.breakpoints = nil
+	for _, id := range s.dataBreakpoints {
+		s.dbg.RemoveDataBreakpoint(id)
+	}
+	s.dataBreakpoints = nil
+	s.async.Close()
+	s.dbg.SetLogpointHandler(nil)
+	close(s.done)
+	s.wmu.Lock()
+	s.w = nil
+	s.wmu.Unlock()
+}
+
+// Output sends text to the client's debug console. category is one of "console", "stdout" or "stderr".
+// It can be used to forward the output of the scripts, e.g. from a console.log implementation.
+func (s *Server) Output(category, text string) {
+	s.sendEvent("output", OutputEventBody{Category: category, Output: text})
+}
+
+func (s *Server) send(msg interface{}) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	if s.w == nil {
+		return
+	}
+	s.seq++
+	switch m := msg.(type) {
+	case *Response:
+		m.Seq = s.seq
+	case *Event:
+		m.Seq = s.seq
+	}
+	_ = WriteMessage(s.w, msg)
+}
+
+func (s *Server) sendEvent(event string, body interface{}) {
+	s.send(&Event{
+		ProtocolMessage: ProtocolMessage{Type: "event"},
+		Event:           event,
+		Body:            body,
+	})
+}
+
+func (s *Server) respond(req *Request, body interface{}, err error) {
+	resp := &Response{
+		ProtocolMessage: ProtocolMessage{Type: "response"},
+		RequestSeq:      req.Seq,
+		Success:         err == nil,
+		Command:         req.Command,
+		Body:            body,
+	}
+	if err != nil {
+		resp.Message = err.Error()
+		resp.Body = nil
+	}
+	s.send(resp)
+}
+
+// dispatch handles a request. It returns false when the session is over.
+func (s *Server) dispatch(req *Request) bool {
+	switch req.Command {
+	case "initialize":
+		s.respond(req, Capabilities{
+			SupportsConfigurationDoneRequest:  true,
+			SupportsEvaluateForHovers:         true,
+			SupportTerminateDebuggee:          true,
+			SupportsConditionalBreakpoints:    true,
+			SupportsHitConditionalBreakpoints: true,
+			SupportsLogPoints:                 true,
+			SupportsExceptionInfoRequest:      true,
+			SupportsDataBreakpoints:           true,
+			SupportsSetVariable:               true,
+			SupportsRestartFrame:              true,
+			ExceptionBreakpointFilters: []ExceptionBreakpointsFilter{
+				{Filter: "all", Label: "All Exceptions"},
+				{Filter: "uncaught", Label: "Uncaught Exceptions"},
+			},
+		}, nil)
+		s.sendEvent("initialized", nil)
+	case "launch":
+		var args LaunchArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		if args.Program == "" {
+			s.respond(req, nil, errors.New("the program to launch is not specified"))
+			break
+		}
+		s.launchArgs = &args
+		s.respond(req, nil, nil)
+		if s.configured {
+			s.start()
+		}
+	case "attach":
+		s.respond(req, nil, nil)
+	case "configurationDone":
+		s.configured = true
+		s.respond(req, nil, nil)
+		if s.launchArgs != nil {
+			s.start()
+		}
+	case "setBreakpoints":
+		var args SetBreakpointsArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		s.respond(req, s.setBreakpoints(&args), nil)
+	case "dataBreakpointInfo":
+		var args DataBreakpointInfoArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		body := DataBreakpointInfoResponseBody{Description: "Data breakpoints are only available for variables"}
+		if args.VariablesReference != 0 {
+			id := strconv.Itoa(args.VariablesReference) + "/" + args.Name
+			body = DataBreakpointInfoResponseBody{
+				DataID:      &id,
+				Description: "Pause when " + args.Name + " changes",
+				AccessTypes: []string{"write"},
+			}
+		}
+		s.respond(req, body, nil)
+	case "setDataBreakpoints":
+		var args SetDataBreakpointsArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		s.respond(req, s.setDataBreakpoints(&args), nil)
+	case "setExceptionBreakpoints":
+		var args SetExceptionBreakpointsArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		mode := goja.ExceptionBreakNone
+		for _, filter := range args.Filters {
+			switch filter {
+			case "all":
+				mode = goja.ExceptionBreakAll
+			case "uncaught":
+				if mode == goja.ExceptionBreakNone {
+					mode = goja.ExceptionBreakUncaught
+				}
+			}
+		}
+		s.dbg.SetExceptionBreakMode(mode)
+		s.respond(req, nil, nil)
+	case "exceptionInfo":
+		var body ExceptionInfoResponseBody
+		var ferr error
+		err := s.onVM(func(state *goja.DebuggerState) {
+			body, ferr = exceptionInfo(state)
+		})
+		if err == nil {
+			err = ferr
+		}
+		s.respond(req, body, err)
+	case "threads":
+		s.respond(req, ThreadsResponseBody{Threads: []Thread{{ID: threadID, Name: "main"}}}, nil)
+	case "stackTrace":
+		var args StackTraceArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		var body StackTraceResponseBody
+		err := s.onVM(func(state *goja.DebuggerState) {
+			body = s.stackTrace(state, &args)
+		})
+		s.respond(req, body, err)
+	case "scopes":
+		var args ScopesArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		var body ScopesResponseBody
+		var ferr error
+		err := s.onVM(func(state *goja.DebuggerState) {
+			body, ferr = s.scopes(state, args.FrameID)
+		})
+		if err == nil {
+			err = ferr
+		}
+		s.respond(req, body, err)
+	case "variables":
+		var args VariablesArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		var body VariablesResponseBody
+		err := s.onVM(func(*goja.DebuggerState) {
+			body = s.variables(args.VariablesReference)
+		})
+		s.respond(req, body, err)
+	case "setVariable":
+		var args SetVariableArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		var body SetVariableResponseBody
+		var ferr error
+		err := s.onVM(func(*goja.DebuggerState) {
+			body, ferr = s.setVariable(&args)
+		})
+		if err == nil {
+			err = ferr
+		}
+		s.respond(req, body, err)
+	case "evaluate":
+		var args EvaluateArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		var body EvaluateResponseBody
+		var ferr error
+		err := s.onVM(func(*goja.DebuggerState) {
+			body, ferr = s.evaluate(&args)
+		})
+		if err == nil {
+			err = ferr
+		}
+		s.respond(req, body, err)
+	case "restartFrame":
+		var args RestartFrameArguments
+		if err := unmarshalArgs(req, &args); err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		var ferr error
+		err := s.onVM(func(*goja.DebuggerState) {
+			ferr = s.dbg.RestartFrame(args.FrameID - 1)
+		})
+		if err == nil {
+			err = ferr
+		}
+		if err != nil {
+			s.respond(req, nil, err)
+			break
+		}
+		s.mu.Lock()
+		s.restarting = true
+		s.mu.Unlock()
+		s.resumeWith(req, goja.DebugContinue, nil)
+	case "continue":
+		s.resumeWith(req, goja.DebugContinue, ContinueResponseBody{AllThreadsContinued: true})
+	case "next":
+		s.resumeWith(req, goja.DebugStepOver, nil)
+	case "stepIn":
+		s.resumeWith(req, goja.DebugStepInto, nil)
+	case "stepOut":
+		s.resumeWith(req, goja.DebugStepOut, nil)
+	case "pause":
+		s.mu.Lock()
+		s.pauseRequested = true
+		s.mu.Unlock()
+		s.dbg.Pause()
+		s.respond(req, nil, nil)
+	case "disconnect", "terminate":
+		var args DisconnectArguments
+		_ = unmarshalArgs(req, &args)
+		s.mu.Lock()
+		terminate := s.running && (req.Command == "terminate" || args.TerminateDebuggee == nil && s.launchArgs != nil ||
+			args.TerminateDebuggee != nil && *args.TerminateDebuggee)
+		s.mu.Unlock()
+		if terminate {
+			// A stopped program is resumed when the session ends and sees the interrupt then
+			s.r.Interrupt("terminated by the debugger")
+		}
+		s.respond(req, nil, nil)
+		if req.Command == "disconnect" {
+			return false
+		}
+	default:
+		s.respond(req, nil, fmt.Errorf("unsupported request %q", req.Command))
+	}
+	return true
+}
+
+func unmarshalArgs(req *Request, args interface{}) error {
+	if len(req.Arguments) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(req.Arguments, args); err != nil {
+		return fmt.Errorf("invalid arguments for %s: %w", req.Command, err)
+	}
+	return nil
+}
+
+// start runs the launched program.
+func (s *Server) start() {
+	args := s.launchArgs
+	s.mu.Lock()
+	s.running = true
+	s.stopOnEntry = args.StopOnEntry
+	s.mu.Unlock()
+	if args.StopOnEntry {
+		s.dbg.SetStepMode(true)
+	}
+	done := s.done
+	go func() {
+		exitCode := 0
+		if err := s.launch(s.r, args); err != nil {
+			exitCode = 1
+			var ex *goja.Exception
+			if errors.As(err, &ex) {
+				s.Output("stderr", ex.String()+"\n")
+			} else {
+				s.Output("stderr", err.Error()+"\n")
+			}
+		}
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+		select {
+		case <-done:
+			return
+		default:
+		}
+		s.sendEvent("exited", ExitedEventBody{ExitCode: exitCode})
+		s.sendEvent("terminated", nil)
+	}()
+}
+
+// watch receives the pauses of the program until the session ends.
+func (s *Server) watch(a *goja.AsyncDebugger, done chan struct{}) {
+	for {
+		select {
+		case state := <-a.Events():
+			s.onPause(a, state)
+		case <-done:
+			return
+		}
+	}
+}
+
+// onPause reports a stop to the client. Pauses where there is nothing to show, such as native calls,
+// are stepped through.
+func (s *Server) onPause(a *goja.AsyncDebugger, state *goja.DebuggerState) {
+	s.mu.Lock()
+	lastCmd := s.lastCmd
+	s.mu.Unlock()
+
+	if state.InNativeCall && !s.dbg.ShouldStepInNativeCall() || state.SourcePos.Line == 0 {
+		// Keep going until the next line of JS code
+		if lastCmd == goja.DebugContinue {
+			lastCmd = goja.DebugStepInto
+		}
+		_ = a.Resume(lastCmd)
+		return
+	}
+
+	body := StoppedEventBody{
+		Reason:            "step",
+		ThreadID:          threadID,
+		AllThreadsStopped: true,
+	}
+	s.mu.Lock()
+	switch {
+	case s.stopOnEntry:
+		body.Reason = "entry"
+		s.stopOnEntry = false
+	case s.restarting:
+		body.Reason = "restart"
+	case state.Exception != nil:
+		body.Reason = "exception"
+		body.Description = "Paused on exception"
+		if state.Uncaught {
+			body.Description = "Paused on uncaught exception"
+		}
+	case state.DataChange != nil:
+		body.Reason = "data breakpoint"
+		body.Description = "Paused on change of " + state.DataChange.Breakpoint.Name()
+		body.HitBreakpointIDs = []int{state.DataChange.Breakpoint.ID()}
+	case state.Breakpoint != nil:
+		body.Reason = "breakpoint"
+		body.HitBreakpointIDs = []int{state.Breakpoint.ID()}
+	case s.pauseRequested:
+		body.Reason = "pause"
+	case !state.StepMode || lastCmd == goja.DebugContinue:
+		body.Reason = "pause"
+		body.Description = "Paused on debugger statement"
+	}
+	s.pauseRequested = false
+	s.restarting = false
+	s.state = state
+	s.mu.Unlock()
+
+	s.sendEvent("stopped", body)
+}
+
+// onVM runs f on the VM goroutine while the program is stopped.
+func (s *Server) onVM(f func(state *goja.DebuggerState)) error {
+	s.mu.Lock()
+	stopped := s.state != nil
+	s.mu.Unlock()
+	if !stopped {
+		return errNotStopped
+	}
+	if err := s.async.Do(f); err != nil {
+		return errNotStopped
+	}
+	return nil
+}
+
+func (s *Server) resumeWith(req *Request, cmd goja.DebugCommand, body interface{}) {
+	s.mu.Lock()
+	stopped := s.state != nil
+	s.state = nil
+	s.lastCmd = cmd
+	s.mu.Unlock()
+	if !stopped {
+		s.respond(req, nil, errNotStopped)
+		return
+	}
+	s.respond(req, body, nil)
+	s.sendEvent("continued", ContinueResponseBody{AllThreadsContinued: true})
+	_ = s.async.Resume(cmd)
+}
+
+func (s *Server) setBreakpoints(args *SetBreakpointsArguments) SetBreakpointsResponseBody {
+	path := args.Source.Path
+	if path == "" {
+		path = args.Source.Name
+	}
+	for _, id := range s.breakpoints[path] {
+		s.dbg.RemoveBreakpoint(id)
+	}
+
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+
+	ids := make([]int, 0, len(args.Breakpoints))
+	body := SetBreakpointsResponseBody{Breakpoints: make([]Breakpoint, 0, len(args.Breakpoints))}
+	for _, sbp := range args.Breakpoints {
+		bp := Breakpoint{
+			Source: &Source{Name: filepath.Base(path), Path: path},
+			Line:   sbp.Line,
+			Column: sbp.Column,
+		}
+		id, err := s.dbg.AddBreakpointWithOptions(path, sbp.Line, sbp.Column, goja.BreakpointOptions{
+			Condition:    sbp.Condition,
+			HitCondition: sbp.HitCondition,
+			LogMessage:   sbp.LogMessage,
+		})
+		if err != nil {
+			bp.Message = err.Error()
+			body.Breakpoints = append(body.Breakpoints, bp)
+			continue
+		}
+		ids = append(ids, id)
+		bp.ID = id
+		// A breakpoint in a script that hasn't been loaded yet is resolved when it runs
+		if s.resolved(id) || !running {
+			bp.Verified = true
+		} else {
+			bp.Message = "No code at this location"
+		}
+		body.Breakpoints = append(body.Breakpoints, bp)
+	}
+	s.breakpoints[path] = ids
+	return body
+}
+
+// setDataBreakpoints replaces the data breakpoints. They are bound to the variables of the current pause,
+// so they can only be set while the program is stopped.
+func (s *Server) setDataBreakpoints(args *SetDataBreakpointsArguments) SetBreakpointsResponseBody {
+	for _, id := range s.dataBreakpoints {
+		s.dbg.RemoveDataBreakpoint(id)
+	}
+	s.dataBreakpoints = nil
+
+	body := SetBreakpointsResponseBody{Breakpoints: make([]Breakpoint, len(args.Breakpoints))}
+	err := s.onVM(func(*goja.DebuggerState) {
+		for i, dbp := range args.Breakpoints {
+			bp := &body.Breakpoints[i]
+			refStr, name, found := strings.Cut(dbp.DataID, "/")
+			ref, err := strconv.Atoi(refStr)
+			if !found || err != nil {
+				bp.Message = "invalid data id"
+				continue
+			}
+			id, err := s.dbg.AddDataBreakpoint(ref, name)
+			if err != nil {
+				bp.Message = err.Error()
+				continue
+			}
+			s.dataBreakpoints = append(s.dataBreakpoints, id)
+			bp.ID = id
+			bp.Verified = true
+		}
+	})
+	if err != nil {
+		for i := range body.Breakpoints {
+			body.Breakpoints[i].Message = err.Error()
+		}
+	}
+	return body
+}
+
+func (s *Server) resolved(id int) bool {
+	for _, bp := range s.dbg.GetBreakpoints() {
+		if bp.ID() == id {
+			return bp.Resolved()
+		}
+	}
+	return false
+}
+
+func (s *Server) stackTrace(state *goja.DebuggerState, args *StackTraceArguments) StackTraceResponseBody {
+	frames := state.DebugStack
+	body := StackTraceResponseBody{
+		StackFrames: []StackFrame{},
+		TotalFrames: len(frames),
+	}
+	start := args.StartFrame
+	if start > len(frames) {
+		start = len(frames)
+	}
+	end := len(frames)
+	if args.Levels > 0 && start+args.Levels < end {
+		end = start + args.Levels
+	}
+	for i := start; i < end; i++ {
+		frame := &frames[i].StackFrame
+		sf := StackFrame{
+			// Frame ids are 1-based, some clients treat 0 as "no frame"
+			ID:         i + 1,
+			Name:       frame.FuncName(),
+			CanRestart: frames[i].CanRestart,
+		}
+		if frame.SrcName() == "<native>" {
+			sf.PresentationHint = "subtle"
+		} else {
+			pos := frame.Position()
+			sf.Line = pos.Line
+			sf.Column = pos.Column
+			if pos.Filename != "" {
+				sf.Source = &Source{Name: filepath.Base(pos.Filename), Path: pos.Filename}
+			}
+		}
+		body.StackFrames = append(body.StackFrames, sf)
+	}
+	return body
+}
+
+func (s *Server) scopes(state *goja.DebuggerState, frameID int) (ScopesResponseBody, error) {
+	idx := frameID - 1
+	if idx < 0 || idx >= len(state.DebugStack) {
+		return ScopesResponseBody{}, fmt.Errorf("invalid frame id %d", frameID)
+	}
+	scopes := state.DebugStack[idx].Scopes
+	body := ScopesResponseBody{Scopes: make([]Scope, 0, len(scopes))}
+	for _, scope := range scopes {
+		body.Scopes = append(body.Scopes, Scope{
+			Name:               scope.Name,
+			VariablesReference: scope.VariablesRef,
+			Expensive:          scope.Expensive,
+		})
+	}
+	return body, nil
+}
+
+func (s *Server) variables(ref int) VariablesResponseBody {
+	vars := s.dbg.GetVariables(ref)
+	body := VariablesResponseBody{Variables: make([]Variable, 0, len(vars))}
+	for _, v := range vars {
+		body.Variables = append(body.Variables, Variable{
+			Name:               v.Name,
+			Value:              formatValue(v.Value),
+			Type:               v.Type,
+			EvaluateName:       v.GeneratedName,
+			VariablesReference: v.Ref,
+		})
+	}
+	sort.Slice(body.Variables, func(i, j int) bool {
+		return body.Variables[i].Name < body.Variables[j].Name
+	})
+	return body
+}
+
+func (s *Server) evaluate(args *EvaluateArguments) (EvaluateResponseBody, error) {
+	var v goja.Value
+	var err error
+	if args.FrameID != nil {
+		v, err = s.dbg.EvaluateInFrame(args.Expression, *args.FrameID-1)
+	} else {
+		v, err = s.dbg.Evaluate(args.Expression, 0)
+	}
+	if err != nil {
+		return EvaluateResponseBody{}, scriptError(err)
+	}
+	return EvaluateResponseBody{
+		Result:             formatValue(v),
+		VariablesReference: s.dbg.ValueRef(v),
+	}, nil
+}
+
+func (s *Server) setVariable(args *SetVariableArguments) (SetVariableResponseBody, error) {
+	v, err := s.dbg.SetVariable(args.VariablesReference, args.Name, args.Value)
+	if err != nil {
+		return SetVariableResponseBody{}, scriptError(err)
+	}
+	return SetVariableResponseBody{
+		Value:              formatValue(v.Value),
+		Type:               v.Type,
+		VariablesReference: v.Ref,
+	}, nil
+}
+
+// scriptError turns a JS exception into an error whose message is the thrown value, which is what
+// the client shows.
+func scriptError(err error) error {
+	var ex *goja.Exception
+	if errors.As(err, &ex) {
+		return errors.New(ex.Value().String())
+	}
+	return err
+}
+
+func exceptionInfo(state *goja.DebuggerState) (ExceptionInfoResponseBody, error) {
+	if state.Exception == nil {
+		return ExceptionInfoResponseBody{}, errors.New("not stopped on an exception")
+	}
+	val := state.Exception.Value()
+	info := ExceptionInfoResponseBody{
+		ExceptionID: "Exception",
+		Description: val.String(),
+		BreakMode:   "always",
+	}
+	if state.Uncaught {
+		info.BreakMode = "unhandled"
+	}
+	if o, ok := val.(*goja.Object); ok {
+		if name := o.Get("name"); name != nil && !goja.IsUndefined(name) {
+			info.ExceptionID = name.String()
+		}
+	}
+	return info, nil
+}
+
+// formatValue returns the representation of a value shown by the client.
+func formatValue(v goja.Value) string {
+	if v == nil || goja.IsUndefined(v) {
+		return "undefined"
+	}
+	if goja.IsNull(v) {
+		return "null"
+	}
+	if o, ok := v.(*goja.Object); ok {
+		if _, isFunc := goja.AssertFunction(o); isFunc {
+			name := o.Get("name")
+			if name == nil || name.String() == "" {
+				return "function"
+			}
+			return "function " + name.String()
+		}
+		className := o.ClassName()
+		if className == "Array" {
+			return "Array(" + o.Get("length").String() + ")"
+		}
+		if className == "Object" {
+			if ctor, ok := o.Get("constructor").(*goja.Object); ok {
+				if name := ctor.Get("name"); name != nil && name.String() != "" {
+					return name.String()
+				}
+			}
+		}
+		return className
+	}
+	if t := v.ExportType(); t != nil && t.Kind() == reflect.String {
+		return strconv.Quote(v.String())
+	}
+	return v.String()
+}
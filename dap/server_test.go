@@ -0,0 +1,549 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// client is an in-process DAP client talking to a Server over pipes.
+type client struct {
+	t    *testing.T
+	w    io.WriteCloser
+	seq  int
+	msgs chan map[string]interface{}
+	errc chan error
+}
+
+func newClient(t *testing.T, s *Server) *client {
+	cr, sw := io.Pipe()
+	sr, cw := io.Pipe()
+	c := &client{
+		t:    t,
+		w:    cw,
+		msgs: make(chan map[string]interface{}, 100),
+		errc: make(chan error, 1),
+	}
+	go func() {
+		c.errc <- s.Serve(sr, sw)
+		sw.Close()
+	}()
+	go func() {
+		br := bufio.NewReader(cr)
+		for {
+			b, err := ReadMessage(br)
+			if err != nil {
+				close(c.msgs)
+				return
+			}
+			var m map[string]interface{}
+			if err := json.Unmarshal(b, &m); err != nil {
+				panic(err)
+			}
+			c.msgs <- m
+		}
+	}()
+	return c
+}
+
+func (c *client) send(command string, args interface{}) int {
+	c.seq++
+	req := map[string]interface{}{
+		"seq":     c.seq,
+		"type":    "request",
+		"command": command,
+	}
+	if args != nil {
+		req["arguments"] = args
+	}
+	if err := WriteMessage(c.w, req); err != nil {
+		c.t.Fatal(err)
+	}
+	return c.seq
+}
+
+func (c *client) next() map[string]interface{} {
+	select {
+	case m, ok := <-c.msgs:
+		if !ok {
+			c.t.Fatal("connection closed")
+		}
+		return m
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timed out waiting for a message")
+	}
+	return nil
+}
+
+// expectEvent skips messages until it gets the event.
+func (c *client) expectEvent(event string) map[string]interface{} {
+	for {
+		m := c.next()
+		if m["type"] == "event" && m["event"] == event {
+			return m
+		}
+	}
+}
+
+// request sends a request and waits for its response, skipping events.
+func (c *client) request(command string, args interface{}) map[string]interface{} {
+	seq := c.send(command, args)
+	for {
+		m := c.next()
+		if m["type"] == "response" && int(m["request_seq"].(float64)) == seq {
+			return m
+		}
+	}
+}
+
+func (c *client) mustRequest(command string, args interface{}) map[string]interface{} {
+	resp := c.request(command, args)
+	if resp["success"] != true {
+		c.t.Fatalf("%s failed: %v", command, resp["message"])
+	}
+	body, _ := resp["body"].(map[string]interface{})
+	return body
+}
+
+func (c *client) close() {
+	c.mustRequest("disconnect", nil)
+	c.w.Close()
+	select {
+	case err := <-c.errc:
+		if err != nil {
+			c.t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("Serve did not return")
+	}
+}
+
+func launchScript(src string) LaunchFunc {
+	return func(r *goja.Runtime, args *LaunchArguments) error {
+		_, err := r.RunScript(args.Program, src)
+		return err
+	}
+}
+
+func newTestServer(src string) *Server {
+	s := NewServer(goja.NewWithOptions(goja.RuntimeOptions{EnableDebugMode: true}))
+	s.SetLauncher(launchScript(src))
+	return s
+}
+
+func (c *client) start(breakpoints ...int) {
+	c.mustRequest("initialize", map[string]interface{}{"adapterID": "goja"})
+	c.expectEvent("initialized")
+	if len(breakpoints) > 0 {
+		bps := make([]map[string]interface{}, len(breakpoints))
+		for i, line := range breakpoints {
+			bps[i] = map[string]interface{}{"line": line}
+		}
+		body := c.mustRequest("setBreakpoints", map[string]interface{}{
+			"source":      map[string]interface{}{"path": "test.js"},
+			"breakpoints": bps,
+		})
+		if n := len(body["breakpoints"].([]interface{})); n != len(breakpoints) {
+			c.t.Fatalf("got %d breakpoints", n)
+		}
+	}
+	c.mustRequest("launch", map[string]interface{}{"program": "test.js"})
+	c.mustRequest("configurationDone", nil)
+}
+
+func (c *client) topFrame() map[string]interface{} {
+	body := c.mustRequest("stackTrace", map[string]interface{}{"threadId": 1})
+	frames := body["stackFrames"].([]interface{})
+	if len(frames) == 0 {
+		c.t.Fatal("empty stack")
+	}
+	return frames[0].(map[string]interface{})
+}
+
+func (c *client) variables(ref float64) map[string]string {
+	body := c.mustRequest("variables", map[string]interface{}{"variablesReference": ref})
+	vars := make(map[string]string)
+	for _, v := range body["variables"].([]interface{}) {
+		v := v.(map[string]interface{})
+		vars[v["name"].(string)] = v["value"].(string)
+	}
+	return vars
+}
+
+func TestBreakpointAndVariables(t *testing.T) {
+	const SCRIPT = `
+function add(a, b) {
+	var sum = a + b;
+	return sum;
+}
+var result = add(1, 2);
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start(4)
+
+	stopped := c.expectEvent("stopped")
+	if reason := stopped["body"].(map[string]interface{})["reason"]; reason != "breakpoint" {
+		t.Fatalf("reason: %v", reason)
+	}
+
+	frame := c.topFrame()
+	if frame["name"] != "add" || frame["line"].(float64) != 4 {
+		t.Fatalf("top frame: %v", frame)
+	}
+
+	body := c.mustRequest("scopes", map[string]interface{}{"frameId": frame["id"]})
+	scopes := body["scopes"].([]interface{})
+	local := scopes[0].(map[string]interface{})
+	if local["name"] != "Local" {
+		t.Fatalf("first scope: %v", local)
+	}
+	vars := c.variables(local["variablesReference"].(float64))
+	if vars["a"] != "1" || vars["b"] != "2" || vars["sum"] != "3" {
+		t.Fatalf("variables: %v", vars)
+	}
+
+	body = c.mustRequest("evaluate", map[string]interface{}{"expression": "sum * 10", "frameId": frame["id"]})
+	if body["result"] != "30" {
+		t.Fatalf("evaluate: %v", body)
+	}
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	exited := c.expectEvent("exited")
+	if code := exited["body"].(map[string]interface{})["exitCode"]; code != float64(0) {
+		t.Fatalf("exit code: %v", code)
+	}
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestStepping(t *testing.T) {
+	const SCRIPT = `
+function f() {
+	var x = 1;
+	return x;
+}
+var y = f();
+var z = y + 1;
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start(6)
+	c.expectEvent("stopped")
+
+	c.mustRequest("stepIn", map[string]interface{}{"threadId": 1})
+	c.expectEvent("stopped")
+	if frame := c.topFrame(); frame["name"] != "f" {
+		t.Fatalf("after stepIn: %v", frame)
+	}
+
+	c.mustRequest("stepOut", map[string]interface{}{"threadId": 1})
+	c.expectEvent("stopped")
+	if frame := c.topFrame(); frame["line"].(float64) != 6 && frame["line"].(float64) != 7 {
+		t.Fatalf("after stepOut: %v", frame)
+	}
+
+	c.mustRequest("next", map[string]interface{}{"threadId": 1})
+	c.expectEvent("stopped")
+	if frame := c.topFrame(); frame["line"].(float64) != 7 {
+		t.Fatalf("after next: %v", frame)
+	}
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestObjectVariables(t *testing.T) {
+	const SCRIPT = `
+var obj = {name: "test", nested: {value: 42}};
+debugger;
+obj = null;
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start()
+	c.expectEvent("stopped")
+
+	body := c.mustRequest("evaluate", map[string]interface{}{"expression": "obj", "frameId": 1})
+	ref := body["variablesReference"].(float64)
+	if ref == 0 {
+		t.Fatal("no reference for an object")
+	}
+	vars := c.variables(ref)
+	if vars["name"] != `"test"` {
+		t.Fatalf("variables: %v", vars)
+	}
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestRequestsWhileRunning(t *testing.T) {
+	const SCRIPT = `
+var i = 0;
+while (true) {
+	i++;
+}
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start()
+
+	if resp := c.request("stackTrace", map[string]interface{}{"threadId": 1}); resp["success"] != false {
+		t.Fatalf("stackTrace while running: %v", resp)
+	}
+
+	c.mustRequest("pause", map[string]interface{}{"threadId": 1})
+	stopped := c.expectEvent("stopped")
+	if reason := stopped["body"].(map[string]interface{})["reason"]; reason != "pause" {
+		t.Fatalf("reason: %v", reason)
+	}
+	c.mustRequest("threads", nil)
+	c.topFrame()
+
+	c.mustRequest("disconnect", map[string]interface{}{"terminateDebuggee": true})
+	c.w.Close()
+	if err := <-c.errc; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUncaughtException(t *testing.T) {
+	c := newClient(t, newTestServer(`throw new Error("boom");`))
+	c.start()
+	for {
+		output := c.expectEvent("output")
+		body := output["body"].(map[string]interface{})
+		if body["category"] == "stderr" {
+			break
+		}
+	}
+	exited := c.expectEvent("exited")
+	if code := exited["body"].(map[string]interface{})["exitCode"]; code != float64(1) {
+		t.Fatalf("exit code: %v", code)
+	}
+	c.close()
+}
+
+func TestConditionalBreakpointsAndLogpoints(t *testing.T) {
+	const SCRIPT = `
+for (var i = 0; i < 10; i++) {
+	var x = i * 2;
+	var y = x + 1;
+}
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.mustRequest("initialize", map[string]interface{}{"adapterID": "goja"})
+	c.expectEvent("initialized")
+	body := c.mustRequest("setBreakpoints", map[string]interface{}{
+		"source": map[string]interface{}{"path": "test.js"},
+		"breakpoints": []map[string]interface{}{
+			{"line": 3, "logMessage": "i is {i}", "condition": "i < 2"},
+			{"line": 4, "condition": "x > 10", "hitCondition": "== 2"},
+			{"line": 4, "hitCondition": "nonsense"},
+		},
+	})
+	bps := body["breakpoints"].([]interface{})
+	if bps[0].(map[string]interface{})["verified"] != true || bps[2].(map[string]interface{})["verified"] != false {
+		t.Fatalf("breakpoints: %v", bps)
+	}
+	c.mustRequest("launch", map[string]interface{}{"program": "test.js"})
+	c.mustRequest("configurationDone", nil)
+
+	for _, expected := range []string{"i is 0\n", "i is 1\n"} {
+		output := c.expectEvent("output")["body"].(map[string]interface{})
+		if output["category"] != "console" || output["output"] != expected {
+			t.Fatalf("output: %v", output)
+		}
+	}
+
+	c.expectEvent("stopped")
+	body = c.mustRequest("evaluate", map[string]interface{}{"expression": "i", "frameId": 1})
+	if body["result"] != "7" {
+		t.Fatalf("stopped at i=%v", body["result"])
+	}
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestExceptionBreakpoints(t *testing.T) {
+	const SCRIPT = `
+try {
+	throw new Error("caught");
+} catch (e) {
+}
+throw new TypeError("boom");
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.mustRequest("initialize", map[string]interface{}{"adapterID": "goja"})
+	c.expectEvent("initialized")
+	c.mustRequest("setExceptionBreakpoints", map[string]interface{}{"filters": []string{"uncaught"}})
+	c.mustRequest("launch", map[string]interface{}{"program": "test.js"})
+	c.mustRequest("configurationDone", nil)
+
+	stopped := c.expectEvent("stopped")["body"].(map[string]interface{})
+	if stopped["reason"] != "exception" {
+		t.Fatalf("stopped: %v", stopped)
+	}
+	if frame := c.topFrame(); frame["line"].(float64) != 6 {
+		t.Fatalf("top frame: %v", frame)
+	}
+	info := c.mustRequest("exceptionInfo", map[string]interface{}{"threadId": 1})
+	if info["exceptionId"] != "TypeError" || info["description"] != "TypeError: boom" || info["breakMode"] != "unhandled" {
+		t.Fatalf("exceptionInfo: %v", info)
+	}
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	exited := c.expectEvent("exited")
+	if code := exited["body"].(map[string]interface{})["exitCode"]; code != float64(1) {
+		t.Fatalf("exit code: %v", code)
+	}
+	c.close()
+}
+
+func TestDataBreakpoints(t *testing.T) {
+	const SCRIPT = `
+function run() {
+	var state = {count: 0};
+	for (var i = 0; i < 3; i++) {
+		state.count += i;
+	}
+	return state;
+}
+run();
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start(4)
+	c.expectEvent("stopped")
+
+	frame := c.topFrame()
+	body := c.mustRequest("scopes", map[string]interface{}{"frameId": frame["id"]})
+	local := body["scopes"].([]interface{})[0].(map[string]interface{})
+	body = c.mustRequest("variables", map[string]interface{}{"variablesReference": local["variablesReference"]})
+	var stateRef float64
+	for _, v := range body["variables"].([]interface{}) {
+		v := v.(map[string]interface{})
+		if v["name"] == "state" {
+			stateRef = v["variablesReference"].(float64)
+		}
+	}
+	if stateRef == 0 {
+		t.Fatal("state not found")
+	}
+
+	info := c.mustRequest("dataBreakpointInfo", map[string]interface{}{"variablesReference": stateRef, "name": "count"})
+	body = c.mustRequest("setDataBreakpoints", map[string]interface{}{
+		"breakpoints": []map[string]interface{}{{"dataId": info["dataId"]}, {"dataId": "bogus"}},
+	})
+	bps := body["breakpoints"].([]interface{})
+	if bps[0].(map[string]interface{})["verified"] != true || bps[1].(map[string]interface{})["verified"] != false {
+		t.Fatalf("data breakpoints: %v", bps)
+	}
+	// The line breakpoint is not needed anymore
+	c.mustRequest("setBreakpoints", map[string]interface{}{"source": map[string]interface{}{"path": "test.js"}})
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	// count goes 0 -> 0 -> 1 -> 3, every write is reported even if it doesn't change the value
+	for _, expected := range []string{"0", "1", "3"} {
+		stopped := c.expectEvent("stopped")["body"].(map[string]interface{})
+		if stopped["reason"] != "data breakpoint" {
+			t.Fatalf("stopped: %v", stopped)
+		}
+		body = c.mustRequest("evaluate", map[string]interface{}{"expression": "state.count", "frameId": 1})
+		if body["result"] != expected {
+			t.Fatalf("state.count = %v, expected %s", body["result"], expected)
+		}
+		c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	}
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestSetVariable(t *testing.T) {
+	const SCRIPT = `
+function run(limit) {
+	var total = 1;
+	return total;
+}
+var result = run(3);
+result;
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start(4, 7)
+	c.expectEvent("stopped")
+
+	frame := c.topFrame()
+	body := c.mustRequest("scopes", map[string]interface{}{"frameId": frame["id"]})
+	local := body["scopes"].([]interface{})[0].(map[string]interface{})
+	ref := local["variablesReference"]
+
+	body = c.mustRequest("setVariable", map[string]interface{}{"variablesReference": ref, "name": "total", "value": "limit * 10"})
+	if body["value"] != "30" {
+		t.Fatalf("setVariable: %v", body)
+	}
+	if resp := c.request("setVariable", map[string]interface{}{"variablesReference": ref, "name": "missing", "value": "1"}); resp["success"] != false {
+		t.Fatalf("setVariable of a missing variable: %v", resp)
+	}
+	if resp := c.request("setVariable", map[string]interface{}{"variablesReference": ref, "name": "total", "value": "nope"}); resp["success"] != false || resp["message"] != "ReferenceError: nope is not defined" {
+		t.Fatalf("setVariable with a failing expression: %v", resp)
+	}
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("stopped")
+	body = c.mustRequest("evaluate", map[string]interface{}{"expression": "result", "frameId": 1})
+	if body["result"] != "30" {
+		t.Fatalf("result = %v", body["result"])
+	}
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestRestartFrame(t *testing.T) {
+	const SCRIPT = `
+function run(limit) {
+	var total = limit * 2;
+	return total;
+}
+var result = run(3);
+result;
+`
+	c := newClient(t, newTestServer(SCRIPT))
+	c.start(4)
+	c.expectEvent("stopped")
+
+	frames := c.mustRequest("stackTrace", map[string]interface{}{"threadId": 1})["stackFrames"].([]interface{})
+	if len(frames) != 2 || frames[0].(map[string]interface{})["canRestart"] != true || frames[1].(map[string]interface{})["canRestart"] != false {
+		t.Fatalf("Unexpected frames: %v", frames)
+	}
+	if resp := c.request("restartFrame", map[string]interface{}{"frameId": 2}); resp["success"] != false {
+		t.Fatalf("restartFrame of the global code: %v", resp)
+	}
+	c.mustRequest("restartFrame", map[string]interface{}{"frameId": 1})
+	body := c.expectEvent("stopped")["body"].(map[string]interface{})
+	if body["reason"] != "restart" {
+		t.Fatalf("stopped: %v", body)
+	}
+	if frame := c.topFrame(); frame["name"] != "run" || frame["line"] != float64(2) {
+		t.Fatalf("Unexpected frame after restart: %v", frame)
+	}
+
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("stopped")
+	c.mustRequest("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("terminated")
+	c.close()
+}
+
+func TestReadMessageTooBig(t *testing.T) {
+	for _, cl := range []string{"9223372036854775807", "1073741824"} {
+		br := bufio.NewReader(strings.NewReader("Content-Length: " + cl + "\r\n\r\n{}"))
+		if _, err := ReadMessage(br); err != errMessageTooBig {
+			t.Fatalf("Content-Length %s: unexpected error %v", cl, err)
+		}
+	}
+}
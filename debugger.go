@@ -1,11 +1,18 @@
 package goja
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+
+	"github.com/dop251/goja/file"
+	"github.com/dop251/goja/parser"
+	"github.com/dop251/goja/unistring"
 )
 
 // DebugFlags controls the debugging behavior
@@ -29,9 +36,44 @@ type Position struct {
 type Breakpoint struct {
 	id        int
 	SourcePos Position // Position in source code
+	prg       *Program // Program the breakpoint is resolved in (nil if not resolved)
 	pc        int      // Program counter position (-1 if not resolved)
 	enabled   bool
 	hit       int // Number of times this breakpoint was hit
+
+	condition    string        // JS expression, the breakpoint only fires when it's truthy
+	hitCondition string        // Hit count condition as given, e.g. ">= 5" or "% 10 == 0"
+	hitCond      *hitCondition // Parsed hitCondition
+	logMessage   string        // Message template of a logpoint, logpoints never pause
+}
+
+// BreakpointOptions holds the optional settings of a breakpoint
+type BreakpointOptions struct {
+	// Condition is a JS expression evaluated in the scope of the paused frame each time the breakpoint
+	// is reached. The breakpoint is ignored unless the result is truthy. An exception thrown by the
+	// condition counts as true, so that a broken condition doesn't go unnoticed.
+	Condition string
+
+	// HitCondition controls which hits of the breakpoint fire. The hit count only includes the hits
+	// where Condition was true. The supported forms are "N", ">= N", "> N", "== N", "= N", "!= N",
+	// "< N", "<= N", "% N" and "% N == M". A plain number is the same as ">= N", "% N" is the same
+	// as "% N == 0".
+	HitCondition string
+
+	// LogMessage turns the breakpoint into a logpoint: instead of pausing, the message is sent to the
+	// logpoint handler (see SetLogpointHandler). Expressions enclosed in {} are evaluated in the
+	// paused frame and interpolated, "{{" and "}}" stand for literal braces.
+	LogMessage string
+}
+
+// LogpointHandler receives the messages of logpoints
+type LogpointHandler func(bp *Breakpoint, message string)
+
+// breakpointLocation identifies an instruction within a compiled function. Every function has its own
+// Program, so a pc alone is ambiguous.
+type breakpointLocation struct {
+	prg *Program
+	pc  int
 }
 
 // ID returns the breakpoint ID
@@ -39,12 +81,116 @@ func (b *Breakpoint) ID() int {
 	return b.id
 }
 
+// Resolved returns true if the breakpoint has been bound to an instruction in a loaded program
+func (b *Breakpoint) Resolved() bool {
+	return b.pc >= 0
+}
+
+// Enabled returns true if the breakpoint is enabled
+func (b *Breakpoint) Enabled() bool {
+	return b.enabled
+}
+
+// HitCount returns the number of times the breakpoint was hit
+func (b *Breakpoint) HitCount() int {
+	return b.hit
+}
+
+// Condition returns the condition expression of the breakpoint, if any
+func (b *Breakpoint) Condition() string {
+	return b.condition
+}
+
+// HitCondition returns the hit count condition of the breakpoint, if any
+func (b *Breakpoint) HitCondition() string {
+	return b.hitCondition
+}
+
+// LogMessage returns the message template if the breakpoint is a logpoint
+func (b *Breakpoint) LogMessage() string {
+	return b.logMessage
+}
+
+// IsLogpoint returns true if the breakpoint logs a message instead of pausing
+func (b *Breakpoint) IsLogpoint() bool {
+	return b.logMessage != ""
+}
+
+// needsEvaluation returns true if the VM has to run code to decide whether the breakpoint fires
+func (b *Breakpoint) needsEvaluation() bool {
+	return b.condition != "" || b.hitCond != nil || b.logMessage != ""
+}
+
+// hitCondition is a parsed BreakpointOptions.HitCondition
+type hitCondition struct {
+	op     string // "==", "!=", ">", ">=", "<", "<=" or "%"
+	n      int
+	remain int // Expected remainder for "%"
+}
+
+func parseHitCondition(s string) (*hitCondition, error) {
+	expr := strings.TrimSpace(s)
+	if expr == "" {
+		return nil, nil
+	}
+	c := &hitCondition{op: ">="}
+	for _, op := range []string{">=", "<=", "==", "!=", ">", "<", "=", "%"} {
+		if strings.HasPrefix(expr, op) {
+			c.op = op
+			expr = strings.TrimSpace(expr[len(op):])
+			break
+		}
+	}
+	if c.op == "=" {
+		c.op = "=="
+	}
+	if c.op == "%" {
+		if i := strings.Index(expr, "=="); i >= 0 {
+			m, err := strconv.Atoi(strings.TrimSpace(expr[i+2:]))
+			if err != nil || m < 0 {
+				return nil, fmt.Errorf("invalid hit condition: %q", s)
+			}
+			c.remain = m
+			expr = strings.TrimSpace(expr[:i])
+		}
+	}
+	n, err := strconv.Atoi(expr)
+	if err != nil || n < 0 || c.op == "%" && (n == 0 || c.remain >= n) {
+		return nil, fmt.Errorf("invalid hit condition: %q", s)
+	}
+	c.n = n
+	return c, nil
+}
+
+func (c *hitCondition) match(hits int) bool {
+	switch c.op {
+	case "==":
+		return hits == c.n
+	case "!=":
+		return hits != c.n
+	case ">":
+		return hits > c.n
+	case "<":
+		return hits < c.n
+	case "<=":
+		return hits <= c.n
+	case "%":
+		return hits%c.n == c.remain
+	}
+	return hits >= c.n
+}
+
 // Variable represents a variable in the debug context
 type Variable struct {
 	Name  string
 	Value Value
 	Type  string
 	Ref   int // Reference ID for complex types (objects, arrays)
+
+	// GeneratedName is the name of the variable in the code that runs if it has been renamed by the tool
+	// that generated the code, in which case Name is the name in the original source (see AddBreakpoint).
+	// Expressions must use GeneratedName.
+	GeneratedName string
 }
 
 // Scope represents a variable scope
@@ -59,8 +205,9 @@ type Scope struct {
 // DebugStackFrame extends StackFrame with debug information
 type DebugStackFrame struct {
 	StackFrame
-	Scopes []Scope
-	This   *Variable // The 'this' value in the context
+	Scopes     []Scope
+	This       *Variable // The 'this' value in the context
+	CanRestart bool      // Whether the frame can be restarted with RestartFrame
 }
 
 // DebuggerState represents the current state when paused
@@ -71,10 +218,26 @@ type DebuggerState struct {
 	DebugStack         []DebugStackFrame // Extended stack frames with variable info
 	Breakpoint         *Breakpoint       // Current breakpoint if stopped at one
 	StepMode           bool
-	InNativeCall       bool   // True when executing native function
-	NativeFunctionName string // Name of the native function being executed
+	InNativeCall       bool          // True when executing native function
+	NativeFunctionName string        // Name of the native function being executed
+	Exception          *Exception    // Exception being thrown if paused on one (see SetExceptionBreakMode)
+	Uncaught           bool          // True if Exception is not going to be caught by JS code
+	DataChange         *DataChange   // Change that triggered a data breakpoint, if paused on one
+	Watches            []WatchResult // Values of the watch expressions, not evaluated in native calls
 }
 
+// ExceptionBreakMode controls when the debugger pauses on exceptions
+type ExceptionBreakMode int
+
+const (
+	// ExceptionBreakNone never pauses on exceptions (the default)
+	ExceptionBreakNone ExceptionBreakMode = iota
+	// ExceptionBreakUncaught pauses when an exception is thrown that no enclosing JS try/catch will catch
+	ExceptionBreakUncaught
+	// ExceptionBreakAll pauses whenever an exception is thrown
+	ExceptionBreakAll
+)
+
 // DebugHandler is called when the debugger pauses execution
 type DebugHandler func(state *DebuggerState) DebugCommand
 
@@ -99,17 +262,38 @@ type Debugger struct {
 	handler     DebugHandler
 
 	// Internal state
-	pcBreakpoints map[int]*Breakpoint // PC to breakpoint mapping for fast lookup
-	stepDepth     int                 // Call stack depth for step over/out
-	stepMode      DebugCommand
-	lastPC        int                 // Previous PC for step-over flow control
-	lastSourceLine int                // Previous source line for step-over flow control
-	continueStepAfterCall bool         // Continue stepping after a call instruction
+	pcBreakpoints         map[breakpointLocation]*Breakpoint // Location to breakpoint mapping for fast lookup
+	programs              map[string]*Program                // Latest top-level program run for each file name, see resolvePendingBreakpoints
+	stepDepth             int                                // Call stack depth for step over/out
+	stepMode              DebugCommand
+	lastPC                int         // Previous PC for step-over flow control
+	lastSourceLine        int         // Previous source line for step-over flow control
+	continueStepAfterCall bool        // Continue stepping after a call instruction
+	inHandler             bool        // True while the handler is running, suppresses nested pauses
+	hitBreakpoint         *Breakpoint // Breakpoint that caused the current pause
+	exceptionMode         ExceptionBreakMode
+	pauseException        *Exception // Exception that caused the current pause
+	pauseUncaught         bool
+	lastException         *Exception // Last exception paused on, so a rethrow across Go code is only reported once
+	watches               []*Watch
+	dataBreakpoints       map[int]*DataBreakpoint
+	watchingData          atomic.Bool     // Set while there are data breakpoints, checked by the instructions writing data
+	dataWrite             *DataBreakpoint // Watched location written by the last instruction, see noteDataWrite
+	dataChange            *DataChange     // Change that caused the current pause
+	logpointHandler       LogpointHandler
+	scripts               map[string]*Script // Latest script loaded for each file name
+	scriptHandler         ScriptHandler
+	nextScriptID          int
+	pauses                uint64 // Number of pauses that have ended, see frameScope
+	runTo                 *runToLocation
+	entries               []frameEntry // Function entries by call stack depth, see RestartFrame
+	canRestart            bool         // True while paused in a state RestartFrame can unwind
+	restarted             bool         // RestartFrame has been called during the current pause
 
 	// Variable reference management for DAP
 	variableRefs map[int]interface{} // Maps reference IDs to values or scopes
 	nextVarRef   int
-	
+
 	// Logger for debugging
 	logger *log.Logger
 }
@@ -124,16 +308,18 @@ func (r *Runtime) NewDebugger() *Debugger {
 	} else {
 		logger = log.New(os.Stderr, "[GOJA_CORE] ", log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)
 	}
-	
+
 	d := &Debugger{
-		runtime:       r,
-		breakpoints:   make(map[int]*Breakpoint),
-		pcBreakpoints: make(map[int]*Breakpoint),
-		variableRefs:  make(map[int]interface{}),
-		nextVarRef:    1000, // Start from 1000 to avoid conflicts with frame IDs
-		logger:        logger,
-	}
-	
+		runtime:         r,
+		breakpoints:     make(map[int]*Breakpoint),
+		pcBreakpoints:   make(map[breakpointLocation]*Breakpoint),
+		programs:        make(map[string]*Program),
+		dataBreakpoints: make(map[int]*DataBreakpoint),
+		variableRefs:    make(map[int]interface{}),
+		nextVarRef:      1000, // Start from 1000 to avoid conflicts with frame IDs
+		logger:          logger,
+	}
+
 	d.logger.Println("=== Debugger created ===")
 	return d
 }
@@ -146,7 +332,10 @@ func (d *Debugger) SetHandler(handler DebugHandler) {
 	d.logger.Println("SetHandler: Debug handler set")
 }
 
-// AddBreakpoint adds a breakpoint at the specified source position
+// AddBreakpoint adds a breakpoint at the specified source position. If the code has a source map, the
+// position can be in one of the original sources (e.g. a TypeScript file) as well as in the generated
+// file. Paused positions and stack frames are reported in the original sources, and so are the names of
+// the variables the source map renames.
 func (d *Debugger) AddBreakpoint(filename string, line, column int) int {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -166,14 +355,85 @@ func (d *Debugger) AddBreakpoint(filename string, line, column int) int {
 	d.breakpoints[bp.id] = bp
 	d.logger.Printf("AddBreakpoint: Added breakpoint #%d at %s:%d:%d\n", bp.id, filename, line, column)
 
-	// Try to resolve the breakpoint to a PC if we have a program loaded
-	if d.runtime.vm != nil && d.runtime.vm.prg != nil {
-		d.resolveBreakpoint(bp)
-	}
+	// Try to resolve the breakpoint to a PC if its file has been loaded
+	d.resolveBreakpoint(bp)
 
 	return bp.id
 }
 
+// AddBreakpointWithOptions adds a conditional breakpoint, a hit count breakpoint or a logpoint at
+// the specified source position. It fails if the condition doesn't compile, the hit condition
+// is malformed or a logpoint is requested without a logpoint handler.
+func (d *Debugger) AddBreakpointWithOptions(filename string, line, column int, opts BreakpointOptions) (int, error) {
+	if _, err := d.checkOptions(&opts); err != nil {
+		return -1, err
+	}
+	id := d.AddBreakpoint(filename, line, column)
+	return id, d.SetBreakpointOptions(id, opts)
+}
+
+// SetBreakpointOptions replaces the options of an existing breakpoint. The hit count is reset.
+func (d *Debugger) SetBreakpointOptions(id int, opts BreakpointOptions) error {
+	hitCond, err := d.checkOptions(&opts)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bp, exists := d.breakpoints[id]
+	if !exists {
+		return fmt.Errorf("no breakpoint with id %d", id)
+	}
+	bp.condition = opts.Condition
+	bp.hitCondition = opts.HitCondition
+	bp.hitCond = hitCond
+	bp.logMessage = opts.LogMessage
+	bp.hit = 0
+	return nil
+}
+
+// checkOptions validates the options of a breakpoint of this debugger and returns the parsed hit condition
+func (d *Debugger) checkOptions(opts *BreakpointOptions) (*hitCondition, error) {
+	if opts.LogMessage != "" {
+		d.mu.RLock()
+		handler := d.logpointHandler
+		d.mu.RUnlock()
+		if handler == nil {
+			return nil, errors.New("logpoints require a logpoint handler, see SetLogpointHandler")
+		}
+	}
+	return opts.check()
+}
+
+// check validates the options and returns the parsed hit condition
+func (opts *BreakpointOptions) check() (*hitCondition, error) {
+	if opts.Condition != "" {
+		if _, err := parser.ParseFile(nil, "", opts.Condition, 0); err != nil {
+			return nil, fmt.Errorf("invalid breakpoint condition: %w", err)
+		}
+	}
+	return parseHitCondition(opts.HitCondition)
+}
+
+// SetLogpointHandler sets the function that receives the messages of logpoints. Logpoints can't be
+// added without a handler; if the handler is removed, the messages of the existing ones are dropped.
+func (d *Debugger) SetLogpointHandler(handler LogpointHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.logpointHandler = handler
+}
+
+// SetExceptionBreakMode sets whether the debugger pauses when an exception is thrown. The handler
+// is called at the throw site, before the stack unwinds, with DebuggerState.Exception set.
+// An exception that is caught by Go code (for example by a Promise job) is considered uncaught.
+func (d *Debugger) SetExceptionBreakMode(mode ExceptionBreakMode) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.exceptionMode = mode
+}
+
 // RemoveBreakpoint removes a breakpoint by ID
 func (d *Debugger) RemoveBreakpoint(id int) bool {
 	d.mu.Lock()
@@ -186,7 +446,7 @@ func (d *Debugger) RemoveBreakpoint(id int) bool {
 
 	delete(d.breakpoints, id)
 	if bp.pc >= 0 {
-		delete(d.pcBreakpoints, bp.pc)
+		delete(d.pcBreakpoints, breakpointLocation{bp.prg, bp.pc})
 	}
 
 	return true
@@ -252,7 +512,7 @@ func (d *Debugger) StepOver() {
 	d.flags &^= FlagPaused
 	d.stepMode = DebugStepOver
 	d.stepDepth = len(d.runtime.vm.callStack)
-	d.lastPC = -1 // Reset lastPC to allow first step
+	d.lastPC = -1         // Reset lastPC to allow first step
 	d.lastSourceLine = -1 // Reset lastSourceLine to allow first step
 	d.logger.Printf("StepOver: flags=%b, stepMode=%v, stepDepth=%d\n", d.flags, d.stepMode, d.stepDepth)
 }
@@ -273,6 +533,7 @@ func (d *Debugger) StepOut() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	d.flags |= FlagStepMode
 	d.flags &^= FlagPaused
 	d.stepMode = DebugStepOut
 	d.stepDepth = len(d.runtime.vm.callStack) - 1
@@ -286,65 +547,219 @@ func (d *Debugger) Pause() {
 	d.flags |= FlagPaused
 }
 
-// resolveBreakpoint tries to resolve a source position to a PC
+// resolveBreakpoint tries to resolve a source position to a PC.
+// Must be called with the write lock held.
 func (d *Debugger) resolveBreakpoint(bp *Breakpoint) {
-	prg := d.runtime.vm.prg
-	if prg == nil || prg.src == nil {
+	prg := d.programs[bp.SourcePos.Filename]
+	if prg == nil {
 		d.logger.Printf("resolveBreakpoint: Cannot resolve BP #%d - no program loaded\n", bp.id)
 		return
 	}
+	d.resolveBreakpointIn(bp, prg)
+}
 
-	// Find the PC that corresponds to this source position
-	// We need to search through srcMap items
-	for pc := 0; pc < len(prg.code); pc++ {
-		if pc < len(prg.srcMap) {
-			item := prg.srcMap[pc]
-			if item.srcPos >= 0 {
-				pos := prg.src.Position(item.srcPos)
-				if pos.Filename == bp.SourcePos.Filename &&
-					pos.Line == bp.SourcePos.Line {
-					// Found a match - we accept any column on the same line
-					bp.pc = pc
-					d.pcBreakpoints[pc] = bp
-					d.logger.Printf("resolveBreakpoint: Resolved BP #%d to PC=%d\n", bp.id, pc)
-					break
-				}
-			}
+// resolveBreakpointIn tries to bind a breakpoint to an instruction of prg or of the functions nested in it.
+// Must be called with the write lock held.
+// The program must have code from the breakpoint's file, see sourceFiles.
+func (d *Debugger) resolveBreakpointIn(bp *Breakpoint, prg *Program) {
+	found := findLocation(prg, bp.SourcePos)
+	if found == nil {
+		if bp.pc < 0 {
+			d.logger.Printf("resolveBreakpoint: Failed to resolve BP #%d at %s:%d\n", bp.id, bp.SourcePos.Filename, bp.SourcePos.Line)
 		}
+		return
 	}
-	if bp.pc < 0 {
-		d.logger.Printf("resolveBreakpoint: Failed to resolve BP #%d at %s:%d\n", bp.id, bp.SourcePos.Filename, bp.SourcePos.Line)
+	if bp.pc >= 0 {
+		delete(d.pcBreakpoints, breakpointLocation{bp.prg, bp.pc})
 	}
+	bp.prg, bp.pc = found.prg, found.pc
+	d.pcBreakpoints[*found] = bp
+	d.logger.Printf("resolveBreakpoint: Resolved BP #%d to PC=%d\n", bp.id, bp.pc)
 }
 
-// resolvePendingBreakpoints resolves all breakpoints that haven't been resolved yet
-func (d *Debugger) resolvePendingBreakpoints() {
+// resolvePendingBreakpoints binds the breakpoints to the program that is about to run. Breakpoints that
+// have already been resolved are re-bound if the new program contains their position (e.g. a script that
+// has been re-compiled), otherwise they keep their current location.
+// The program is remembered as the latest version of its file and of the original sources of its source
+// map, so that breakpoints added later can be resolved without touching the VM, which may be running on
+// another goroutine.
+// The script handler is notified if the program comes from a new source file.
+func (d *Debugger) resolvePendingBreakpoints(prg *Program) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
+	if d.inHandler || prg.src == nil {
+		// Code evaluated by the handler must not steal breakpoints from the paused program
+		d.mu.Unlock()
+		return
+	}
 
+	files := sourceFiles(prg)
+	for name := range files {
+		d.programs[name] = prg
+	}
 	for _, bp := range d.breakpoints {
-		if bp.pc < 0 {
-			d.resolveBreakpoint(bp)
+		if _, exists := files[bp.SourcePos.Filename]; exists {
+			d.resolveBreakpointIn(bp, prg)
+		}
+	}
+	if d.runTo != nil {
+		if _, exists := files[d.runTo.pos.Filename]; exists {
+			if loc := findLocation(prg, d.runTo.pos); loc != nil {
+				d.runTo.loc = loc
+			}
+		}
+	}
+	script, handler := d.loadScript(prg)
+	d.mu.Unlock()
+
+	if script != nil && handler != nil {
+		handler(*script)
+	}
+}
+
+// findLocation returns the first instruction of prg or of the functions nested in it at pos, nil if
+// there is none.
+func findLocation(prg *Program, pos Position) *breakpointLocation {
+	if prg.src == nil {
+		return nil
+	}
+	// A position in the generated file is matched against the positions in the file itself
+	generated := pos.Filename == prg.src.Name()
+
+	// Function bodies are compiled into their own Programs, so the search has to descend into them.
+	// The first instruction found for the line wins, which is the outermost one in source order.
+	var found *breakpointLocation
+	walkPrograms(prg, func(p *Program) bool {
+		if p.src == nil {
+			return true
+		}
+		for _, item := range p.srcMap {
+			var itemPos file.Position
+			if generated {
+				itemPos = p.src.GeneratedPosition(item.srcPos)
+			} else {
+				itemPos = p.src.Position(item.srcPos)
+			}
+			if itemPos.Filename == pos.Filename && itemPos.Line == pos.Line &&
+				(pos.Column <= 0 || itemPos.Column >= pos.Column) {
+				found = &breakpointLocation{prg: p, pc: item.pc}
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// walkPrograms calls f for prg and every function Program nested in it, depth-first in code order.
+// The walk stops as soon as f returns false.
+func walkPrograms(prg *Program, f func(*Program) bool) bool {
+	if !f(prg) {
+		return false
+	}
+	walk := func(p *Program) bool {
+		if p == nil {
+			return true
+		}
+		return walkPrograms(p, f)
+	}
+	for _, ins := range prg.code {
+		for _, p := range nestedPrograms(ins) {
+			if !walk(p) {
+				return false
+			}
 		}
 	}
+	return true
+}
+
+// nestedPrograms returns the Programs of the functions an instruction creates
+func nestedPrograms(ins instruction) []*Program {
+	switch ins := ins.(type) {
+	case newFuncInstruction:
+		return []*Program{ins.getPrg()}
+	case *newDerivedClass:
+		return []*Program{ins.initFields, ins.ctor}
+	case *newClass:
+		return []*Program{ins.initFields, ins.ctor}
+	case *newStaticFieldInit:
+		return []*Program{ins.initFields}
+	}
+	return nil
+}
+
+// sourcePosition returns the source position of the instruction at pc
+func sourcePosition(prg *Program, pc int) Position {
+	if prg == nil || prg.src == nil || len(prg.srcMap) == 0 || prg.srcMap[0].pc > pc {
+		// Instructions emitted before the first mapped one (e.g. scope setup) have no position
+		return Position{}
+	}
+	pos := prg.src.Position(prg.sourceOffset(pc))
+	return Position{
+		Filename: pos.Filename,
+		Line:     pos.Line,
+		Column:   pos.Column,
+	}
 }
 
 // checkBreakpoint is called by the VM to check if we should pause
 func (d *Debugger) checkBreakpoint(vm *vm) bool {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+	d.mu.Lock()
+	pause, bp := d.checkPause(vm)
+	d.mu.Unlock()
+	if pause || bp == nil {
+		return pause
+	}
+
+	// The breakpoint's options have to be evaluated, which runs JS code and so can't be done under the lock
+	if d.evalBreakpoint(vm, bp) {
+		d.mu.Lock()
+		d.flags |= FlagPaused
+		d.hitBreakpoint = bp
+		d.mu.Unlock()
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.checkStep(vm)
+}
+
+// checkPause decides whether to pause before the current instruction. If there is a breakpoint with
+// options at the instruction, it's returned instead and the caller has to evaluate it, then call checkStep.
+// Must be called with the write lock held.
+func (d *Debugger) checkPause(vm *vm) (bool, *Breakpoint) {
+	if d.inHandler {
+		// Code run by the handler itself (e.g. an evaluation) is never paused
+		return false, nil
+	}
+
+	if vm.pc == 0 && vm.prg != nil {
+		d.recordEntry(vm)
+	}
+
+	// A write to a watched location is reported before the next instruction, i.e. right after the one that made it
+	if d.dataWrite != nil {
+		if change := d.takeDataChange(); change != nil {
+			d.flags |= FlagPaused
+			d.dataChange = change
+			d.logger.Printf("checkBreakpoint: Data breakpoint #%d hit at PC=%d\n", change.Breakpoint.id, vm.pc)
+			return true, nil
+		}
+	}
 
 	// For native functions, we still want to track step events
 	// but the handler can decide whether to process them
 	if vm.prg == nil {
-		// Check if already paused or in step mode
-		shouldPause := d.flags&FlagPaused != 0 || d.flags&FlagStepMode != 0
+		// Check if already paused or in step mode. A native call never ends a step over or out,
+		// it runs one level deeper than the step started at.
+		shouldPause := d.flags&FlagPaused != 0 ||
+			d.flags&FlagStepMode != 0 && d.stepMode != DebugStepOver && d.stepMode != DebugStepOut
 		if shouldPause {
 			d.logger.Printf("checkBreakpoint: Native function, flags=%b, pausing=%v\n", d.flags, shouldPause)
 		}
-		return shouldPause
+		return shouldPause, nil
 	}
-	
+
 	// Special handling for step-into with call instructions
 	if d.flags&FlagStepMode != 0 && d.stepMode == DebugStepInto {
 		// Check if the current instruction is a call
@@ -352,7 +767,7 @@ func (d *Debugger) checkBreakpoint(vm *vm) bool {
 			// Log the instruction type for debugging
 			instr := vm.prg.code[vm.pc]
 			d.logger.Printf("checkBreakpoint: StepInto - instruction type: %T\n", instr)
-			
+
 			if _, isCall := instr.(call); isCall {
 				// We're about to execute a call instruction
 				// Don't pause now, let it execute and pause at the first instruction of the called function
@@ -360,37 +775,46 @@ func (d *Debugger) checkBreakpoint(vm *vm) bool {
 				// Mark that we should continue stepping after the call
 				d.continueStepAfterCall = true
 				// Keep step mode active but don't pause yet
-				return false
+				return false, nil
 			}
 		}
 	}
 
 	// Log current state
-	currentLine := -1
-	if vm.prg.srcMap != nil && vm.pc < len(vm.prg.srcMap) {
-		item := vm.prg.srcMap[vm.pc]
-		if item.srcPos >= 0 && vm.prg.src != nil {
-			pos := vm.prg.src.Position(item.srcPos)
-			currentLine = pos.Line
-		}
-	}
-	d.logger.Printf("checkBreakpoint: PC=%d, Line=%d, flags=%b, stepMode=%v, callStackLen=%d, stepDepth=%d\n", 
-		vm.pc, currentLine, d.flags, d.stepMode, len(vm.callStack), d.stepDepth)
+	d.logger.Printf("checkBreakpoint: PC=%d, flags=%b, stepMode=%v, callStackLen=%d, stepDepth=%d\n",
+		vm.pc, d.flags, d.stepMode, len(vm.callStack), d.stepDepth)
 
 	// Check if paused
 	if d.flags&FlagPaused != 0 {
 		d.logger.Println("checkBreakpoint: Already paused, returning true")
-		return true
+		return true, nil
 	}
 
 	// Check breakpoints
-	if bp, exists := d.pcBreakpoints[vm.pc]; exists && bp.enabled {
+	if bp, exists := d.pcBreakpoints[breakpointLocation{vm.prg, vm.pc}]; exists && bp.enabled {
+		if bp.needsEvaluation() {
+			return false, bp
+		}
 		bp.hit++
 		d.flags |= FlagPaused
+		d.hitBreakpoint = bp
 		d.logger.Printf("checkBreakpoint: Hit breakpoint #%d at PC=%d, hits=%d\n", bp.id, vm.pc, bp.hit)
+		return true, nil
+	}
+
+	return d.checkStep(vm), nil
+}
+
+// checkStep decides whether a step in progress, or a run to a location, ends at the current instruction.
+// Must be called with the write lock held.
+func (d *Debugger) checkStep(vm *vm) bool {
+	if d.runTo != nil && d.runTo.loc != nil && *d.runTo.loc == (breakpointLocation{vm.prg, vm.pc}) {
+		d.flags |= FlagPaused
 		return true
 	}
 
+	currentLine := sourcePosition(vm.prg, vm.pc).Line
+
 	// Check step mode
 	if d.flags&FlagStepMode != 0 {
 		switch d.stepMode {
@@ -400,29 +824,19 @@ func (d *Debugger) checkBreakpoint(vm *vm) bool {
 			return true
 		case DebugStepOver:
 			if len(vm.callStack) <= d.stepDepth {
-				// Get current source line
-				currentLine := -1
-				if vm.prg != nil && vm.prg.srcMap != nil && vm.pc < len(vm.prg.srcMap) {
-					item := vm.prg.srcMap[vm.pc]
-					if item.srcPos >= 0 && vm.prg.src != nil {
-						pos := vm.prg.src.Position(item.srcPos)
-						currentLine = pos.Line
-					}
-				}
-				
 				// Check if we've moved to a different source line
 				isFirstStep := d.lastSourceLine == -1
 				isDifferentLine := currentLine > 0 && currentLine != d.lastSourceLine
 				isReturning := len(vm.callStack) < d.stepDepth
-				
+
 				// Special handling: if we had a valid line before and now have an invalid line,
 				// continue stepping (we're probably in a transition state)
 				wasValidLine := d.lastSourceLine > 0
 				isInvalidLine := currentLine <= 0
-				
+
 				d.logger.Printf("checkBreakpoint: StepOver - currentLine=%d, lastLine=%d, firstStep=%v, diffLine=%v, returning=%v\n",
 					currentLine, d.lastSourceLine, isFirstStep, isDifferentLine, isReturning)
-				
+
 				if isFirstStep || isDifferentLine || isReturning {
 					// Only pause if we have a valid source position
 					if currentLine > 0 || isReturning {
@@ -432,7 +846,7 @@ func (d *Debugger) checkBreakpoint(vm *vm) bool {
 						return true
 					}
 				}
-				
+
 				// If we were at a valid line and now at invalid, keep stepping
 				if wasValidLine && isInvalidLine {
 					// Don't pause, keep stepping
@@ -444,9 +858,9 @@ func (d *Debugger) checkBreakpoint(vm *vm) bool {
 					len(vm.callStack), d.stepDepth)
 			}
 		case DebugStepOut:
-			if len(vm.callStack) < d.stepDepth {
+			if len(vm.callStack) <= d.stepDepth {
 				d.flags |= FlagPaused
-				d.logger.Printf("checkBreakpoint: StepOut - pausing, callStack=%d < stepDepth=%d\n", 
+				d.logger.Printf("checkBreakpoint: StepOut - pausing, callStack=%d <= stepDepth=%d\n",
 					len(vm.callStack), d.stepDepth)
 				return true
 			}
@@ -456,14 +870,151 @@ func (d *Debugger) checkBreakpoint(vm *vm) bool {
 	return false
 }
 
+// evalBreakpoint evaluates the options of a breakpoint that has been reached and returns true
+// if execution should pause. Logpoints emit their message and never pause.
+func (d *Debugger) evalBreakpoint(vm *vm, bp *Breakpoint) bool {
+	d.mu.Lock()
+	d.inHandler = true
+	condition, hitCond, logMessage := bp.condition, bp.hitCond, bp.logMessage
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		d.inHandler = false
+		d.mu.Unlock()
+	}()
+
+	if condition != "" {
+		v, err := d.evalInScope(condition, d.currentScope(vm))
+		if err != nil {
+			d.logger.Printf("checkBreakpoint: Condition of breakpoint #%d failed: %v\n", bp.id, err)
+		} else if !v.ToBoolean() {
+			return false
+		}
+	}
+
+	d.mu.Lock()
+	bp.hit++
+	hits := bp.hit
+	handler := d.logpointHandler
+	d.mu.Unlock()
+
+	if hitCond != nil && !hitCond.match(hits) {
+		return false
+	}
+
+	if logMessage != "" {
+		msg := d.formatLogMessage(vm, logMessage)
+		d.logger.Printf("checkBreakpoint: Logpoint #%d: %s\n", bp.id, msg)
+		if handler != nil {
+			handler(bp, msg)
+		}
+		return false
+	}
+
+	d.logger.Printf("checkBreakpoint: Hit breakpoint #%d at PC=%d, hits=%d\n", bp.id, vm.pc, hits)
+	return true
+}
+
+// formatLogMessage interpolates the {expr} placeholders of a logpoint message in the current scope
+func (d *Debugger) formatLogMessage(vm *vm, template string) string {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if c != '{' {
+			b.WriteByte(c)
+			continue
+		}
+		// Find the matching brace, the expression may contain object literals
+		end, depth := -1, 0
+		for j := i + 1; j < len(template) && end < 0; j++ {
+			switch template[j] {
+			case '{':
+				depth++
+			case '}':
+				if depth == 0 {
+					end = j
+				}
+				depth--
+			}
+		}
+		if end < 0 {
+			b.WriteString(template[i:])
+			break
+		}
+		b.WriteString(d.logValue(vm, template[i+1:end]))
+		i = end
+	}
+	return b.String()
+}
+
+func (d *Debugger) logValue(vm *vm, expr string) (s string) {
+	v, err := d.evalInScope(expr, d.currentScope(vm))
+	if err == nil {
+		err = d.runtime.try(func() {
+			s = v.String()
+		})
+	}
+	if err != nil {
+		if ex, ok := err.(*Exception); ok {
+			return "<" + ex.Value().String() + ">"
+		}
+		return "<" + err.Error() + ">"
+	}
+	return s
+}
+
+// checkException is called by the VM when an exception is thrown, before it's handled.
+// It pauses if the exception break mode calls for it.
+func (d *Debugger) checkException(vm *vm, ex *Exception) {
+	d.mu.Lock()
+	if d.inHandler || d.exceptionMode == ExceptionBreakNone || ex == d.lastException {
+		d.mu.Unlock()
+		return
+	}
+
+	uncaught := true
+	for i := len(vm.tryStack) - 1; i >= 0; i-- {
+		if vm.tryStack[i].catchPos >= 0 {
+			uncaught = false
+			break
+		}
+	}
+	if !uncaught && d.exceptionMode != ExceptionBreakAll {
+		d.mu.Unlock()
+		return
+	}
+
+	d.lastException = ex
+	d.pauseException = ex
+	d.pauseUncaught = uncaught
+	d.flags |= FlagPaused
+	d.logger.Printf("checkException: Pausing on exception at PC=%d, uncaught=%v\n", vm.pc, uncaught)
+	d.mu.Unlock()
+
+	d.handlePause(vm)
+}
+
 // handlePause is called when the VM pauses
 func (d *Debugger) handlePause(vm *vm) {
 	// No need to skip native functions anymore - the handler can decide
 	d.logger.Printf("handlePause: Called, PC=%d, prg=%v\n", vm.pc, vm.prg != nil)
 
-	d.mu.RLock()
+	d.mu.Lock()
 	handler := d.handler
-	d.mu.RUnlock()
+	hitBreakpoint := d.hitBreakpoint
+	d.hitBreakpoint = nil
+	exception, uncaught := d.pauseException, d.pauseUncaught
+	d.pauseException = nil
+	dataChange := d.dataChange
+	d.dataChange = nil
+	d.runTo = nil
+	d.mu.Unlock()
 
 	if handler == nil {
 		// No handler, just continue
@@ -513,45 +1064,64 @@ func (d *Debugger) handlePause(vm *vm) {
 	}
 
 	// Get source position
-	if vm.prg != nil && vm.prg.srcMap != nil && vm.pc < len(vm.prg.srcMap) {
-		item := vm.prg.srcMap[vm.pc]
-		if item.srcPos >= 0 && vm.prg.src != nil {
-			pos := vm.prg.src.Position(item.srcPos)
-			state.SourcePos = Position{
-				Filename: pos.Filename,
-				Line:     pos.Line,
-				Column:   pos.Column,
-			}
-		}
-	}
+	state.SourcePos = sourcePosition(vm.prg, vm.pc)
 
 	// Get current breakpoint if any
-	d.mu.RLock()
-	if bp, exists := d.pcBreakpoints[vm.pc]; exists {
-		state.Breakpoint = bp
-	}
-	d.mu.RUnlock()
+	state.Breakpoint = hitBreakpoint
+	state.Exception = exception
+	state.Uncaught = uncaught
+	state.DataChange = dataChange
 
 	// Capture call stack
 	state.CallStack = d.runtime.CaptureCallStack(0, nil)
 
 	// Build debug stack with variable information
 	state.DebugStack = d.buildDebugStack(vm)
+	if exception != nil && len(state.DebugStack) > 0 {
+		// The thrown value is shown as a scope of the frame that throws it
+		top := &state.DebugStack[0]
+		d.mu.Lock()
+		ref := d.createVariableRef(&scopeRef{typ: "exception", value: exception.val})
+		d.mu.Unlock()
+		top.Scopes = append([]Scope{{Name: "Exception", VariablesRef: ref}}, top.Scopes...)
+	}
+
+	d.mu.Lock()
+	d.inHandler = true
+	d.canRestart = !isNative && exception == nil
+	if d.canRestart {
+		for i := range state.DebugStack {
+			_, _, err := d.restartTarget(vm, i)
+			state.DebugStack[i].CanRestart = err == nil
+		}
+	}
+	d.mu.Unlock()
+
+	if !isNative {
+		state.Watches = d.evalWatches(vm)
+	}
 
 	// Call handler and process command
 	d.logger.Printf("handlePause: Calling handler at Line=%d, PC=%d, InNative=%v, NativeName=%s\n",
 		state.SourcePos.Line, state.PC, state.InNativeCall, state.NativeFunctionName)
-	
+
 	// Update lastSourceLine before calling handler
 	if state.SourcePos.Line > 0 {
 		d.mu.Lock()
 		d.lastSourceLine = state.SourcePos.Line
 		d.mu.Unlock()
 	}
-	
+
 	cmd := handler(state)
 	d.logger.Printf("handlePause: Handler returned command: %v\n", cmd)
-	
+
+	d.mu.Lock()
+	d.inHandler = false
+	d.canRestart = false
+	d.pauses++
+	d.syncDataBreakpoints()
+	d.mu.Unlock()
+
 	switch cmd {
 	case DebugContinue:
 		d.Continue()
@@ -565,78 +1135,141 @@ func (d *Debugger) handlePause(vm *vm) {
 		// Already paused, do nothing
 		d.logger.Println("handlePause: Already paused, no action")
 	}
+
+	d.mu.Lock()
+	if d.restarted {
+		d.stepIntoRestarted(vm)
+	}
+	d.mu.Unlock()
 }
 
 // GetScopes returns the scopes for a given stack frame
 func (d *Debugger) GetScopes(frameID int) []Scope {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+	if d.runtime.vm == nil {
+		return nil
+	}
 
-	if d.runtime.vm == nil || frameID >= len(d.runtime.vm.callStack) {
+	stack := d.runtime.CaptureCallStack(0, nil)
+	if frameID < 0 || frameID >= len(stack) {
 		return nil
 	}
 
-	// For simplicity, we'll start with just local scope
-	// In the future, we can add closure and global scopes
+	return d.frameScopes(&stack[frameID])
+}
+
+// scopeRef is what a scope's VariablesRef points to. It's bound to the frame's stash when the scope is
+// created, so the variables can still be inspected after the VM has moved on.
+type scopeRef struct {
+	typ   string // "local", "closure", "global" or "exception"
+	stash *stash
+	frame *frameScope // The frame the scope belongs to
+	value Value       // The thrown value for "exception"
+}
+
+// frameScopes creates the scopes of a stack frame: the function's own variables (including those of the
+// enclosing blocks), the variables captured from the outer functions and the globals.
+func (d *Debugger) frameScopes(frame *StackFrame) []Scope {
+	var local, closure *stash
+	global := &d.runtime.global.stash
+	if frame.ctx != nil && frame.ctx.stash != nil && frame.ctx.stash != global {
+		s := frame.ctx.stash
+		if s == calleeStash(frame.vm, frame.ctx.sb) {
+			// The function has no stash of its own, the innermost one belongs to the closure
+			closure = s
+		} else {
+			local = s
+			for ; s != nil && s != global; s = s.outer {
+				if s.isVariable() {
+					if s.outer != global {
+						closure = s.outer
+					}
+					break
+				}
+			}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f := d.frameScope(frame)
 	scopes := []Scope{
 		{
 			Name:         "Local",
-			VariablesRef: d.createVariableRef(frameID, "local"),
-			Expensive:    false,
+			VariablesRef: d.createVariableRef(&scopeRef{typ: "local", stash: local, frame: f}),
 		},
 	}
-
-	// Add global scope
+	if closure != nil {
+		scopes = append(scopes, Scope{
+			Name:         "Closure",
+			VariablesRef: d.createVariableRef(&scopeRef{typ: "closure", stash: closure, frame: f}),
+		})
+	}
 	scopes = append(scopes, Scope{
 		Name:         "Global",
-		VariablesRef: d.createVariableRef(frameID, "global"),
+		VariablesRef: d.createVariableRef(&scopeRef{typ: "global", stash: global, frame: f}),
 		Expensive:    true,
 	})
 
 	return scopes
 }
 
+// calleeStash returns the stash the function executing in the frame with the stack base sb was created
+// in, or nil if it's not a JS function.
+func calleeStash(vm *vm, sb int) *stash {
+	if vm == nil || sb <= 0 || sb > len(vm.stack) {
+		return nil
+	}
+	if fn, ok := vm.stack[sb-1].(*Object); ok {
+		if f, ok := fn.self.(interface{ closureStash() *stash }); ok {
+			return f.closureStash()
+		}
+	}
+	return nil
+}
+
+// objectRef creates a reference through which the properties of obj can be retrieved with GetVariables
+func (d *Debugger) objectRef(obj *Object) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ref := d.nextVarRef
+	d.nextVarRef++
+	d.variableRefs[ref] = obj
+	return ref
+}
+
+// ValueRef returns a reference through which the properties of v can be retrieved with GetVariables,
+// or 0 if v is not an object. It's meant for values that were not obtained from GetVariables, such as
+// the results of EvaluateInFrame.
+func (d *Debugger) ValueRef(v Value) int {
+	if obj, ok := v.(*Object); ok {
+		return d.objectRef(obj)
+	}
+	return 0
+}
+
 // createVariableRef creates a reference for variables lookup
-func (d *Debugger) createVariableRef(frameID int, scopeType string) int {
+func (d *Debugger) createVariableRef(data interface{}) int {
 	// Note: This function must be called with write lock held
 	ref := d.nextVarRef
 	d.nextVarRef++
-	d.variableRefs[ref] = map[string]interface{}{
-		"frameID": frameID,
-		"type":    scopeType,
-	}
+	d.variableRefs[ref] = data
 	return ref
 }
 
 // GetVariables returns variables for a given reference (scope or object)
 func (d *Debugger) GetVariables(variablesRef int) []Variable {
-	// Handle lazy scope references (negative refs)
-	if variablesRef < 0 {
-		return d.resolveLazyScope(variablesRef)
-	}
-
 	d.mu.RLock()
-	defer d.mu.RUnlock()
-
 	refData, exists := d.variableRefs[variablesRef]
+	d.mu.RUnlock()
 	if !exists {
-		// Debug: log what references we have
-		if d.runtime.vm != nil {
-			for k, v := range d.variableRefs {
-				_ = k
-				_ = v
-				// fmt.Printf("Debug: Have ref %d -> %T\n", k, v)
-			}
-		}
 		return nil
 	}
 
+	// The lock is not held past this point: reading a property may call a getter, which runs JS code.
 	switch data := refData.(type) {
-	case map[string]interface{}:
-		if scopeType, ok := data["type"].(string); ok {
-			frameID, _ := data["frameID"].(int)
-			return d.getVariablesForScope(frameID, scopeType)
-		}
+	case *scopeRef:
+		return d.getVariablesForScope(data)
 	case *Object:
 		// Handle object properties
 		return d.getObjectProperties(data)
@@ -646,52 +1279,59 @@ func (d *Debugger) GetVariables(variablesRef int) []Variable {
 }
 
 // getVariablesForScope retrieves variables for a specific scope
-func (d *Debugger) getVariablesForScope(frameID int, scopeType string) []Variable {
+func (d *Debugger) getVariablesForScope(scope *scopeRef) []Variable {
 	var variables []Variable
-
-	// Get the current call stack
-	stack := d.runtime.CaptureCallStack(0, nil)
-	if frameID < 0 || frameID >= len(stack) {
-		return nil
-	}
-
-	switch scopeType {
-	case "local":
-		// Use the new GetLocalVariables method
-		frame := &stack[frameID]
-		localVars := frame.GetLocalVariables()
-		if localVars != nil {
-			variables = make([]Variable, 0, len(localVars))
-			for name, value := range localVars {
-				variable := Variable{
-					Name:  name,
-					Value: value,
-					Type:  d.getValueType(value),
-				}
-
-				// For complex types, create a reference
-				if obj, ok := value.(*Object); ok {
-					d.mu.Lock()
-					ref := d.nextVarRef
-					d.nextVarRef++
-					d.variableRefs[ref] = obj
-					d.mu.Unlock()
-					variable.Ref = ref
+	seen := make(map[unistring.String]struct{})
+	global := &d.runtime.global.stash
+
+	switch scope.typ {
+	case "local", "closure":
+		// Local stops at the function's stash, closure goes all the way up to the global one
+		// The variables are renamed in the scope they're declared in: the frame's own code for the local
+		// ones, the code that created the function for the closure ones
+		var prg *Program
+		var pc int
+		if scope.frame != nil {
+			prg, pc = scope.frame.prg, scope.frame.pc
+			if scope.typ == "closure" {
+				prg, pc = enclosingProgram(prg)
+			}
+		}
+		for s := scope.stash; s != nil && s != global; s = s.outer {
+			vars := d.extractStashVariables(s, seen)
+			renameVariables(prg, pc, vars)
+			variables = append(variables, vars...)
+			if s.isVariable() {
+				if scope.typ == "local" {
+					break
 				}
-
-				variables = append(variables, variable)
+				prg, pc = enclosingProgram(prg)
 			}
 		}
+		if scope.typ == "local" && scope.frame != nil {
+			vars := d.extractStackVariables(scope.frame, seen)
+			renameVariables(scope.frame.prg, scope.frame.pc, vars)
+			variables = append(variables, vars...)
+		}
 	case "global":
-		// Get global variables
-		variables = d.extractGlobalVariables()
+		// Top-level let, const and class declarations live in the global stash, not in the global object
+		variables = d.extractStashVariables(global, seen)
+		variables = append(variables, d.extractGlobalVariables(seen)...)
+	case "exception":
+		variables = []Variable{{
+			Name:  "exception",
+			Value: scope.value,
+			Type:  d.getValueType(scope.value),
+			Ref:   d.ValueRef(scope.value),
+		}}
 	}
 
 	return variables
 }
 
-// extractStashVariables extracts variables from a stash
-func (d *Debugger) extractStashVariables(s *stash) []Variable {
+// extractStashVariables extracts variables from a stash. Names in seen are skipped, as they are
+// shadowed by an inner scope, and the extracted names are added to it.
+func (d *Debugger) extractStashVariables(s *stash, seen map[unistring.String]struct{}) []Variable {
 	if s == nil || s.names == nil {
 		return nil
 	}
@@ -699,8 +1339,19 @@ func (d *Debugger) extractStashVariables(s *stash) []Variable {
 	variables := make([]Variable, 0, len(s.names))
 
 	for name, idx := range s.names {
-		if int(idx) < len(s.values) && s.values[idx] != nil {
-			v := s.values[idx]
+		if _, shadowed := seen[name]; shadowed {
+			continue
+		}
+		idx &^= maskTyp
+		var v Value
+		if int(idx) < len(s.values) {
+			v = s.values[idx]
+			if b, ok := v.(*importBinding); ok {
+				v = b.get()
+			}
+		}
+		if v != nil {
+			seen[name] = struct{}{}
 			variable := Variable{
 				Name:  name.String(),
 				Value: v,
@@ -709,10 +1360,7 @@ func (d *Debugger) extractStashVariables(s *stash) []Variable {
 
 			// For complex types, create a reference
 			if obj, ok := v.(*Object); ok {
-				ref := d.nextVarRef
-				d.nextVarRef++
-				d.variableRefs[ref] = obj
-				variable.Ref = ref
+				variable.Ref = d.objectRef(obj)
 			}
 
 			variables = append(variables, variable)
@@ -723,7 +1371,7 @@ func (d *Debugger) extractStashVariables(s *stash) []Variable {
 }
 
 // extractGlobalVariables extracts global variables
-func (d *Debugger) extractGlobalVariables() []Variable {
+func (d *Debugger) extractGlobalVariables(seen map[unistring.String]struct{}) []Variable {
 	globalObj := d.runtime.globalObject
 	if globalObj == nil {
 		return nil
@@ -734,6 +1382,9 @@ func (d *Debugger) extractGlobalVariables() []Variable {
 	// Iterate through global object properties
 	for item, next := globalObj.self.iterateStringKeys()(); next != nil; item, next = next() {
 		nameStr := item.name.string()
+		if _, shadowed := seen[nameStr]; shadowed {
+			continue
+		}
 		prop := globalObj.self.getStr(nameStr, nil)
 		if prop != nil {
 			variable := Variable{
@@ -744,10 +1395,7 @@ func (d *Debugger) extractGlobalVariables() []Variable {
 
 			// For complex types, create a reference
 			if obj, ok := prop.(*Object); ok {
-				ref := d.nextVarRef
-				d.nextVarRef++
-				d.variableRefs[ref] = obj
-				variable.Ref = ref
+				variable.Ref = d.objectRef(obj)
 			}
 
 			variables = append(variables, variable)
@@ -778,10 +1426,7 @@ func (d *Debugger) getObjectProperties(obj *Object) []Variable {
 
 			// For nested objects, create a reference
 			if nestedObj, ok := prop.(*Object); ok {
-				ref := d.nextVarRef
-				d.nextVarRef++
-				d.variableRefs[ref] = nestedObj
-				variable.Ref = ref
+				variable.Ref = d.objectRef(nestedObj)
 			}
 
 			variables = append(variables, variable)
@@ -830,90 +1475,92 @@ func (d *Debugger) getValueType(v Value) string {
 	}
 }
 
-// Evaluate evaluates an expression in the context of a frame
+// Evaluate evaluates an expression in the global context.
+// Use EvaluateInFrame to evaluate in the context of a stack frame.
 func (d *Debugger) Evaluate(expression string, frameID int) (Value, error) {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
-
-	// This is a simplified implementation
-	// A full implementation would need to:
-	// 1. Parse the expression
-	// 2. Evaluate it in the context of the specified frame
-	// 3. Handle errors appropriately
-
-	// For now, we'll use the runtime's RunString in the global context
-	// In the future, this should evaluate in the frame's context
 	return d.runtime.RunString(expression)
 }
 
-// EvaluateInFrame evaluates an expression in the context of a specific stack frame
+// EvaluateInFrame evaluates an expression in the context of a specific stack frame.
+// The expression sees the frame's scope chain the same way a direct eval() placed at the paused
+// position would, so locals, closure variables and globals are all accessible.
+// It must be called from the debug handler, i.e. on the VM goroutine while execution is paused.
 func (d *Debugger) EvaluateInFrame(expression string, frameIndex int) (Value, error) {
-	d.mu.RLock()
-	
 	if d.runtime.vm == nil {
-		d.mu.RUnlock()
 		return nil, fmt.Errorf("no active execution context")
 	}
 
 	// Get the current call stack
 	stack := d.runtime.CaptureCallStack(0, nil)
 	if frameIndex < 0 || frameIndex >= len(stack) {
-		d.mu.RUnlock()
 		return nil, fmt.Errorf("invalid frame index: %d", frameIndex)
 	}
 
-	frame := &stack[frameIndex]
+	d.mu.RLock()
+	f := d.frameScope(&stack[frameIndex])
+	d.mu.RUnlock()
 
-	// If the frame has no context, evaluate in global scope
-	if frame.ctx == nil || frame.ctx.stash == nil {
-		d.mu.RUnlock()
-		return d.runtime.RunString(expression)
-	}
+	return d.evalInScope(expression, f)
+}
 
-	// Create a temporary evaluation context with the frame's variables
-	// This is a simplified implementation that creates a new scope with the frame's variables
-	evalCode := "(function() {\n"
+// evalInScope runs src as if it were the argument of a direct eval() call made in the scope of f.
+// Breakpoints are not checked while it runs because it's only called from within the handler.
+func (d *Debugger) evalInScope(src string, f *frameScope) (result Value, err error) {
+	r := d.runtime
+	vm := r.vm
 
-	// Inject local variables from the frame's stash
-	if frame.ctx.stash.names != nil {
-		for name, idx := range frame.ctx.stash.names {
-			if int(idx) < len(frame.ctx.stash.values) && frame.ctx.stash.values[idx] != nil {
-				// Create a variable declaration for each local variable
-				evalCode += fmt.Sprintf("var %s = this._%s;\n", name.String(), name.String())
-			}
+	d.mu.RLock()
+	s, writeBack := d.materialise(f)
+	d.mu.RUnlock()
+	defer writeBack()
+
+	inGlobal := true
+	for s1 := s; s1 != nil; s1 = s1.outer {
+		if s1.isVariable() {
+			inGlobal = false
+			break
 		}
 	}
 
-	// Add the expression to evaluate
-	evalCode += "return (" + expression + ");\n"
-	evalCode += "}).call(this)"
+	vm.pushCtx()
+	vm.stash = s
+	vm.privEnv = nil
+	vm.newTarget = nil
+	p, err := r.compile("<eval>", src, false, inGlobal, vm)
+	if err != nil {
+		vm.popCtx()
+		return nil, err
+	}
 
-	// Create an object with the frame's variables
-	varsObj := d.runtime.NewObject()
-	if frame.ctx.stash.names != nil {
-		for name, idx := range frame.ctx.stash.names {
-			if int(idx) < len(frame.ctx.stash.values) && frame.ctx.stash.values[idx] != nil {
-				varsObj.Set("_"+name.String(), frame.ctx.stash.values[idx])
+	defer func() {
+		vm.sp -= 2
+		vm.popCtx()
+		if x := recover(); x != nil {
+			if ex := asUncatchableException(x); ex != nil {
+				result, err = nil, ex
+			} else {
+				panic(x)
 			}
 		}
+	}()
+
+	sp := vm.sp
+	vm.stack.expand(sp + 1)
+	vm.stack[sp] = _undefined // 'callee'
+	vm.stack[sp+1] = nil      // 'this'
+	vm.sb = sp + 1
+	vm.sp = sp + 2
+	vm.prg = p
+	vm.pc = 0
+	vm.args = 0
+	vm.result = _undefined
+
+	if ex := vm.runTry(); ex != nil {
+		return nil, ex
 	}
-
-	// Save current global state
-	savedGlobal := d.runtime.globalObject
-
-	// Temporarily set the variables object as 'this'
-	d.runtime.globalObject = varsObj
-
-	// Release lock before evaluating
-	d.mu.RUnlock()
-
-	// Evaluate the expression
-	result, err := d.runtime.RunString(evalCode)
-
-	// Restore global state
-	d.runtime.globalObject = savedGlobal
-
-	return result, err
+	result = vm.result
+	vm.clearStack()
+	return
 }
 
 // IsInNativeCall returns true if currently executing native code
@@ -974,61 +1621,17 @@ func (d *Debugger) buildDebugStack(vm *vm) []DebugStackFrame {
 	callStack := d.runtime.CaptureCallStack(0, nil)
 	debugStack := make([]DebugStackFrame, len(callStack))
 
-	// Create scope references that will be populated lazily
-	for i, frame := range callStack {
-		debugFrame := DebugStackFrame{
-			StackFrame: frame,
-		}
-
-		// Build scopes for this frame
-		// Use negative refs for lazy loading - will be resolved in GetScopes
-		scopes := []Scope{
-			{
-				Name:         "Local",
-				VariablesRef: -(i*10 + 1), // Negative to indicate it needs resolution
-				Expensive:    false,
-			},
-		}
-
-		// Only add global scope for the first frame to avoid duplication
-		if i == 0 {
-			scopes = append(scopes, Scope{
-				Name:         "Global",
-				VariablesRef: -(i*10 + 2), // Negative to indicate it needs resolution
-				Expensive:    true,
-			})
+	for i := range callStack {
+		frame := &callStack[i]
+		debugStack[i] = DebugStackFrame{
+			StackFrame: *frame,
+			Scopes:     d.frameScopes(frame),
 		}
 
-		debugFrame.Scopes = scopes
-
 		// Get 'this' value for the frame
 		// For now, we'll leave this as nil
 		// TODO: Extract 'this' value from the context
-
-		debugStack[i] = debugFrame
 	}
 
 	return debugStack
 }
-
-// resolveLazyScope resolves a lazy scope reference and returns its variables
-func (d *Debugger) resolveLazyScope(lazyRef int) []Variable {
-	// Extract frame ID and scope type from the negative reference
-	// lazyRef = -(frameID*10 + scopeID)
-	// scopeID: 1 = local, 2 = global
-	absRef := -lazyRef
-	frameID := absRef / 10
-	scopeID := absRef % 10
-
-	var scopeType string
-	switch scopeID {
-	case 1:
-		scopeType = "local"
-	case 2:
-		scopeType = "global"
-	default:
-		return nil
-	}
-
-	return d.getVariablesForScope(frameID, scopeType)
-}
@@ -0,0 +1,225 @@
+package goja
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotPaused is returned by the AsyncDebugger methods that require execution to be paused
+var ErrNotPaused = errors.New("the debugger is not paused")
+
+// AsyncDebugger is an alternative to DebugHandler for frontends that don't want to block inside a callback.
+// Pauses are published on the Events channel and commands can be sent from any goroutine. While execution
+// is paused the VM goroutine waits for commands, the ones that need the VM (Evaluate, GetVariables,
+// SetVariable, Do) are executed on it and the ones that resume execution (Continue, StepOver,
+// RunToLocation, RestartFrame, ...) end the pause.
+//
+// The breakpoint methods of Debugger and Debugger.Pause are safe to call from any goroutine, whether
+// the VM is running or paused.
+type AsyncDebugger struct {
+	d      *Debugger
+	events chan *DebuggerState
+	closed chan struct{}
+	once   sync.Once
+
+	mu    sync.Mutex
+	pause *asyncPause
+}
+
+// asyncPause is the state of a single pause. Each pause has its own channels, so that a command sent
+// for a pause that has just ended can't be picked up by the next one.
+type asyncPause struct {
+	state  *DebuggerState
+	jobs   chan func()
+	resume chan DebugCommand
+	done   chan struct{}
+}
+
+// EnableAsync installs an AsyncDebugger as the debugger's handler, replacing any DebugHandler.
+func (d *Debugger) EnableAsync() *AsyncDebugger {
+	a := &AsyncDebugger{
+		d:      d,
+		events: make(chan *DebuggerState, 1),
+		closed: make(chan struct{}),
+	}
+	d.SetHandler(a.handle)
+	return a
+}
+
+// Events returns the channel on which pauses are published. The VM stays paused until a command resumes
+// it, so the channel must be drained: the VM goroutine blocks if a pause can't be delivered.
+func (a *AsyncDebugger) Events() <-chan *DebuggerState {
+	return a.events
+}
+
+// Close uninstalls the AsyncDebugger and resumes execution if it's paused. Events are no longer sent
+// after Close returns.
+func (a *AsyncDebugger) Close() {
+	a.once.Do(func() {
+		a.d.SetHandler(nil)
+		close(a.closed)
+	})
+}
+
+func (a *AsyncDebugger) handle(state *DebuggerState) DebugCommand {
+	p := &asyncPause{
+		state:  state,
+		jobs:   make(chan func()),
+		resume: make(chan DebugCommand),
+		done:   make(chan struct{}),
+	}
+	a.mu.Lock()
+	a.pause = p
+	a.mu.Unlock()
+
+	defer func() {
+		a.mu.Lock()
+		a.pause = nil
+		a.mu.Unlock()
+		close(p.done)
+	}()
+
+	select {
+	case a.events <- state:
+	case <-a.closed:
+		return DebugContinue
+	}
+
+	for {
+		select {
+		case job := <-p.jobs:
+			job()
+		case cmd := <-p.resume:
+			return cmd
+		case <-a.closed:
+			return DebugContinue
+		}
+	}
+}
+
+func (a *AsyncDebugger) current() *asyncPause {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pause
+}
+
+// State returns the state of the current pause, or nil if execution is not paused
+func (a *AsyncDebugger) State() *DebuggerState {
+	if p := a.current(); p != nil {
+		return p.state
+	}
+	return nil
+}
+
+// Do runs f on the VM goroutine while execution is paused and waits for it to return. Debugger methods
+// that run JS code, such as EvaluateInFrame and GetVariables, must only be called this way.
+func (a *AsyncDebugger) Do(f func(state *DebuggerState)) error {
+	p := a.current()
+	if p == nil {
+		return ErrNotPaused
+	}
+	done := make(chan struct{})
+	select {
+	case p.jobs <- func() {
+		defer close(done)
+		f(p.state)
+	}:
+	case <-p.done:
+		return ErrNotPaused
+	}
+	<-done
+	return nil
+}
+
+// Evaluate evaluates an expression in the scope of a frame of the paused stack
+func (a *AsyncDebugger) Evaluate(expression string, frameIndex int) (result Value, err error) {
+	if doErr := a.Do(func(*DebuggerState) {
+		result, err = a.d.EvaluateInFrame(expression, frameIndex)
+	}); doErr != nil {
+		return nil, doErr
+	}
+	return
+}
+
+// GetVariables returns the variables of a scope or an object of the paused stack
+func (a *AsyncDebugger) GetVariables(variablesRef int) (variables []Variable, err error) {
+	err = a.Do(func(*DebuggerState) {
+		variables = a.d.GetVariables(variablesRef)
+	})
+	return
+}
+
+// SetVariable assigns a new value to a variable of the paused stack, see Debugger.SetVariable
+func (a *AsyncDebugger) SetVariable(variablesRef int, name, value string) (variable Variable, err error) {
+	if doErr := a.Do(func(*DebuggerState) {
+		variable, err = a.d.SetVariable(variablesRef, name, value)
+	}); doErr != nil {
+		return Variable{}, doErr
+	}
+	return
+}
+
+// Resume ends the current pause with a command, as if a DebugHandler had returned it
+func (a *AsyncDebugger) Resume(cmd DebugCommand) error {
+	p := a.current()
+	if p == nil {
+		return ErrNotPaused
+	}
+	select {
+	case p.resume <- cmd:
+		return nil
+	case <-p.done:
+		return ErrNotPaused
+	}
+}
+
+// Continue resumes execution
+func (a *AsyncDebugger) Continue() error {
+	return a.Resume(DebugContinue)
+}
+
+// StepOver resumes execution until the next line, stepping over function calls
+func (a *AsyncDebugger) StepOver() error {
+	return a.Resume(DebugStepOver)
+}
+
+// StepInto resumes execution until the next line, stepping into function calls
+func (a *AsyncDebugger) StepInto() error {
+	return a.Resume(DebugStepInto)
+}
+
+// StepOut resumes execution until the current function returns
+func (a *AsyncDebugger) StepOut() error {
+	return a.Resume(DebugStepOut)
+}
+
+// RunToLocation resumes execution until it reaches a line, see Debugger.RunToLocation
+func (a *AsyncDebugger) RunToLocation(filename string, line int) error {
+	if a.current() == nil {
+		return ErrNotPaused
+	}
+	if err := a.d.RunToLocation(filename, line); err != nil {
+		return err
+	}
+	return a.Continue()
+}
+
+// RestartFrame restarts the function of a frame of the paused stack and resumes execution, which pauses
+// again on the first line of the function. See Debugger.RestartFrame.
+func (a *AsyncDebugger) RestartFrame(frameIndex int) error {
+	var err error
+	if doErr := a.Do(func(*DebuggerState) {
+		err = a.d.RestartFrame(frameIndex)
+	}); doErr != nil {
+		return doErr
+	}
+	if err != nil {
+		return err
+	}
+	return a.Continue()
+}
+
+// Pause requests execution to pause at the next instruction. The pause is published on the Events channel.
+func (a *AsyncDebugger) Pause() {
+	a.d.Pause()
+}
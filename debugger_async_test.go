@@ -0,0 +1,192 @@
+package goja
+
+import (
+	"testing"
+	"time"
+)
+
+func nextPause(t *testing.T, a *AsyncDebugger) *DebuggerState {
+	select {
+	case state := <-a.Events():
+		return state
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for a pause")
+	}
+	return nil
+}
+
+func runAsync(vm *Runtime, script string) chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := vm.RunString(script)
+		done <- err
+	}()
+	return done
+}
+
+func waitDone(t *testing.T, done chan error) {
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for the script to finish")
+	}
+}
+
+func TestAsyncDebuggerBreakpointAndStep(t *testing.T) {
+	const SCRIPT = `
+	function add(a, b) {
+		var sum = a + b;
+		return sum;
+	}
+	var result = add(1, 2);
+	`
+
+	vm := NewWithOptions(RuntimeOptions{EnableDebugMode: true})
+	debugger := vm.EnableDebugger()
+	a := debugger.EnableAsync()
+	defer a.Close()
+	debugger.AddBreakpoint("", 4, 0)
+
+	done := runAsync(vm, SCRIPT)
+
+	state := nextPause(t, a)
+	if state.Breakpoint == nil || state.SourcePos.Line != 4 {
+		t.Fatalf("Expected to stop at the breakpoint on line 4, got line %d", state.SourcePos.Line)
+	}
+	if a.State() != state {
+		t.Error("State() must return the current pause")
+	}
+
+	v, err := a.Evaluate("sum * 10", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ToInteger() != 30 {
+		t.Errorf("Expected 30, got %v", v)
+	}
+
+	vars, err := a.GetVariables(state.DebugStack[0].Scopes[0].VariablesRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, v := range vars {
+		if v.Name == "sum" && v.Value.ToInteger() == 3 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("sum not found in %v", vars)
+	}
+
+	if err := a.StepOut(); err != nil {
+		t.Fatal(err)
+	}
+	state = nextPause(t, a)
+	if state.SourcePos.Line != 6 {
+		t.Errorf("Expected to stop on line 6 after step out, got %d", state.SourcePos.Line)
+	}
+
+	if err := a.Continue(); err != nil {
+		t.Fatal(err)
+	}
+	waitDone(t, done)
+
+	if err := a.Continue(); err != ErrNotPaused {
+		t.Errorf("Expected ErrNotPaused, got %v", err)
+	}
+	if _, err := a.Evaluate("1", 0); err != ErrNotPaused {
+		t.Errorf("Expected ErrNotPaused, got %v", err)
+	}
+	if a.State() != nil {
+		t.Error("State() must return nil when not paused")
+	}
+}
+
+func TestAsyncDebuggerPauseWhileRunning(t *testing.T) {
+	const SCRIPT = `
+	var stop = false;
+	var count = 0;
+	while (!stop) {
+		count++;
+	}
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+	a := debugger.EnableAsync()
+	defer a.Close()
+
+	done := runAsync(vm, SCRIPT)
+
+	time.Sleep(10 * time.Millisecond)
+	a.Pause()
+	nextPause(t, a)
+
+	if _, err := a.Evaluate("stop = true", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Continue(); err != nil {
+		t.Fatal(err)
+	}
+	waitDone(t, done)
+}
+
+func TestAsyncDebuggerBreakpointWhileRunning(t *testing.T) {
+	const SCRIPT = `
+	var stop = false;
+	var count = 0;
+	while (!stop) {
+		count++;
+	}
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+	a := debugger.EnableAsync()
+	defer a.Close()
+
+	done := runAsync(vm, SCRIPT)
+
+	time.Sleep(10 * time.Millisecond)
+	id := debugger.AddBreakpoint("", 5, 0)
+
+	state := nextPause(t, a)
+	if state.Breakpoint == nil || state.Breakpoint.ID() != id {
+		t.Fatalf("Expected to stop at the breakpoint, got line %d", state.SourcePos.Line)
+	}
+
+	debugger.RemoveBreakpoint(id)
+	if _, err := a.Evaluate("stop = true", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Continue(); err != nil {
+		t.Fatal(err)
+	}
+	waitDone(t, done)
+}
+
+func TestAsyncDebuggerClose(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+	a := debugger.EnableAsync()
+
+	done := runAsync(vm, `
+	var x = 1;
+	debugger;
+	x++;
+	debugger;
+	x++;
+	`)
+
+	nextPause(t, a)
+	a.Close()
+	waitDone(t, done)
+
+	if x := vm.Get("x").ToInteger(); x != 3 {
+		t.Errorf("Expected x to be 3, got %d", x)
+	}
+}
@@ -0,0 +1,173 @@
+package goja
+
+import (
+	"testing"
+)
+
+const breakpointLoopScript = `
+function loop(n) {
+	var sum = 0;
+	for (var i = 0; i < n; i++) {
+		sum += i; // line 5
+	}
+	return sum;
+}
+loop(30);
+`
+
+func runBreakpointLoop(t *testing.T, opts BreakpointOptions) (values []int64, logs []string) {
+	vm := NewWithOptions(RuntimeOptions{EnableDebugMode: true})
+	debugger := vm.EnableDebugger()
+
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		v, err := debugger.EvaluateInFrame("i", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, v.ToInteger())
+		return DebugContinue
+	})
+	debugger.SetLogpointHandler(func(bp *Breakpoint, message string) {
+		logs = append(logs, message)
+	})
+
+	if _, err := debugger.AddBreakpointWithOptions("", 5, 0, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := vm.RunString(breakpointLoopScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ToInteger() != 435 {
+		t.Fatalf("Unexpected result: %v", res)
+	}
+	return
+}
+
+func TestDebuggerConditionalBreakpoint(t *testing.T) {
+	values, _ := runBreakpointLoop(t, BreakpointOptions{Condition: "i === 7 || i === 20"})
+	if len(values) != 2 || values[0] != 7 || values[1] != 20 {
+		t.Fatalf("Expected pauses at i=7 and i=20, got %v", values)
+	}
+}
+
+func TestDebuggerConditionalBreakpointException(t *testing.T) {
+	// A failing condition pauses so that the mistake doesn't go unnoticed
+	values, _ := runBreakpointLoop(t, BreakpointOptions{Condition: "i > 27 && undefinedVariable"})
+	if len(values) != 2 || values[0] != 28 || values[1] != 29 {
+		t.Fatalf("Expected pauses at i=28 and i=29, got %v", values)
+	}
+}
+
+func TestDebuggerHitCountBreakpoint(t *testing.T) {
+	tests := []struct {
+		hitCondition string
+		expected     []int64
+	}{
+		{"28", []int64{27, 28, 29}},
+		{">= 28", []int64{27, 28, 29}},
+		{"> 28", []int64{28, 29}},
+		{"== 3", []int64{2}},
+		{"= 3", []int64{2}},
+		{"< 3", []int64{0, 1}},
+		{"% 10", []int64{9, 19, 29}},
+		{"% 10 == 0", []int64{9, 19, 29}},
+		{"%10==1", []int64{0, 10, 20}},
+	}
+
+	for _, tc := range tests {
+		values, _ := runBreakpointLoop(t, BreakpointOptions{HitCondition: tc.hitCondition})
+		if len(values) != len(tc.expected) {
+			t.Errorf("%q: expected pauses at %v, got %v", tc.hitCondition, tc.expected, values)
+			continue
+		}
+		for i := range values {
+			if values[i] != tc.expected[i] {
+				t.Errorf("%q: expected pauses at %v, got %v", tc.hitCondition, tc.expected, values)
+				break
+			}
+		}
+	}
+}
+
+func TestDebuggerConditionAndHitCount(t *testing.T) {
+	// Only the hits where the condition is true are counted
+	values, _ := runBreakpointLoop(t, BreakpointOptions{Condition: "i % 2 === 1", HitCondition: "% 5 == 0"})
+	if len(values) != 3 || values[0] != 9 || values[1] != 19 || values[2] != 29 {
+		t.Fatalf("Expected pauses at i=9, 19 and 29, got %v", values)
+	}
+}
+
+func TestDebuggerLogpoint(t *testing.T) {
+	values, logs := runBreakpointLoop(t, BreakpointOptions{
+		Condition:  "i >= 27",
+		LogMessage: "i={i}, sum={sum}, obj={ ({a: {b: i}}).a.b }, {{literal}}, err={nope}, open={i",
+	})
+	if len(values) != 0 {
+		t.Fatalf("Logpoints must not pause, paused at %v", values)
+	}
+	expected := []string{
+		"i=27, sum=351, obj=27, {literal}, err=<ReferenceError: nope is not defined>, open={i",
+		"i=28, sum=378, obj=28, {literal}, err=<ReferenceError: nope is not defined>, open={i",
+		"i=29, sum=406, obj=29, {literal}, err=<ReferenceError: nope is not defined>, open={i",
+	}
+	if len(logs) != len(expected) {
+		t.Fatalf("Expected %d messages, got %q", len(expected), logs)
+	}
+	for i := range logs {
+		if logs[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], logs[i])
+		}
+	}
+}
+
+func TestDebuggerLogpointWithoutHandler(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	if _, err := debugger.AddBreakpointWithOptions("", 5, 0, BreakpointOptions{LogMessage: "i={i}"}); err == nil {
+		t.Error("Expected an error adding a logpoint without a handler")
+	}
+	id := debugger.AddBreakpoint("", 5, 0)
+	if err := debugger.SetBreakpointOptions(id, BreakpointOptions{LogMessage: "i={i}"}); err == nil {
+		t.Error("Expected an error turning a breakpoint into a logpoint without a handler")
+	}
+
+	debugger.SetLogpointHandler(func(bp *Breakpoint, message string) {})
+	if err := debugger.SetBreakpointOptions(id, BreakpointOptions{LogMessage: "i={i}"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDebuggerBreakpointOptionsErrors(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	for _, opts := range []BreakpointOptions{
+		{Condition: "i ==="},
+		{HitCondition: ">= x"},
+		{HitCondition: "% 0"},
+		{HitCondition: "% 5 == 5"},
+		{HitCondition: "-1"},
+	} {
+		if _, err := debugger.AddBreakpointWithOptions("", 1, 0, opts); err == nil {
+			t.Errorf("Expected an error for %+v", opts)
+		}
+	}
+	if len(debugger.GetBreakpoints()) != 0 {
+		t.Error("Invalid breakpoints must not be added")
+	}
+
+	id := debugger.AddBreakpoint("", 1, 0)
+	if err := debugger.SetBreakpointOptions(id, BreakpointOptions{HitCondition: "bad"}); err == nil {
+		t.Error("Expected an error for a bad hit condition")
+	}
+	if err := debugger.SetBreakpointOptions(id, BreakpointOptions{Condition: "x > 1", HitCondition: "% 2"}); err != nil {
+		t.Fatal(err)
+	}
+	bp := debugger.GetBreakpoints()[0]
+	if bp.Condition() != "x > 1" || bp.HitCondition() != "% 2" || bp.IsLogpoint() {
+		t.Errorf("Unexpected breakpoint options: %q %q %v", bp.Condition(), bp.HitCondition(), bp.IsLogpoint())
+	}
+}
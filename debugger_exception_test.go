@@ -0,0 +1,157 @@
+package goja
+
+import (
+	"strings"
+	"testing"
+)
+
+type exceptionPause struct {
+	line     int
+	value    string
+	uncaught bool
+}
+
+func runExceptionScript(t *testing.T, mode ExceptionBreakMode, script string) ([]exceptionPause, error) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+	debugger.SetExceptionBreakMode(mode)
+
+	var pauses []exceptionPause
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.Exception == nil {
+			t.Errorf("Unexpected pause at line %d", state.SourcePos.Line)
+			return DebugContinue
+		}
+		pauses = append(pauses, exceptionPause{
+			line:     state.SourcePos.Line,
+			value:    state.Exception.Value().String(),
+			uncaught: state.Uncaught,
+		})
+		return DebugContinue
+	})
+
+	_, err := vm.RunString(script)
+	return pauses, err
+}
+
+const exceptionScript = `
+try {
+	throw new Error("caught");
+} catch (e) {
+}
+function f() {
+	null.x;
+}
+f();
+`
+
+func TestDebuggerPauseOnUncaughtException(t *testing.T) {
+	pauses, err := runExceptionScript(t, ExceptionBreakUncaught, exceptionScript)
+	if err == nil {
+		t.Fatal("Expected the script to fail")
+	}
+	if len(pauses) != 1 {
+		t.Fatalf("Expected 1 pause, got %v", pauses)
+	}
+	if p := pauses[0]; p.line != 7 || !p.uncaught || !strings.HasPrefix(p.value, "TypeError: ") {
+		t.Errorf("Unexpected pause: %+v", p)
+	}
+}
+
+func TestDebuggerPauseOnAllExceptions(t *testing.T) {
+	pauses, err := runExceptionScript(t, ExceptionBreakAll, exceptionScript)
+	if err == nil {
+		t.Fatal("Expected the script to fail")
+	}
+	if len(pauses) != 2 {
+		t.Fatalf("Expected 2 pauses, got %v", pauses)
+	}
+	if p := pauses[0]; p.line != 3 || p.uncaught || p.value != "Error: caught" {
+		t.Errorf("Unexpected first pause: %+v", p)
+	}
+	if p := pauses[1]; p.line != 7 || !p.uncaught {
+		t.Errorf("Unexpected second pause: %+v", p)
+	}
+}
+
+func TestDebuggerNoPauseOnExceptions(t *testing.T) {
+	pauses, err := runExceptionScript(t, ExceptionBreakNone, exceptionScript)
+	if err == nil {
+		t.Fatal("Expected the script to fail")
+	}
+	if len(pauses) != 0 {
+		t.Fatalf("Expected no pauses, got %v", pauses)
+	}
+}
+
+func TestDebuggerExceptionThroughNative(t *testing.T) {
+	// The exception crosses Go code on its way to the catch, it must be reported once
+	const SCRIPT = `
+try {
+	[1, 2].forEach(function(x) {
+		throw x;
+	});
+} catch (e) {
+}
+`
+	pauses, err := runExceptionScript(t, ExceptionBreakAll, SCRIPT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pauses) != 1 {
+		t.Fatalf("Expected 1 pause, got %v", pauses)
+	}
+	if p := pauses[0]; p.line != 4 || p.uncaught || p.value != "1" {
+		t.Errorf("Unexpected pause: %+v", p)
+	}
+
+	pauses, err = runExceptionScript(t, ExceptionBreakUncaught, SCRIPT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pauses) != 0 {
+		t.Fatalf("Expected no pauses, got %v", pauses)
+	}
+}
+
+func TestDebuggerExceptionVariables(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+	debugger.SetExceptionBreakMode(ExceptionBreakUncaught)
+
+	var message Value
+	var paused bool
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		paused = true
+		scopes := state.DebugStack[0].Scopes
+		if len(scopes) == 0 || scopes[0].Name != "Exception" {
+			t.Fatalf("Expected the Exception scope first, got %v", scopes)
+		}
+		vars := debugger.GetVariables(scopes[0].VariablesRef)
+		if len(vars) != 1 || vars[0].Name != "exception" || vars[0].Ref == 0 {
+			t.Fatalf("Unexpected exception variables: %v", vars)
+		}
+		for _, v := range debugger.GetVariables(vars[0].Ref) {
+			if v.Name == "message" {
+				message = v.Value
+			}
+		}
+		return DebugContinue
+	})
+
+	_, err := vm.RunString(`
+	function fail() {
+		throw new RangeError("out of range");
+	}
+	fail();
+	`)
+	if _, ok := err.(*Exception); !ok {
+		t.Fatalf("Expected an exception, got %v", err)
+	}
+	if !paused {
+		t.Fatal("The debugger did not pause")
+	}
+	if message == nil || message.String() != "out of range" {
+		t.Errorf("Unexpected message: %v", message)
+	}
+}
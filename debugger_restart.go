@@ -0,0 +1,160 @@
+package goja
+
+import (
+	"errors"
+	"fmt"
+)
+
+// runToLocation is the target of RunToLocation, a breakpoint that is removed at the next pause
+type runToLocation struct {
+	pos Position
+	loc *breakpointLocation // nil until the file is loaded
+}
+
+// frameEntry is the state of the VM on entry to a function, kept so that its frame can be restarted
+type frameEntry struct {
+	prg             *Program
+	sb              int
+	args            []Value
+	iterLen, refLen int
+}
+
+// RunToLocation resumes execution until it reaches a line, as if there was a one-shot breakpoint on it.
+// The location is forgotten as soon as execution pauses, whether it's there or somewhere else (e.g. on a
+// breakpoint). Like the other resuming methods it can be called from a DebugHandler, which then has to
+// return DebugContinue. It fails if the file has been loaded and has no code on the line.
+func (d *Debugger) RunToLocation(filename string, line int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	target := &runToLocation{pos: Position{Filename: filename, Line: line}}
+	if prg := d.programs[filename]; prg != nil {
+		if target.loc = findLocation(prg, target.pos); target.loc == nil {
+			return fmt.Errorf("no code at %s:%d", filename, line)
+		}
+	}
+	d.runTo = target
+	d.flags &^= FlagPaused
+	d.stepMode = DebugContinue
+	return nil
+}
+
+// recordEntry remembers the arguments of the function the VM is entering.
+// Must be called with the write lock held.
+func (d *Debugger) recordEntry(vm *vm) {
+	depth := len(vm.callStack)
+	for len(d.entries) <= depth {
+		d.entries = append(d.entries, frameEntry{})
+	}
+	e := &d.entries[depth]
+	e.prg = vm.prg
+	e.sb = vm.sp - vm.args - 1 // See enterFunc
+	e.args = append(e.args[:0], vm.stack[vm.sp-vm.args:vm.sp]...)
+	e.iterLen, e.refLen = len(vm.iterStack), len(vm.refStack)
+}
+
+// RestartFrame unwinds the call stack to a frame and calls its function again with the arguments it was
+// originally called with. frameIndex is the index of the frame in DebuggerState.CallStack, 0 being the
+// paused one. Execution pauses again on the first line of the function once the pause ends, whatever
+// the command returned by the DebugHandler.
+// Only ordinary functions, arrow functions and methods can be restarted, and the frames above it must all
+// be JS code. Functions that were already running when the debugger was attached can't be restarted as
+// their original arguments are unknown, see DebugStackFrame.CanRestart. Side effects, such as changes made
+// to variables outside the function, are not undone.
+// It must be called from the DebugHandler, execution must be paused in JS code and not on an exception.
+func (d *Debugger) RestartFrame(frameIndex int) error {
+	vm := d.runtime.vm
+
+	d.mu.Lock()
+	if !d.inHandler {
+		d.mu.Unlock()
+		return ErrNotPaused
+	}
+	if !d.canRestart {
+		d.mu.Unlock()
+		return errors.New("frames can only be restarted when paused in JS code and not on an exception")
+	}
+	e, f, err := d.restartTarget(vm, frameIndex)
+	d.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	depth, sb := len(vm.callStack)-frameIndex, e.sb
+
+	for len(vm.callStack) > depth {
+		vm.popCtx()
+	}
+	for len(vm.tryStack) > 0 && int(vm.tryStack[len(vm.tryStack)-1].callStackLen) >= depth {
+		vm.popTryFrame()
+	}
+	_ = vm.restoreStacks(uint32(e.iterLen), uint32(e.refLen))
+
+	// Back to the state the function was called in, see vmCall
+	vm.stack.expand(sb + len(e.args))
+	copy(vm.stack[sb+1:], e.args)
+	vm.sp = sb + 1 + len(e.args)
+	vm.args = len(e.args)
+	vm.prg = e.prg
+	vm.pc = 0
+	vm.stash = f.stash
+	vm.privEnv = f.privEnv
+
+	d.mu.Lock()
+	d.restarted = true
+	d.mu.Unlock()
+	return nil
+}
+
+// restartTarget returns the entry of the frame at frameIndex and its function, or why it can't be restarted.
+// Must be called with the lock held.
+func (d *Debugger) restartTarget(vm *vm, frameIndex int) (frameEntry, *baseJsFuncObject, error) {
+	depth := len(vm.callStack) - frameIndex // Length of the call stack while the frame runs
+	if frameIndex < 0 || depth < 1 {
+		return frameEntry{}, nil, fmt.Errorf("invalid frame index %d", frameIndex)
+	}
+	for i := len(vm.callStack) - 1; i >= depth; i-- {
+		// Calls made by Go code (e.g. by a native function) run in their own loop, which can't be unwound
+		if ctx := &vm.callStack[i]; ctx.prg == nil || ctx.pc < 0 {
+			return frameEntry{}, nil, errors.New("can't restart a frame called from native code")
+		}
+	}
+	prg, sb := vm.prg, vm.sb
+	if frameIndex > 0 {
+		ctx := &vm.callStack[depth]
+		prg, sb = ctx.prg, ctx.sb
+	}
+	if prg == nil || sb < 1 {
+		return frameEntry{}, nil, fmt.Errorf("frame %d is not a function call", frameIndex)
+	}
+	var f *baseJsFuncObject
+	if fn, ok := vm.stack[sb-1].(*Object); ok {
+		switch fn := fn.self.(type) {
+		case *funcObject:
+			f = &fn.baseJsFuncObject
+		case *methodFuncObject:
+			f = &fn.baseJsFuncObject
+		case *arrowFuncObject:
+			f = &fn.baseJsFuncObject
+		}
+	}
+	if f == nil || f.prg != prg {
+		return frameEntry{}, nil, errors.New("only ordinary functions, arrow functions and methods can be restarted")
+	}
+	if depth >= len(d.entries) || d.entries[depth].prg != prg || d.entries[depth].sb != sb {
+		return frameEntry{}, nil, fmt.Errorf("frame %d was entered before the debugger was attached", frameIndex)
+	}
+	e := d.entries[depth]
+	e.args = append([]Value(nil), e.args...)
+	return e, f, nil
+}
+
+// stepIntoRestarted makes execution pause on the first line of a restarted frame.
+// Must be called with the write lock held.
+func (d *Debugger) stepIntoRestarted(vm *vm) {
+	d.restarted = false
+	d.flags |= FlagStepMode
+	d.flags &^= FlagPaused
+	d.stepMode = DebugStepOver
+	d.stepDepth = len(vm.callStack)
+	d.lastSourceLine = -1
+}
@@ -0,0 +1,298 @@
+package goja
+
+import (
+	"testing"
+)
+
+func TestDebuggerRunToLocation(t *testing.T) {
+	const SCRIPT = `
+	function work(n) {
+		var total = 0;
+		for (var i = 0; i < n; i++) {
+			total += i;
+		}
+		return total;
+	}
+	debugger;
+	work(3);
+	work(4);
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	var lines []int
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		lines = append(lines, state.SourcePos.Line)
+		if len(lines) == 1 {
+			if err := debugger.RunToLocation("", 100); err == nil {
+				t.Error("Expected an error for a line without code")
+			}
+			if err := debugger.RunToLocation("", 7); err != nil {
+				t.Fatal(err)
+			}
+			return DebugContinue
+		}
+		if state.Breakpoint != nil {
+			t.Error("Unexpected breakpoint")
+		}
+		v, err := debugger.EvaluateInFrame("total", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.ToInteger() != 3 {
+			t.Errorf("Expected 3, got %v", v)
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+	// The location is only used once
+	if len(lines) != 2 || lines[0] != 10 || lines[1] != 7 {
+		t.Errorf("Unexpected pauses: %v", lines)
+	}
+}
+
+func TestDebuggerRunToLocationInterrupted(t *testing.T) {
+	const SCRIPT = `
+	var x = 0;
+	debugger;
+	x++;
+	x++;
+	debugger;
+	x++;
+	x++;
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	var lines []int
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		lines = append(lines, state.SourcePos.Line)
+		if len(lines) == 1 {
+			// Pausing after the second debugger statement cancels it
+			if err := debugger.RunToLocation("", 8); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[0] != 4 || lines[1] != 7 {
+		t.Errorf("Unexpected pauses: %v", lines)
+	}
+}
+
+func TestDebuggerRestartFrame(t *testing.T) {
+	const SCRIPT = `
+	var calls = 0;
+	function inner(x) {
+		calls++;
+		x = x * 2;
+		debugger;
+		return x;
+	}
+	function outer(a, b) {
+		var r = inner(a + b);
+		return r + 1;
+	}
+	var result = outer(1, 2);
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	type pause struct {
+		funcName string
+		line     int
+	}
+	var pauses []pause
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		pauses = append(pauses, pause{state.CallStack[0].FuncName(), state.SourcePos.Line})
+		switch len(pauses) {
+		case 1:
+			if err := debugger.RestartFrame(len(state.CallStack) - 1); err == nil {
+				t.Error("Expected an error for the global code")
+			}
+			if err := debugger.RestartFrame(len(state.CallStack)); err == nil {
+				t.Error("Expected an error for an invalid frame")
+			}
+			if err := debugger.RestartFrame(1); err != nil {
+				t.Fatal(err)
+			}
+		case 2:
+			if v, err := debugger.EvaluateInFrame("a + b", 0); err != nil || v.ToInteger() != 3 {
+				t.Errorf("Unexpected arguments: %v, %v", v, err)
+			}
+		case 3:
+			if err := debugger.RestartFrame(0); err != nil {
+				t.Fatal(err)
+			}
+		case 4:
+			// The argument was modified before the restart
+			if v, err := debugger.EvaluateInFrame("x", 0); err != nil || v.ToInteger() != 3 {
+				t.Errorf("Expected the original argument, got %v, %v", v, err)
+			}
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restarted functions pause on their first position, the declaration
+	expected := []pause{{"inner", 7}, {"outer", 9}, {"inner", 7}, {"inner", 3}, {"inner", 7}}
+	if len(pauses) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, pauses)
+	}
+	for i := range expected {
+		if pauses[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, pauses)
+		}
+	}
+	if res := vm.Get("result").ToInteger(); res != 7 {
+		t.Errorf("Expected 7, got %d", res)
+	}
+	if calls := vm.Get("calls").ToInteger(); calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDebuggerRestartFrameNative(t *testing.T) {
+	const SCRIPT = `
+	function each(list) {
+		var sum = 0;
+		list.forEach(function(v) {
+			sum += v;
+			debugger;
+		});
+		return sum;
+	}
+	each([1, 2]);
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	restarted := false
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.CallStack[0].FuncName() == "each" || restarted {
+			return DebugContinue
+		}
+		if err := debugger.RestartFrame(2); err == nil {
+			t.Error("Expected an error for a frame above a native call")
+		}
+		// The callback itself can be restarted
+		if err := debugger.RestartFrame(0); err != nil {
+			t.Fatal(err)
+		}
+		restarted = true
+		return DebugContinue
+	})
+
+	res, err := vm.RunString(SCRIPT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restarted {
+		t.Fatal("No pause")
+	}
+	// The first element was added twice
+	if res.ToInteger() != 4 {
+		t.Errorf("Expected 4, got %v", res)
+	}
+}
+
+func TestAsyncDebuggerRestartFrame(t *testing.T) {
+	const SCRIPT = `
+	function f(n) {
+		var m = n + 1;
+		m = m * 2;
+		return m;
+	}
+	debugger;
+	var result = f(1);
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+	a := debugger.EnableAsync()
+	defer a.Close()
+
+	done := runAsync(vm, SCRIPT)
+
+	nextPause(t, a)
+	if err := a.RunToLocation("", 4); err != nil {
+		t.Fatal(err)
+	}
+	if state := nextPause(t, a); state.SourcePos.Line != 4 {
+		t.Errorf("Expected to pause on line 4, got %d", state.SourcePos.Line)
+	}
+	if err := a.RestartFrame(0); err != nil {
+		t.Fatal(err)
+	}
+	if state := nextPause(t, a); state.SourcePos.Line != 2 {
+		t.Errorf("Expected to pause on line 2, got %d", state.SourcePos.Line)
+	}
+	if err := a.Continue(); err != nil {
+		t.Fatal(err)
+	}
+	waitDone(t, done)
+	if res := vm.Get("result").ToInteger(); res != 4 {
+		t.Errorf("Expected 4, got %d", res)
+	}
+}
+
+func TestDebuggerRestartFrameAttachedMidCall(t *testing.T) {
+	const SCRIPT = `
+	function inner(x) {
+		debugger;
+		return x;
+	}
+	function outer(a) {
+		attach();
+		return inner(a + 1);
+	}
+	outer(1);
+	`
+
+	vm := New()
+	var frames []bool
+	vm.Set("attach", func() {
+		debugger := vm.EnableDebugger()
+		debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+			if frames != nil {
+				return DebugContinue
+			}
+			for _, frame := range state.DebugStack {
+				frames = append(frames, frame.CanRestart)
+			}
+			// outer was entered before the debugger was attached
+			if err := debugger.RestartFrame(1); err == nil {
+				t.Error("Expected an error for a frame entered before the debugger was attached")
+			}
+			if err := debugger.RestartFrame(0); err != nil {
+				t.Fatal(err)
+			}
+			return DebugContinue
+		})
+	})
+
+	res, err := vm.RunString(SCRIPT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 3 || !frames[0] || frames[1] || frames[2] {
+		t.Errorf("Unexpected restartable frames: %v", frames)
+	}
+	if res.ToInteger() != 2 {
+		t.Errorf("Expected 2, got %v", res)
+	}
+}
@@ -0,0 +1,297 @@
+package goja
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dop251/goja/unistring"
+)
+
+// frameScope is the scope of a paused frame: its innermost stash and the variables it keeps on the
+// VM stack (see stackVar). The slots are reused once execution resumes, so after the pause the
+// scope was captured in is over the stack variables keep the values they had at the time and can
+// no longer be modified.
+type frameScope struct {
+	vm    *vm
+	prg   *Program
+	pc    int
+	stash *stash
+	sb    int
+	args  int
+	pause uint64 // Debugger.pauses at the time the scope was captured
+
+	vars   []*stackVar // Stack variables in scope, in declaration order
+	values []Value     // Values of vars when the scope was captured
+}
+
+// currentScope returns the scope of the instruction the VM is about to execute
+func (d *Debugger) currentScope(vm *vm) *frameScope {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.newFrameScope(vm, vm.stash, vm.prg, vm.pc, vm.sb, vm.args)
+}
+
+// frameScope returns the scope of a stack frame.
+// Must be called with the lock held.
+func (d *Debugger) frameScope(frame *StackFrame) *frameScope {
+	if frame.ctx == nil {
+		return d.newFrameScope(d.runtime.vm, nil, nil, 0, 0, 0)
+	}
+	return d.newFrameScope(d.runtime.vm, frame.ctx.stash, frame.prg, frame.pc, frame.ctx.sb, frame.ctx.args)
+}
+
+// Must be called with the lock held.
+func (d *Debugger) newFrameScope(vm *vm, s *stash, prg *Program, pc, sb, args int) *frameScope {
+	global := &d.runtime.global.stash
+	if s == nil {
+		s = global
+	}
+	f := &frameScope{
+		vm:    vm,
+		prg:   prg,
+		pc:    pc,
+		stash: s,
+		sb:    sb,
+		args:  args,
+		pause: d.pauses,
+	}
+	if prg == nil || len(prg.stackVars) == 0 {
+		return f
+	}
+
+	// Stack variables are shadowed by inner ones and by the stash variables of the frame's own code
+	seen := make(map[unistring.String]struct{})
+	closure := calleeStash(vm, sb)
+	for ; s != nil && s != closure && s != global; s = s.outer {
+		for name := range s.names {
+			seen[name] = struct{}{}
+		}
+	}
+	for i := len(prg.stackVars) - 1; i >= 0; i-- {
+		v := &prg.stackVars[i]
+		if pc < v.start || pc >= v.end {
+			continue
+		}
+		if _, shadowed := seen[v.name]; shadowed {
+			continue
+		}
+		seen[v.name] = struct{}{}
+		f.vars = append(f.vars, v)
+	}
+	f.values = make([]Value, len(f.vars))
+	for i, j := 0, len(f.vars)-1; i < j; i, j = i+1, j-1 {
+		f.vars[i], f.vars[j] = f.vars[j], f.vars[i]
+	}
+	for i, v := range f.vars {
+		f.values[i] = f.slotValue(v)
+	}
+	return f
+}
+
+// slot returns the location of a stack variable, or nil if it doesn't exist (a missing argument)
+func (f *frameScope) slot(v *stackVar) *Value {
+	var idx int
+	switch {
+	case v.stack1:
+		idx = f.sb + v.idx
+	case v.idx < 0:
+		if -v.idx > f.args {
+			return nil
+		}
+		idx = f.sb - v.idx
+	default:
+		idx = f.sb + f.args + v.idx
+	}
+	if idx <= 0 || idx >= len(f.vm.stack) {
+		return nil
+	}
+	return &f.vm.stack[idx]
+}
+
+func (f *frameScope) slotValue(v *stackVar) Value {
+	if p := f.slot(v); p != nil {
+		return *p
+	}
+	if v.idx < 0 {
+		return _undefined
+	}
+	return nil
+}
+
+// stackValue returns the value of the i-th stack variable of f, nil if it's not initialised.
+// Must be called with the lock held.
+func (d *Debugger) stackValue(f *frameScope, i int) Value {
+	if f.pause == d.pauses {
+		return f.slotValue(f.vars[i])
+	}
+	return f.values[i]
+}
+
+// materialise returns a stash holding a copy of the stack variables in scope, placed on top of the
+// frame's stash chain so that code compiled against it sees them like a direct eval() would. The
+// returned function writes the changes made to the copies back to the stack.
+// Must be called with the lock held.
+func (d *Debugger) materialise(f *frameScope) (*stash, func()) {
+	if len(f.vars) == 0 || f.pause != d.pauses {
+		return f.stash, func() {}
+	}
+	s := &stash{
+		outer:  f.stash,
+		names:  make(map[unistring.String]uint32, len(f.vars)),
+		values: make([]Value, len(f.vars)),
+	}
+	for i, v := range f.vars {
+		s.names[v.name] = uint32(i) | v.flags
+		s.values[i] = f.slotValue(v)
+	}
+	return s, func() {
+		for i, v := range f.vars {
+			if v.flags&maskConst != 0 {
+				continue
+			}
+			if p := f.slot(v); p != nil && !sameValue(*p, s.values[i]) {
+				*p = s.values[i]
+			}
+		}
+	}
+}
+
+// extractStackVariables lists the stack variables of a frame. Like extractStashVariables, names in seen
+// are skipped and the extracted names are added to it.
+func (d *Debugger) extractStackVariables(f *frameScope, seen map[unistring.String]struct{}) []Variable {
+	var variables []Variable
+	for i, v := range f.vars {
+		if _, shadowed := seen[v.name]; shadowed {
+			continue
+		}
+		d.mu.RLock()
+		val := d.stackValue(f, i)
+		d.mu.RUnlock()
+		if val == nil {
+			continue
+		}
+		seen[v.name] = struct{}{}
+		variables = append(variables, Variable{
+			Name:  v.name.String(),
+			Value: val,
+			Type:  d.getValueType(val),
+			Ref:   d.ValueRef(val),
+		})
+	}
+	return variables
+}
+
+// SetVariable assigns a new value to a variable or a property listed by GetVariables: variablesRef is
+// the reference it was listed under and name its name (the original or the generated one for a variable
+// renamed by a source map). The value is a JS expression, evaluated in the
+// scope of the frame the variable belongs to (of the top frame for object properties), so it can refer
+// to other variables. Constants and variables in their temporal dead zone can't be assigned.
+// It returns the variable with its new value. Like EvaluateInFrame, it must be called from the debug
+// handler.
+func (d *Debugger) SetVariable(variablesRef int, name, value string) (Variable, error) {
+	d.mu.RLock()
+	refData, exists := d.variableRefs[variablesRef]
+	d.mu.RUnlock()
+	if !exists {
+		return Variable{}, fmt.Errorf("invalid variables reference: %d", variablesRef)
+	}
+
+	var f *frameScope
+	if sr, ok := refData.(*scopeRef); ok && sr.frame != nil {
+		f = sr.frame
+	} else {
+		f = d.currentScope(d.runtime.vm)
+	}
+
+	// The parentheses make sure it's parsed as an expression, e.g. {} is an object rather than a block
+	v, err := d.evalInScope("(\n"+value+"\n)", f)
+	if err != nil {
+		return Variable{}, err
+	}
+
+	switch data := refData.(type) {
+	case *Object:
+		err = data.Set(name, v)
+	case *scopeRef:
+		err = d.setScopeVariable(data, d.generatedName(data, unistring.NewFromString(name)), v)
+	default:
+		err = errors.New("unsupported variables reference")
+	}
+	if err != nil {
+		return Variable{}, err
+	}
+	return Variable{
+		Name:  name,
+		Value: v,
+		Type:  d.getValueType(v),
+		Ref:   d.ValueRef(v),
+	}, nil
+}
+
+// setScopeVariable assigns v to the variable name of a scope, looked up the same way as
+// getVariablesForScope lists them.
+func (d *Debugger) setScopeVariable(scope *scopeRef, name unistring.String, v Value) error {
+	global := &d.runtime.global.stash
+	switch scope.typ {
+	case "local", "closure":
+		for s := scope.stash; s != nil && s != global; s = s.outer {
+			if s.obj != nil {
+				if stashObjHas(s.obj, name) {
+					return s.obj.Set(name.String(), v)
+				}
+			} else if idx, exists := s.names[name]; exists {
+				return setStashValue(s, name, idx, v)
+			}
+			if scope.typ == "local" && s.isVariable() {
+				break
+			}
+		}
+		if f := scope.frame; scope.typ == "local" && f != nil {
+			for _, sv := range f.vars {
+				if sv.name == name {
+					return d.setStackValue(f, sv, v)
+				}
+			}
+		}
+	case "global":
+		if idx, exists := global.names[name]; exists {
+			return setStashValue(global, name, idx, v)
+		}
+		if obj := d.runtime.globalObject; obj.self.hasPropertyStr(name) {
+			return obj.Set(name.String(), v)
+		}
+	case "exception":
+		return errors.New("the exception can't be modified")
+	}
+	return fmt.Errorf("variable %q not found", name)
+}
+
+func setStashValue(s *stash, name unistring.String, idx uint32, v Value) error {
+	p := &s.values[idx&^maskTyp]
+	if idx&maskConst != 0 {
+		return fmt.Errorf("%s is a constant", name)
+	}
+	if *p == nil && idx&maskVar == 0 {
+		return fmt.Errorf("%s is not initialized", name)
+	}
+	*p = v
+	return nil
+}
+
+func (d *Debugger) setStackValue(f *frameScope, sv *stackVar, v Value) error {
+	if sv.flags&maskConst != 0 {
+		return fmt.Errorf("%s is a constant", sv.name)
+	}
+	d.mu.RLock()
+	live := f.pause == d.pauses
+	d.mu.RUnlock()
+	p := f.slot(sv)
+	if !live || p == nil {
+		return fmt.Errorf("%s is no longer available", sv.name)
+	}
+	if *p == nil && sv.flags&maskVar == 0 {
+		return fmt.Errorf("%s is not initialized", sv.name)
+	}
+	*p = v
+	return nil
+}
@@ -0,0 +1,65 @@
+package goja
+
+import (
+	"sort"
+
+	"github.com/dop251/goja/file"
+)
+
+// Script is a source file loaded by the Runtime
+type Script struct {
+	ID       int // Unique for each loaded file, a file compiled again gets a new one
+	Filename string
+	Source   string
+
+	file *file.File
+}
+
+// ScriptHandler is notified of the scripts loaded by the Runtime
+type ScriptHandler func(script Script)
+
+// SetScriptHandler sets a handler that is called, on the VM goroutine, every time the Runtime starts to run
+// code from a new source file: a script, a module or code given to eval() or the Function constructor.
+// Code run by the DebugHandler is not reported.
+func (d *Debugger) SetScriptHandler(handler ScriptHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.scriptHandler = handler
+}
+
+// Scripts returns the latest script loaded for each file name, in loading order
+func (d *Debugger) Scripts() []Script {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make([]Script, 0, len(d.scripts))
+	for _, script := range d.scripts {
+		result = append(result, *script)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// loadScript records the source file of a program that is about to run. It returns the script and the
+// handler to notify if the file is new.
+// Must be called with the write lock held.
+func (d *Debugger) loadScript(prg *Program) (*Script, ScriptHandler) {
+	name := prg.src.Name()
+	if script := d.scripts[name]; script != nil && script.file == prg.src {
+		return nil, nil
+	}
+	d.nextScriptID++
+	script := &Script{
+		ID:       d.nextScriptID,
+		Filename: name,
+		Source:   prg.src.Source(),
+		file:     prg.src,
+	}
+	if d.scripts == nil {
+		d.scripts = make(map[string]*Script)
+	}
+	d.scripts[name] = script
+	return script, d.scriptHandler
+}
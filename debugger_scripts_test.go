@@ -0,0 +1,54 @@
+package goja
+
+import (
+	"testing"
+)
+
+func TestDebuggerScripts(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	var loaded []Script
+	debugger.SetScriptHandler(func(script Script) {
+		loaded = append(loaded, script)
+	})
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		// Code run by the handler is not reported
+		if _, err := debugger.EvaluateInFrame("1 + 1", 0); err != nil {
+			t.Fatal(err)
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunScript("a.js", "var a = 1; debugger;"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.RunScript("b.js", "eval('var b = 2')"); err != nil {
+		t.Fatal(err)
+	}
+	prg := MustCompile("a.js", "var a = 3;", false)
+	for i := 0; i < 2; i++ {
+		// Running the same program again doesn't load anything
+		if _, err := vm.RunProgram(prg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(loaded) != 4 {
+		t.Fatalf("Expected 4 scripts, got %+v", loaded)
+	}
+	if loaded[0].Filename != "a.js" || loaded[0].Source != "var a = 1; debugger;" || loaded[1].Filename != "b.js" {
+		t.Errorf("Unexpected scripts %+v", loaded)
+	}
+	for i := 1; i < len(loaded); i++ {
+		if loaded[i].ID <= loaded[i-1].ID {
+			t.Errorf("Script ids are not increasing: %+v", loaded)
+		}
+	}
+
+	// The second a.js replaces the first one
+	scripts := debugger.Scripts()
+	if len(scripts) != 3 || scripts[0].Filename != "b.js" || scripts[2].Source != "var a = 3;" {
+		t.Errorf("Unexpected scripts %+v", scripts)
+	}
+}
@@ -0,0 +1,229 @@
+package goja
+
+import (
+	"testing"
+)
+
+func findVariable(vars []Variable, name string) *Variable {
+	for i := range vars {
+		if vars[i].Name == name {
+			return &vars[i]
+		}
+	}
+	return nil
+}
+
+func TestDebuggerSetStackVariable(t *testing.T) {
+	const SCRIPT = `
+	function calc(a, b) {
+		var sum = a + b;
+		const limit = 100;
+		for (let i = 0; i < 1; i++) {
+			debugger;
+			sum += i;
+		}
+		return [a, b, sum, limit];
+	}
+	calc(1, 2).join();
+	`
+
+	// Without debug mode the variables are kept on the stack
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		ref := state.DebugStack[0].Scopes[0].VariablesRef
+		vars := debugger.GetVariables(ref)
+		for name, expected := range map[string]int64{"a": 1, "b": 2, "sum": 3, "limit": 100, "i": 0} {
+			if v := findVariable(vars, name); v == nil || v.Value.ToInteger() != expected {
+				t.Errorf("Expected %s to be %d, got %+v", name, expected, v)
+			}
+		}
+
+		if v, err := debugger.SetVariable(ref, "sum", "sum * 10 + limit"); err != nil {
+			t.Fatal(err)
+		} else if v.Value.ToInteger() != 130 || v.Type != "number" {
+			t.Errorf("Unexpected result %+v", v)
+		}
+		if _, err := debugger.SetVariable(ref, "a", "'x'"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.SetVariable(ref, "i", "5"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.SetVariable(ref, "limit", "1"); err == nil {
+			t.Error("Expected an error when assigning a constant")
+		}
+		if _, err := debugger.SetVariable(ref, "missing", "1"); err == nil {
+			t.Error("Expected an error for a missing variable")
+		}
+		if _, err := debugger.SetVariable(ref, "b", "nope"); err == nil {
+			t.Error("Expected an error for an invalid expression")
+		}
+
+		// Evaluations can assign stack variables too
+		if _, err := debugger.EvaluateInFrame("b = sum + 1", 0); err != nil {
+			t.Fatal(err)
+		}
+		return DebugContinue
+	})
+
+	res, err := vm.RunString(SCRIPT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := res.String(); s != "x,131,135,100" {
+		t.Errorf("Unexpected result %q", s)
+	}
+}
+
+func TestDebugInfoOnlyWhenDebugging(t *testing.T) {
+	const SCRIPT = `
+	{
+		let x = 1;
+		x++;
+	}
+	`
+	prg, err := Compile("test.js", SCRIPT, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prg.stackVars) != 0 {
+		t.Errorf("Expected no stack variables, got %+v", prg.stackVars)
+	}
+
+	prg, err = CompileForDebugging("test.js", SCRIPT, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prg.stackVars) != 1 || prg.stackVars[0].name != "x" {
+		t.Errorf("Expected x to be recorded, got %+v", prg.stackVars)
+	}
+}
+
+func TestDebuggerSetVariableAfterResume(t *testing.T) {
+	const SCRIPT = `
+	function f(x) {
+		debugger;
+		return x;
+	}
+	f(1);
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	var ref int
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		ref = state.DebugStack[0].Scopes[0].VariablesRef
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stack slots are gone, the values seen during the pause are kept
+	if v := findVariable(debugger.GetVariables(ref), "x"); v == nil || v.Value.ToInteger() != 1 {
+		t.Errorf("Unexpected x: %+v", v)
+	}
+	if _, err := debugger.SetVariable(ref, "x", "2"); err == nil {
+		t.Error("Expected an error when the pause is over")
+	}
+}
+
+func TestDebuggerSetStashVariable(t *testing.T) {
+	const SCRIPT = `
+	var counter = 0;
+	let label = "a";
+	const fixed = 1;
+	function makeInc(step) {
+		return function() {
+			debugger;
+			counter += step;
+			return counter;
+		};
+	}
+	var inc = makeInc(1);
+	inc();
+	[counter, label, config.level, config.extra.deep].join();
+	`
+
+	vm := New()
+	config := vm.NewObject()
+	config.Set("level", 1)
+	vm.Set("config", config)
+	debugger := vm.EnableDebugger()
+
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		scopes := state.DebugStack[0].Scopes
+		if len(scopes) != 3 || scopes[1].Name != "Closure" {
+			t.Fatalf("Unexpected scopes %v", scopes)
+		}
+		closure, global := scopes[1].VariablesRef, scopes[2].VariablesRef
+
+		if _, err := debugger.SetVariable(closure, "step", "10"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.SetVariable(global, "counter", "5"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.SetVariable(global, "label", "label + 'b'"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.SetVariable(global, "fixed", "2"); err == nil {
+			t.Error("Expected an error when assigning a constant")
+		}
+
+		configRef := findVariable(debugger.GetVariables(global), "config").Ref
+		if _, err := debugger.SetVariable(configRef, "level", "3"); err != nil {
+			t.Fatal(err)
+		}
+		v, err := debugger.SetVariable(configRef, "extra", "{deep: step}")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Ref == 0 || v.Type != "object" {
+			t.Errorf("Expected an object, got %+v", v)
+		}
+		return DebugContinue
+	})
+
+	res, err := vm.RunString(SCRIPT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := res.String(); s != "15,ab,3,10" {
+		t.Errorf("Unexpected result %q", s)
+	}
+}
+
+func TestAsyncDebuggerSetVariable(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+	a := debugger.EnableAsync()
+	defer a.Close()
+
+	done := runAsync(vm, `
+	var x = 1;
+	debugger;
+	x++;
+	`)
+
+	state := nextPause(t, a)
+	scopes := state.DebugStack[0].Scopes
+	if _, err := a.SetVariable(scopes[len(scopes)-1].VariablesRef, "x", "41"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Continue(); err != nil {
+		t.Fatal(err)
+	}
+	waitDone(t, done)
+
+	if x := vm.Get("x").ToInteger(); x != 42 {
+		t.Errorf("Expected 42, got %d", x)
+	}
+	if _, err := a.SetVariable(1, "x", "1"); err != ErrNotPaused {
+		t.Errorf("Expected ErrNotPaused, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package goja
+
+import (
+	"github.com/dop251/goja/unistring"
+)
+
+// sourceFiles returns the names of the files the code of prg comes from: its own and, if it has a source
+// map, the original sources the map points to.
+func sourceFiles(prg *Program) map[string]struct{} {
+	files := map[string]struct{}{prg.src.Name(): {}}
+	if prg.src.SourceMap() == nil {
+		return files
+	}
+	walkPrograms(prg, func(p *Program) bool {
+		if p.src != nil {
+			for _, item := range p.srcMap {
+				files[p.src.Position(item.srcPos).Filename] = struct{}{}
+			}
+		}
+		return true
+	})
+	return files
+}
+
+// originalName returns the name the source map of prg gives to the variable name in the scope of the
+// instruction at pc, or "" if it doesn't rename it. Source maps only name tokens, so it's the name of the
+// identifier declaring the variable.
+func originalName(prg *Program, pc int, name unistring.String) string {
+	if prg == nil || prg.src == nil || prg.src.SourceMap() == nil {
+		return ""
+	}
+	// The declarations of the nested scopes come after the ones of the scopes enclosing them
+	for i := len(prg.varDecls) - 1; i >= 0; i-- {
+		d := &prg.varDecls[i]
+		if d.name != name || pc < d.start || pc >= d.end {
+			continue
+		}
+		if orig := prg.src.OriginalName(d.offset); orig != name.String() {
+			return orig
+		}
+		return ""
+	}
+	return ""
+}
+
+// enclosingProgram returns the Program of the function enclosing prg and the pc of the instruction that
+// creates prg in it, the scope the function closes over. It returns nil if prg is not a function or if
+// it's been compiled without the debug information.
+func enclosingProgram(prg *Program) (*Program, int) {
+	if prg == nil || prg.outer == nil {
+		return nil, 0
+	}
+	for pc, ins := range prg.outer.code {
+		for _, p := range nestedPrograms(ins) {
+			if p == prg {
+				return prg.outer, pc
+			}
+		}
+	}
+	return nil, 0
+}
+
+// renameVariables reports the variables of the scope of the instruction at pc in prg under their original
+// names, see originalName
+func renameVariables(prg *Program, pc int, variables []Variable) {
+	for i := range variables {
+		v := &variables[i]
+		if orig := originalName(prg, pc, unistring.NewFromString(v.Name)); orig != "" {
+			v.GeneratedName = v.Name
+			v.Name = orig
+		}
+	}
+}
+
+// generatedName returns the name of the variable of a scope that GetVariables reports as name, which is
+// different if the source map renames it.
+func (d *Debugger) generatedName(scope *scopeRef, name unistring.String) unistring.String {
+	if scope.frame == nil || scope.typ != "local" && scope.typ != "closure" {
+		return name
+	}
+	prg, pc := scope.frame.prg, scope.frame.pc
+	if prg == nil || prg.src == nil || prg.src.SourceMap() == nil {
+		return name
+	}
+	if scope.typ == "closure" {
+		prg, pc = enclosingProgram(prg)
+	}
+	global := &d.runtime.global.stash
+	for s := scope.stash; s != nil && s != global; s = s.outer {
+		for n := range s.names {
+			if originalName(prg, pc, n) == name.String() {
+				return n
+			}
+		}
+		if s.isVariable() {
+			if scope.typ == "local" {
+				break
+			}
+			prg, pc = enclosingProgram(prg)
+		}
+	}
+	if scope.typ == "local" {
+		for _, v := range scope.frame.vars {
+			if originalName(scope.frame.prg, scope.frame.pc, v.name) == name.String() {
+				return v.name
+			}
+		}
+	}
+	return name
+}
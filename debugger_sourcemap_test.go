@@ -0,0 +1,249 @@
+package goja
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Generated from this TypeScript source, with the variables of add renamed:
+//
+//	// Adds two numbers
+//
+//	function add(first: number, second: number): number {
+//	    const total = first + second;
+//	    return total;
+//	}
+//	const result = add(1, 2);
+const sourceMappedScript = `function add(a, b) {
+    var c = a + b;
+    return c;
+}
+var result = add(1, 2);
+`
+
+// sourceMapping maps a generated column to a position in the original source, name is an index
+// into names or -1
+type sourceMapping struct {
+	genColumn, line, column, name int
+}
+
+func encodeVLQ(sb *strings.Builder, values ...int) {
+	const digits = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	for _, v := range values {
+		u := v << 1
+		if v < 0 {
+			u = -v<<1 | 1
+		}
+		for {
+			digit := u & 31
+			u >>= 5
+			if u > 0 {
+				digit |= 32
+			}
+			sb.WriteByte(digits[digit])
+			if u == 0 {
+				break
+			}
+		}
+	}
+}
+
+// inlineSourceMap returns a sourceMappingURL comment with a single source map with one list of mappings
+// per generated line. Lines are 1-based, columns 0-based.
+func inlineSourceMap(source string, names []string, lines [][]sourceMapping) string {
+	var mappings strings.Builder
+	var line, column, name int
+	for i, segments := range lines {
+		if i > 0 {
+			mappings.WriteByte(';')
+		}
+		genColumn := 0
+		for j, m := range segments {
+			if j > 0 {
+				mappings.WriteByte(',')
+			}
+			encodeVLQ(&mappings, m.genColumn-genColumn, 0, m.line-1-line, m.column-column)
+			genColumn, line, column = m.genColumn, m.line-1, m.column
+			if m.name >= 0 {
+				encodeVLQ(&mappings, m.name-name)
+				name = m.name
+			}
+		}
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	sm := fmt.Sprintf(`{"version":3,"sources":[%q],"names":[%s],"mappings":%q}`, source, strings.Join(quoted, ","), mappings.String())
+	return "//# sourceMappingURL=data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(sm))
+}
+
+func compileSourceMapped(t *testing.T) *Program {
+	const first, second, total = 0, 1, 2
+	sm := inlineSourceMap("../src/app.ts", []string{"first", "second", "total"}, [][]sourceMapping{
+		{{0, 3, 0, -1}, {13, 3, 13, first}, {16, 3, 28, second}},
+		{{4, 4, 4, -1}, {8, 4, 10, total}, {12, 4, 18, first}, {16, 4, 26, second}},
+		{{4, 5, 4, -1}, {11, 5, 11, total}},
+		{{0, 6, 0, -1}},
+		{{0, 7, 0, -1}, {13, 7, 15, -1}, {22, 7, 24, -1}},
+	})
+	prg, err := CompileForDebugging("dist/app.js", sourceMappedScript+sm, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prg
+}
+
+func TestDebuggerSourceMapBreakpoint(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+	id := debugger.AddBreakpoint("src/app.ts", 5, 0)
+
+	paused := false
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		paused = true
+		if state.Breakpoint == nil || state.Breakpoint.ID() != id {
+			t.Fatalf("Unexpected pause at %v", state.SourcePos)
+		}
+		if pos := state.SourcePos; pos.Filename != "src/app.ts" || pos.Line != 5 {
+			t.Errorf("Expected the original position, got %v", pos)
+		}
+		if pos := state.DebugStack[0].Position(); pos.Filename != "src/app.ts" || pos.Line != 5 {
+			t.Errorf("Expected the original position in the stack, got %v", pos)
+		}
+		if pos := state.DebugStack[1].Position(); pos.Filename != "src/app.ts" || pos.Line != 7 {
+			t.Errorf("Expected the caller at the original position, got %v", pos)
+		}
+
+		ref := state.DebugStack[0].Scopes[0].VariablesRef
+		vars := debugger.GetVariables(ref)
+		for name, generated := range map[string]string{"first": "a", "second": "b", "total": "c"} {
+			v := findVariable(vars, name)
+			if v == nil || v.GeneratedName != generated {
+				t.Errorf("Expected %s to be reported as %s, got %+v", generated, name, vars)
+			}
+		}
+
+		// Expressions run against the generated code
+		if _, err := debugger.SetVariable(ref, "total", "a * 10"); err != nil {
+			t.Fatal(err)
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunProgram(compileSourceMapped(t)); err != nil {
+		t.Fatal(err)
+	}
+	if !paused {
+		t.Fatal("The breakpoint was not hit")
+	}
+	if result := vm.Get("result").ToInteger(); result != 10 {
+		t.Errorf("Expected 10, got %d", result)
+	}
+}
+
+func TestDebuggerSourceMapGeneratedBreakpoint(t *testing.T) {
+	vm := New()
+	debugger := vm.EnableDebugger()
+	debugger.AddBreakpoint("dist/app.js", 2, 0)
+
+	var lines []int
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		lines = append(lines, state.SourcePos.Line)
+		if len(lines) == 1 {
+			// The program is known by its original source too
+			debugger.AddBreakpoint("src/app.ts", 5, 0)
+			for _, bp := range debugger.GetBreakpoints() {
+				if !bp.Resolved() {
+					t.Errorf("Breakpoint #%d is not resolved", bp.ID())
+				}
+			}
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunProgram(compileSourceMapped(t)); err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[0] != 4 || lines[1] != 5 {
+		t.Errorf("Expected pauses on the original lines 4 and 5, got %v", lines)
+	}
+}
+
+func TestDebuggerSourceMapNestedScopes(t *testing.T) {
+	// A minifier reuses b in nested functions: the parameter of h is value, the variable of g is result
+	const script = `function f() {
+    var c = 1;
+    function g() {
+        function h(b) { return b; }
+        var b = h(c);
+        return b;
+    }
+    return g();
+}
+var r = f();
+`
+	const count, value, result = 0, 1, 2
+	lines := strings.Split(script, "\n")
+	named := map[int][]sourceMapping{
+		2: {{strings.Index(lines[1], "c"), 2, 8, count}},
+		4: {{strings.Index(lines[3], "(b)") + 1, 4, 19, value}},
+		5: {{strings.Index(lines[4], "b"), 5, 12, result}},
+	}
+	var mappings [][]sourceMapping
+	for i := 1; i < len(lines); i++ {
+		mappings = append(mappings, append([]sourceMapping{{0, i, 0, -1}}, named[i]...))
+	}
+	sm := inlineSourceMap("src/app.js", []string{"count", "value", "result"}, mappings)
+	prg, err := CompileForDebugging("dist/app.js", script+sm, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+	debugger.AddBreakpoint("dist/app.js", 6, 0)
+
+	paused := false
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		paused = true
+		scopes := state.DebugStack[0].Scopes
+		if len(scopes) < 2 {
+			t.Fatalf("Expected a local and a closure scope, got %+v", scopes)
+		}
+		local := debugger.GetVariables(scopes[0].VariablesRef)
+		if v := findVariable(local, "result"); v == nil || v.GeneratedName != "b" {
+			t.Errorf("Expected b to be reported as result, got %+v", local)
+		}
+		if findVariable(local, "value") != nil {
+			t.Errorf("b is reported under the name of the parameter of a nested function: %+v", local)
+		}
+		closure := debugger.GetVariables(scopes[1].VariablesRef)
+		if v := findVariable(closure, "count"); v == nil || v.GeneratedName != "c" {
+			t.Errorf("Expected c to be reported as count, got %+v", closure)
+		}
+
+		if _, err := debugger.SetVariable(scopes[0].VariablesRef, "result", "10"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.SetVariable(scopes[1].VariablesRef, "count", "20"); err != nil {
+			t.Fatal(err)
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunProgram(prg); err != nil {
+		t.Fatal(err)
+	}
+	if !paused {
+		t.Fatal("The breakpoint was not hit")
+	}
+	if r := vm.Get("r").ToInteger(); r != 10 {
+		t.Errorf("Expected 10, got %d", r)
+	}
+	if c := vm.Get("c"); c != nil {
+		t.Errorf("count was set as a global: %v", c)
+	}
+}
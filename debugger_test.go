@@ -160,7 +160,7 @@ func TestDebuggerStepOver(t *testing.T) {
 			return DebugContinue
 		}
 
-		if state.SourcePos.Line == 8 { // At the function call line
+		if state.SourcePos.Line == 9 { // At the function call line
 			return DebugStepOver
 		}
 
@@ -198,9 +198,9 @@ func TestDebuggerMultipleBreakpoints(t *testing.T) {
 	const SCRIPT = `
 	var sum = 0;
 	for (var i = 0; i < 3; i++) {
-		sum += i; // line 3
+		sum += i; // line 4
 	}
-	var result = sum; // line 5
+	var result = sum; // line 6
 	`
 
 	vm := New()
@@ -217,15 +217,15 @@ func TestDebuggerMultipleBreakpoints(t *testing.T) {
 		}
 		mu.Unlock()
 
-		if state.SourcePos.Line == 5 {
+		if state.SourcePos.Line == 6 {
 			done <- true
 		}
 		return DebugContinue
 	})
 
 	// Add breakpoints
-	bp1 := debugger.AddBreakpoint("", 3, 0)
-	bp2 := debugger.AddBreakpoint("", 5, 0)
+	bp1 := debugger.AddBreakpoint("", 4, 0)
+	bp2 := debugger.AddBreakpoint("", 6, 0)
 
 	// Check that breakpoints were added
 	bps := debugger.GetBreakpoints()
@@ -250,7 +250,7 @@ func TestDebuggerMultipleBreakpoints(t *testing.T) {
 	mu.Lock()
 	defer mu.Unlock()
 
-	// Should hit line 3 three times (loop) and line 5 once
+	// Should hit line 4 three times (loop) and line 6 once
 	if len(breakpointHits) != 4 {
 		t.Errorf("Expected 4 breakpoint hits, got %d", len(breakpointHits))
 	}
@@ -264,6 +264,50 @@ func TestDebuggerMultipleBreakpoints(t *testing.T) {
 	}
 }
 
+func TestDebuggerSourcePosition(t *testing.T) {
+	const SCRIPT = `var a = [1, 2, 3].map(function(x) { return x * 2; });
+var b = a.length;
+var c = b + 1;`
+
+	prg := MustCompile("test.js", SCRIPT, false)
+
+	// Every instruction takes its position from the last srcMap entry at or before it
+	for i, item := range prg.srcMap {
+		end := len(prg.code)
+		if i+1 < len(prg.srcMap) {
+			end = prg.srcMap[i+1].pc
+		}
+		want := prg.src.Position(item.srcPos)
+		for pc := item.pc; pc < end; pc++ {
+			if pos := sourcePosition(prg, pc); pos.Line != want.Line || pos.Column != want.Column {
+				t.Fatalf("pc %d: expected %d:%d, got %d:%d", pc, want.Line, want.Column, pos.Line, pos.Column)
+			}
+		}
+	}
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	var hits []int
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.Breakpoint != nil {
+			hits = append(hits, state.SourcePos.Line)
+		}
+		return DebugContinue
+	})
+
+	debugger.AddBreakpoint("test.js", 2, 0)
+	debugger.AddBreakpoint("test.js", 3, 0)
+
+	if _, err := vm.RunProgram(prg); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hits) != 2 || hits[0] != 2 || hits[1] != 3 {
+		t.Fatalf("Expected breakpoints to be hit on lines [2 3], got %v", hits)
+	}
+}
+
 func TestDebuggerPauseResume(t *testing.T) {
 	const SCRIPT = `
 	var count = 0;
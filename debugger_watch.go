@@ -0,0 +1,337 @@
+package goja
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dop251/goja/unistring"
+)
+
+// Watch is a watch expression, re-evaluated every time execution pauses
+type Watch struct {
+	ID         int
+	Expression string
+}
+
+// WatchResult is the value of a watch expression at a pause
+type WatchResult struct {
+	Watch
+	Value Value
+	Ref   int   // Reference ID for objects, see GetVariables
+	Err   error // Error thrown by the expression, Value is nil if set
+}
+
+// DataBreakpoint pauses execution when an object property or a variable is written
+type DataBreakpoint struct {
+	id   int
+	name unistring.String
+
+	// Exactly one of these is set
+	obj   *Object
+	stash *stash
+
+	value Value // Value at the last write
+	hit   int
+}
+
+// DataChange describes the change that caused a pause on a data breakpoint
+type DataChange struct {
+	Breakpoint *DataBreakpoint
+	OldValue   Value
+	NewValue   Value
+}
+
+// ID returns the data breakpoint ID
+func (b *DataBreakpoint) ID() int {
+	return b.id
+}
+
+// Name returns the name of the watched property or variable
+func (b *DataBreakpoint) Name() string {
+	return b.name.String()
+}
+
+// HitCount returns the number of times the data breakpoint was hit
+func (b *DataBreakpoint) HitCount() int {
+	return b.hit
+}
+
+// isProp returns true if the breakpoint watches the property name of obj
+func (b *DataBreakpoint) isProp(obj *Object, name unistring.String) bool {
+	return b.obj == obj && b.name == name
+}
+
+// isVar returns true if the breakpoint watches the variable at idx in the values of a stash
+func (b *DataBreakpoint) isVar(values *[]Value, idx int) bool {
+	if b.stash == nil || &b.stash.values != values {
+		return false
+	}
+	i, exists := b.stash.names[b.name]
+	return exists && int(i&^maskTyp) == idx
+}
+
+// current returns the current value of the watched location. Accessor properties and bindings
+// that have been removed have no value.
+// Must be called with the write lock held.
+func (b *DataBreakpoint) current() Value {
+	if b.stash != nil {
+		if idx, exists := b.stash.names[b.name]; exists {
+			if idx &^= maskTyp; int(idx) < len(b.stash.values) {
+				return b.stash.values[idx]
+			}
+		}
+		return nil
+	}
+	v := b.obj.self.getOwnPropStr(b.name)
+	if prop, ok := v.(*valueProperty); ok {
+		if prop.accessor {
+			return nil
+		}
+		return prop.value
+	}
+	return v
+}
+
+func sameValue(a, b Value) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.SameAs(b)
+}
+
+// AddWatch registers a watch expression. Watches are evaluated in the scope of the paused frame every
+// time execution pauses in JS code and reported in DebuggerState.Watches.
+func (d *Debugger) AddWatch(expression string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	w := &Watch{ID: d.nextID, Expression: expression}
+	d.nextID++
+	d.watches = append(d.watches, w)
+	return w.ID
+}
+
+// RemoveWatch removes a watch expression by ID
+func (d *Debugger) RemoveWatch(id int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, w := range d.watches {
+		if w.ID == id {
+			d.watches = append(d.watches[:i], d.watches[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetWatches returns all watch expressions
+func (d *Debugger) GetWatches() []Watch {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make([]Watch, len(d.watches))
+	for i, w := range d.watches {
+		result[i] = *w
+	}
+	return result
+}
+
+// evalWatches evaluates the watch expressions in the current scope.
+// Must be called with inHandler set.
+func (d *Debugger) evalWatches(vm *vm) []WatchResult {
+	watches := d.GetWatches()
+	if len(watches) == 0 {
+		return nil
+	}
+	f := d.currentScope(vm)
+	results := make([]WatchResult, len(watches))
+	for i, w := range watches {
+		results[i].Watch = w
+		v, err := d.evalInScope(w.Expression, f)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Value = v
+		results[i].Ref = d.ValueRef(v)
+	}
+	return results
+}
+
+// AddDataBreakpoint adds a data breakpoint on a variable of a scope or a property of an object, as
+// returned by GetVariables: variablesRef is the reference the variable was listed under, and name its
+// name. Execution pauses after the instruction that writes or deletes the location, even if the value stays
+// the same. Changes made by the Go functions called from JS are reported when the function returns, but
+// only if the value has changed.
+// Variables held on the stack can't be watched unless the Runtime runs in debug mode.
+// Like GetVariables, it must be called while execution is paused.
+func (d *Debugger) AddDataBreakpoint(variablesRef int, name string) (int, error) {
+	d.mu.RLock()
+	refData, exists := d.variableRefs[variablesRef]
+	d.mu.RUnlock()
+	if !exists {
+		return -1, fmt.Errorf("invalid variables reference: %d", variablesRef)
+	}
+
+	key := unistring.NewFromString(name)
+	switch data := refData.(type) {
+	case *Object:
+		return d.AddPropertyDataBreakpoint(data, name)
+	case *scopeRef:
+		global := &d.runtime.global.stash
+		key = d.generatedName(data, key)
+		switch data.typ {
+		case "local", "closure":
+			for s := data.stash; s != nil && s != global; s = s.outer {
+				if _, exists := s.names[key]; exists && s.obj == nil {
+					return d.addDataBreakpoint(&DataBreakpoint{name: key, stash: s}), nil
+				}
+				if data.typ == "local" && s.isVariable() {
+					break
+				}
+			}
+		case "global":
+			if _, exists := global.names[key]; exists {
+				return d.addDataBreakpoint(&DataBreakpoint{name: key, stash: global}), nil
+			}
+			return d.AddPropertyDataBreakpoint(d.runtime.globalObject, name)
+		}
+	}
+	return -1, fmt.Errorf("variable %q not found", name)
+}
+
+// AddPropertyDataBreakpoint adds a data breakpoint on an own data property of obj, which doesn't have to
+// exist yet. See AddDataBreakpoint.
+func (d *Debugger) AddPropertyDataBreakpoint(obj *Object, name string) (int, error) {
+	if _, ok := obj.self.(*proxyObject); ok {
+		return -1, errors.New("data breakpoints are not supported on proxies")
+	}
+	return d.addDataBreakpoint(&DataBreakpoint{name: unistring.NewFromString(name), obj: obj}), nil
+}
+
+func (d *Debugger) addDataBreakpoint(bp *DataBreakpoint) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bp.id = d.nextID
+	d.nextID++
+	bp.value = bp.current()
+	d.dataBreakpoints[bp.id] = bp
+	d.watchingData.Store(true)
+	return bp.id
+}
+
+// RemoveDataBreakpoint removes a data breakpoint by ID
+func (d *Debugger) RemoveDataBreakpoint(id int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bp, exists := d.dataBreakpoints[id]
+	if !exists {
+		return false
+	}
+	delete(d.dataBreakpoints, id)
+	if d.dataWrite == bp {
+		d.dataWrite = nil
+	}
+	d.watchingData.Store(len(d.dataBreakpoints) > 0)
+	return true
+}
+
+// GetDataBreakpoints returns all data breakpoints
+func (d *Debugger) GetDataBreakpoints() []*DataBreakpoint {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make([]*DataBreakpoint, 0, len(d.dataBreakpoints))
+	for _, bp := range d.dataBreakpoints {
+		result = append(result, bp)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+// notePropWrite is called by the VM once the code has written or deleted the property name of obj.
+func (d *Debugger) notePropWrite(obj *Object, name unistring.String) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, bp := range d.dataBreakpoints {
+		if bp.isProp(obj, name) {
+			d.noteDataWrite(bp)
+		}
+	}
+}
+
+// noteVarWrite is called by the VM once the code has written the variable at idx in the values of a stash.
+func (d *Debugger) noteVarWrite(values *[]Value, idx int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, bp := range d.dataBreakpoints {
+		if bp.isVar(values, idx) {
+			d.noteDataWrite(bp)
+		}
+	}
+}
+
+// noteGlobalWrite is called by the VM once the code has written the global variable name, which is either
+// a global lexical binding or a property of the global object.
+func (d *Debugger) noteGlobalWrite(name unistring.String) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	global := &d.runtime.global.stash
+	for _, bp := range d.dataBreakpoints {
+		if bp.name == name && (bp.stash == global || bp.obj == d.runtime.globalObject) {
+			d.noteDataWrite(bp)
+		}
+	}
+}
+
+// noteNativeWrites is called by the VM when a Go function called from JS returns. The Go code doesn't
+// report its writes, so the watched locations whose value has changed are treated as written.
+func (d *Debugger) noteNativeWrites() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, bp := range d.dataBreakpoints {
+		if !sameValue(bp.current(), bp.value) {
+			d.noteDataWrite(bp)
+		}
+	}
+}
+
+// noteDataWrite records a write to the location watched by bp, to be reported before the next instruction.
+// Writes made while paused (e.g. by an evaluation) are not reported.
+// Must be called with the write lock held.
+func (d *Debugger) noteDataWrite(bp *DataBreakpoint) {
+	if d.inHandler {
+		return
+	}
+	if d.dataWrite == nil || bp.id < d.dataWrite.id {
+		d.dataWrite = bp
+	}
+}
+
+// takeDataChange returns the change of the location written by the last instruction.
+// Must be called with the write lock held.
+func (d *Debugger) takeDataChange() *DataChange {
+	bp := d.dataWrite
+	d.dataWrite = nil
+	v := bp.current()
+	change := &DataChange{Breakpoint: bp, OldValue: bp.value, NewValue: v}
+	bp.value = v
+	bp.hit++
+	return change
+}
+
+// syncDataBreakpoints records the current values of the watched locations, so that changes made while
+// paused (e.g. by an evaluation) are not reported.
+// Must be called with the write lock held.
+func (d *Debugger) syncDataBreakpoints() {
+	for _, bp := range d.dataBreakpoints {
+		bp.value = bp.current()
+	}
+	d.dataWrite = nil
+}
@@ -0,0 +1,263 @@
+package goja
+
+import (
+	"testing"
+)
+
+func TestDebuggerWatches(t *testing.T) {
+	const SCRIPT = `
+	var x = 1;
+	debugger;
+	x = 5;
+	debugger;
+	x;
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+	debugger.AddWatch("x * 2")
+	missing := debugger.AddWatch("missing")
+	debugger.AddWatch("({x: x})")
+
+	var results [][]WatchResult
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		results = append(results, state.Watches)
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 pauses, got %d", len(results))
+	}
+	for i, expected := range []int64{2, 10} {
+		watches := results[i]
+		if len(watches) != 3 {
+			t.Fatalf("Expected 3 watches, got %v", watches)
+		}
+		if watches[0].Value == nil || watches[0].Value.ToInteger() != expected || watches[0].Expression != "x * 2" {
+			t.Errorf("Pause %d: unexpected watch result %+v", i, watches[0])
+		}
+		if watches[1].Err == nil || watches[1].Value != nil || watches[1].ID != missing {
+			t.Errorf("Pause %d: expected an error, got %+v", i, watches[1])
+		}
+		if watches[2].Ref == 0 {
+			t.Errorf("Pause %d: expected a reference for an object", i)
+		}
+	}
+
+	if !debugger.RemoveWatch(missing) || debugger.RemoveWatch(missing) {
+		t.Error("RemoveWatch failed")
+	}
+	if len(debugger.GetWatches()) != 2 {
+		t.Errorf("Expected 2 watches, got %v", debugger.GetWatches())
+	}
+}
+
+func TestDebuggerPropertyDataBreakpoint(t *testing.T) {
+	const SCRIPT = `
+	function mutate() {
+		config.level = 2;
+		return 0;
+	}
+	config.other = 5;
+	mutate();
+	config.level = 2;
+	Object.assign(config, {level: 3});
+	delete config.level;
+	`
+
+	vm := New()
+	config := vm.NewObject()
+	config.Set("level", 1)
+	vm.Set("config", config)
+
+	debugger := vm.EnableDebugger()
+	id, err := debugger.AddPropertyDataBreakpoint(config, "level")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		funcName string
+		old, new Value
+	}
+	var changes []change
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.DataChange == nil {
+			t.Fatalf("Unexpected pause at line %d", state.SourcePos.Line)
+		}
+		if state.DataChange.Breakpoint.ID() != id || state.DataChange.Breakpoint.Name() != "level" {
+			t.Errorf("Unexpected data breakpoint #%d", state.DataChange.Breakpoint.ID())
+		}
+		changes = append(changes, change{
+			funcName: state.CallStack[0].FuncName(),
+			old:      state.DataChange.OldValue,
+			new:      state.DataChange.NewValue,
+		})
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 changes, got %v", changes)
+	}
+	if c := changes[0]; c.funcName != "mutate" || c.old.ToInteger() != 1 || c.new.ToInteger() != 2 {
+		t.Errorf("Unexpected first change: %+v", c)
+	}
+	// A write is reported even if it doesn't change the value
+	if c := changes[1]; c.old.ToInteger() != 2 || c.new.ToInteger() != 2 {
+		t.Errorf("Unexpected second change: %+v", c)
+	}
+	if c := changes[2]; c.old.ToInteger() != 2 || c.new.ToInteger() != 3 {
+		t.Errorf("Unexpected third change: %+v", c)
+	}
+	if c := changes[3]; c.old.ToInteger() != 3 || c.new != nil {
+		t.Errorf("Unexpected fourth change: %+v", c)
+	}
+	if debugger.GetDataBreakpoints()[0].HitCount() != 4 {
+		t.Errorf("Expected 4 hits, got %d", debugger.GetDataBreakpoints()[0].HitCount())
+	}
+}
+
+func TestDebuggerSameValueDataBreakpoint(t *testing.T) {
+	const SCRIPT = `
+	var o = {x: 1};
+	debugger;
+	o.x = o.x;
+	o["x"] = 1;
+	[o.x] = [1];
+	o.y = 1;
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	hits := 0
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.DataChange != nil {
+			if c := state.DataChange; c.OldValue.ToInteger() != 1 || c.NewValue.ToInteger() != 1 {
+				t.Errorf("Unexpected change: %+v", c)
+			}
+			hits++
+			return DebugContinue
+		}
+		o, err := debugger.EvaluateInFrame("o", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := debugger.AddPropertyDataBreakpoint(o.(*Object), "x"); err != nil {
+			t.Fatal(err)
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 3 {
+		t.Errorf("Expected a pause after each of the 3 writes of x, got %d", hits)
+	}
+}
+
+func TestDebuggerVariableDataBreakpoint(t *testing.T) {
+	const SCRIPT = `
+	function makeCounter() {
+		var count = 0;
+		return function() { count++; return count; };
+	}
+	var inc = makeCounter();
+	inc();
+	inc();
+	var total = inc();
+	`
+
+	vm := NewWithOptions(RuntimeOptions{EnableDebugMode: true})
+	debugger := vm.EnableDebugger()
+	debugger.AddBreakpoint("", 4, 0)
+
+	var changes [][2]int64
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.Breakpoint != nil {
+			ref := state.DebugStack[0].Scopes[0].VariablesRef
+			if _, err := debugger.AddDataBreakpoint(ref, "nonexistent"); err == nil {
+				t.Error("Expected an error for a missing variable")
+			}
+			if _, err := debugger.AddDataBreakpoint(ref, "count"); err != nil {
+				t.Fatal(err)
+			}
+			return DebugContinue
+		}
+		if state.DataChange == nil {
+			t.Fatalf("Unexpected pause at line %d", state.SourcePos.Line)
+		}
+		changes = append(changes, [2]int64{state.DataChange.OldValue.ToInteger(), state.DataChange.NewValue.ToInteger()})
+		if len(changes) == 1 {
+			// Changes made while paused are not reported
+			if _, err := debugger.EvaluateInFrame("count = 10", 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return DebugContinue
+	})
+
+	res, err := vm.RunString(SCRIPT + "; total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ToInteger() != 12 {
+		t.Errorf("Expected 12, got %v", res)
+	}
+
+	expected := [][2]int64{{0, 1}, {10, 11}, {11, 12}}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, changes)
+			break
+		}
+	}
+}
+
+func TestDebuggerGlobalDataBreakpoint(t *testing.T) {
+	const SCRIPT = `
+	let lexical = 1;
+	var global = 1;
+	debugger;
+	global = 2;
+	lexical = 2;
+	`
+
+	vm := New()
+	debugger := vm.EnableDebugger()
+
+	var names []string
+	debugger.SetHandler(func(state *DebuggerState) DebugCommand {
+		if state.DataChange != nil {
+			names = append(names, state.DataChange.Breakpoint.Name())
+			return DebugContinue
+		}
+		scopes := state.DebugStack[0].Scopes
+		ref := scopes[len(scopes)-1].VariablesRef
+		for _, name := range []string{"lexical", "global"} {
+			if _, err := debugger.AddDataBreakpoint(ref, name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return DebugContinue
+	})
+
+	if _, err := vm.RunString(SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "global" || names[1] != "lexical" {
+		t.Errorf("Unexpected changes: %v", names)
+	}
+}
@@ -136,27 +136,13 @@ func (fl *File) SetSourceMap(m *sourcemap.Consumer) {
 	fl.sourceMap = m
 }
 
-func (fl *File) Position(offset int) Position {
-	var line int
-	var lineOffsets []int
-	fl.mu.Lock()
-	if offset > fl.lastScannedOffset {
-		line = fl.scanTo(offset)
-		lineOffsets = fl.lineOffsets
-		fl.mu.Unlock()
-	} else {
-		lineOffsets = fl.lineOffsets
-		fl.mu.Unlock()
-		line = sort.Search(len(lineOffsets), func(x int) bool { return lineOffsets[x] > offset }) - 1
-	}
-
-	var lineStart int
-	if line >= 0 {
-		lineStart = lineOffsets[line]
-	}
+// SourceMap returns the source map set with SetSourceMap, or nil
+func (fl *File) SourceMap() *sourcemap.Consumer {
+	return fl.sourceMap
+}
 
-	row := line + 2
-	col := offset - lineStart + 1
+func (fl *File) Position(offset int) Position {
+	row, col := fl.lineCol(offset)
 
 	if fl.sourceMap != nil {
 		if source, _, row, col, ok := fl.sourceMap.Source(row, col); ok {
@@ -181,6 +167,61 @@ func (fl *File) Position(offset int) Position {
 	}
 }
 
+// GeneratedPosition is like Position, but ignores the source map, so the position is always in the file itself.
+func (fl *File) GeneratedPosition(offset int) Position {
+	row, col := fl.lineCol(offset)
+	return Position{
+		Filename: fl.name,
+		Line:     row,
+		Column:   col,
+	}
+}
+
+// OriginalName returns the name the source map gives to the token that starts at offset, typically the
+// original name of an identifier renamed by a transpiler or a minifier. It returns "" if the file has no
+// source map or if no mapping with a name starts exactly at offset.
+func (fl *File) OriginalName(offset int) string {
+	if fl.sourceMap == nil {
+		return ""
+	}
+	row, col := fl.lineCol(offset)
+
+	// Generated columns are 0-based in source maps. The lookup falls back to the closest mapping on
+	// the left, so a mapping starts at offset only if the one for the previous column is different.
+	source, name, line, column, ok := fl.sourceMap.Source(row, col-1)
+	if !ok || name == "" {
+		return ""
+	}
+	if prevSource, prevName, prevLine, prevColumn, ok := fl.sourceMap.Source(row, col-2); ok &&
+		prevSource == source && prevName == name && prevLine == line && prevColumn == column {
+		return ""
+	}
+	return name
+}
+
+// lineCol returns the 1-based line and column of offset
+func (fl *File) lineCol(offset int) (int, int) {
+	var line int
+	var lineOffsets []int
+	fl.mu.Lock()
+	if offset > fl.lastScannedOffset {
+		line = fl.scanTo(offset)
+		lineOffsets = fl.lineOffsets
+		fl.mu.Unlock()
+	} else {
+		lineOffsets = fl.lineOffsets
+		fl.mu.Unlock()
+		line = sort.Search(len(lineOffsets), func(x int) bool { return lineOffsets[x] > offset }) - 1
+	}
+
+	var lineStart int
+	if line >= 0 {
+		lineStart = lineOffsets[line]
+	}
+
+	return line + 2, offset - lineStart + 1
+}
+
 func ResolveSourcemapURL(basename, source string) *url.URL {
 	// if the url is absolute(has scheme) there is nothing to do
 	smURL, err := url.Parse(filepath.ToSlash(strings.TrimSpace(source)))
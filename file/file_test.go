@@ -2,6 +2,8 @@ package file
 
 import (
 	"testing"
+
+	"github.com/go-sourcemap/sourcemap"
 )
 
 func TestPosition(t *testing.T) {
@@ -74,3 +76,22 @@ func TestGetSourceFilename(t *testing.T) {
 		}
 	}
 }
+
+func TestOriginalName(t *testing.T) {
+	const SRC = "var a = 1;"
+	sm, err := sourcemap.Parse("gen.js", []byte(`{"version":3,"sources":["orig.ts"],"names":["count"],"mappings":"AAAA,IAAIA"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := NewFile("gen.js", SRC, 0)
+	f.SetSourceMap(sm)
+
+	for offset, name := range map[int]string{0: "", 4: "count", 5: "", 8: ""} {
+		if n := f.OriginalName(offset); n != name {
+			t.Errorf("%d. Expected %q, got %q", offset, name, n)
+		}
+	}
+	if p := f.GeneratedPosition(4); p.Filename != "gen.js" || p.Line != 1 || p.Column != 5 {
+		t.Errorf("Unexpected generated position: %v", p)
+	}
+}
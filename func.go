@@ -34,6 +34,13 @@ var (
 	yieldEmpty       = &yieldMarker{resultType: resultYield}
 )
 
+// the value an async generator suspended at a yield is resumed with when it's resumed with a return
+// completion, see asyncGenResume
+type asyncGenReturn struct {
+	valueNull
+	value Value
+}
+
 // AsyncContextTracker is a handler that allows to track an async execution context to ensure it remains
 // consistent across all callback invocations.
 // Whenever a Promise reaction job is scheduled the Grab method is called. It is supposed to return the
@@ -85,6 +92,10 @@ type asyncFuncObject struct {
 	baseJsFuncObject
 }
 
+type asyncGeneratorFuncObject struct {
+	baseJsFuncObject
+}
+
 type classFuncObject struct {
 	baseJsFuncObject
 	initFields   *Program
@@ -109,6 +120,10 @@ type asyncMethodFuncObject struct {
 	methodFuncObject
 }
 
+type asyncGeneratorMethodFuncObject struct {
+	methodFuncObject
+}
+
 type arrowFuncObject struct {
 	baseJsFuncObject
 	funcObj   *Object
@@ -145,6 +160,7 @@ const (
 	genStateSuspendedYield
 	genStateSuspendedYieldRes
 	genStateCompleted
+	genStateAwaitingReturn
 )
 
 type generatorObject struct {
@@ -154,6 +170,28 @@ type generatorObject struct {
 	state     generatorState
 }
 
+type completionType uint8
+
+const (
+	completionNormal completionType = iota
+	completionReturn
+	completionThrow
+)
+
+type asyncGeneratorRequest struct {
+	completionType completionType
+	value          Value
+	promiseCap     *promiseCapability
+}
+
+type asyncGeneratorObject struct {
+	baseObject
+	gen       generator
+	queue     []asyncGeneratorRequest
+	delegated *iteratorRecord
+	state     generatorState
+}
+
 func (f *nativeFuncObject) source() String {
 	return newStringValue(fmt.Sprintf("function %s() { [native code] }", nilSafe(f.getStr("name", nil)).toString()))
 }
@@ -253,6 +291,11 @@ func (f *baseFuncObject) createInstance(newTarget *Object) *Object {
 	return f.val.runtime.newBaseObject(proto, classObject).val
 }
 
+// closureStash returns the stash the function was created in
+func (f *baseJsFuncObject) closureStash() *stash {
+	return f.stash
+}
+
 func (f *baseJsFuncObject) source() String {
 	return newStringValue(f.src)
 }
@@ -565,6 +608,9 @@ func (f *nativeFuncObject) assertCallable() (func(FunctionCall) Value, bool) {
 
 func (f *nativeFuncObject) vmCall(vm *vm, n int) {
 	if f.f != nil {
+		if m := vm.meter; m != nil && m.opts.Costs.NativeCall > 0 {
+			m.charge(vm, m.opts.Costs.NativeCall)
+		}
 		vm.pushCtx()
 		oldPrg := vm.prg
 		vm.prg = nil
@@ -589,6 +635,10 @@ func (f *nativeFuncObject) vmCall(vm *vm, n int) {
 		
 		// Restore program state
 		vm.prg = oldPrg
+
+		if d := vm.r.debugger; d != nil && d.watchingData.Load() {
+			d.noteNativeWrites()
+		}
 		
 		vm.popCtx()
 	} else {
@@ -627,7 +677,7 @@ func (f *baseJsFuncObject) prepareForVmCall(call FunctionCall) {
 func (f *baseJsFuncObject) asyncCall(call FunctionCall, vmCall func(*vm, int)) Value {
 	f.prepareForVmCall(call)
 	ar := &asyncRunner{
-		f:      f.val,
+		r:      f.val.runtime,
 		vmCall: vmCall,
 	}
 	ar.start(len(call.Arguments))
@@ -680,7 +730,7 @@ func (f *asyncMethodFuncObject) vmCall(vm *vm, n int) {
 
 func (f *baseJsFuncObject) asyncVmCall(vm *vm, n int, vmCall func(*vm, int)) {
 	ar := &asyncRunner{
-		f:      f.val,
+		r:      f.val.runtime,
 		vmCall: vmCall,
 	}
 	ar.start(n)
@@ -695,7 +745,7 @@ func (f *asyncFuncObject) vmCall(vm *vm, n int) {
 type asyncRunner struct {
 	gen        generator
 	promiseCap *promiseCapability
-	f          *Object
+	r          *Runtime
 	vmCall     func(*vm, int)
 }
 
@@ -722,7 +772,7 @@ func (ar *asyncRunner) onRejected(call FunctionCall) Value {
 }
 
 func (ar *asyncRunner) step(res Value, done bool, ex *Exception) {
-	r := ar.f.runtime
+	r := ar.r
 	if done || ex != nil {
 		if ex == nil {
 			ar.promiseCap.resolve(res)
@@ -746,7 +796,7 @@ func (ar *asyncRunner) step(res Value, done bool, ex *Exception) {
 }
 
 func (ar *asyncRunner) start(nArgs int) {
-	r := ar.f.runtime
+	r := ar.r
 	ar.gen.vm = r.vm
 	ar.promiseCap = r.newPromiseCapability(r.getPromise())
 	sp := r.vm.sp
@@ -840,25 +890,29 @@ func (g *generator) nextThrow(v interface{}) (Value, resultType, *Exception) {
 	return res, resType, ex
 }
 
-func (g *generatorObject) init(vmCall func(*vm, int), nArgs int) {
-	g.baseObject.init()
-	vm := g.val.runtime.vm
-	g.gen.vm = vm
+// init runs the generator function up to the initial yield, i.e. until the parameters are initialised.
+func (g *generator) init(vm *vm, vmCall func(*vm, int), nArgs int) {
+	g.vm = vm
 
-	g.gen.enter()
+	g.enter()
 	vmCall(vm, nArgs)
 
-	_, _, ex := g.gen.step()
+	_, _, ex := g.step()
 
 	vm.popTryFrame()
 	if ex != nil {
 		panic(ex)
 	}
 
-	g.state = genStateSuspendedStart
 	vm.popCtx()
 }
 
+func (g *generatorObject) init(vmCall func(*vm, int), nArgs int) {
+	g.baseObject.init()
+	g.gen.init(g.val.runtime.vm, vmCall, nArgs)
+	g.state = genStateSuspendedStart
+}
+
 func (g *generatorObject) validate() {
 	if g.state == genStateExecuting {
 		panic(g.val.runtime.NewTypeError("Illegal generator state"))
@@ -1125,3 +1179,380 @@ func (f *generatorMethodFuncObject) assertCallable() (func(FunctionCall) Value,
 func (f *generatorMethodFuncObject) export(*objectExportCtx) interface{} {
 	return f.Call
 }
+
+func (f *baseJsFuncObject) asyncGeneratorCall(vmCall func(*vm, int), nArgs int) Value {
+	o := &Object{runtime: f.val.runtime}
+
+	genObj := &asyncGeneratorObject{
+		baseObject: baseObject{
+			class:      classObject,
+			val:        o,
+			extensible: true,
+		},
+	}
+	o.self = genObj
+	genObj.init(vmCall, nArgs)
+	genObj.prototype = o.runtime.getPrototypeFromCtor(f.val, nil, o.runtime.getAsyncGeneratorPrototype())
+	return o
+}
+
+func (f *baseJsFuncObject) asyncGeneratorVmCall(vmCall func(*vm, int), nArgs int) {
+	vm := f.val.runtime.vm
+	vm.push(f.asyncGeneratorCall(vmCall, nArgs))
+	vm.pc++
+}
+
+func (f *asyncGeneratorFuncObject) vmCall(_ *vm, nArgs int) {
+	f.asyncGeneratorVmCall(f.baseJsFuncObject.vmCall, nArgs)
+}
+
+func (f *asyncGeneratorFuncObject) Call(call FunctionCall) Value {
+	f.prepareForVmCall(call)
+	return f.asyncGeneratorCall(f.baseJsFuncObject.vmCall, len(call.Arguments))
+}
+
+func (f *asyncGeneratorFuncObject) assertCallable() (func(FunctionCall) Value, bool) {
+	return f.Call, true
+}
+
+func (f *asyncGeneratorFuncObject) export(*objectExportCtx) interface{} {
+	return f.Call
+}
+
+func (f *asyncGeneratorFuncObject) assertConstructor() func(args []Value, newTarget *Object) *Object {
+	return nil
+}
+
+func (f *asyncGeneratorMethodFuncObject) vmCall(_ *vm, nArgs int) {
+	f.asyncGeneratorVmCall(f.methodFuncObject.vmCall, nArgs)
+}
+
+func (f *asyncGeneratorMethodFuncObject) Call(call FunctionCall) Value {
+	f.prepareForVmCall(call)
+	return f.asyncGeneratorCall(f.methodFuncObject.vmCall, len(call.Arguments))
+}
+
+func (f *asyncGeneratorMethodFuncObject) assertCallable() (func(FunctionCall) Value, bool) {
+	return f.Call, true
+}
+
+func (f *asyncGeneratorMethodFuncObject) export(*objectExportCtx) interface{} {
+	return f.Call
+}
+
+func (g *asyncGeneratorObject) init(vmCall func(*vm, int), nArgs int) {
+	g.baseObject.init()
+	g.gen.init(g.val.runtime.vm, vmCall, nArgs)
+	g.state = genStateSuspendedStart
+}
+
+// enqueue implements the next(), return() and throw() methods. The returned promise is settled when the
+// generator gets to the request.
+func (g *asyncGeneratorObject) enqueue(typ completionType, v Value) Value {
+	r := g.val.runtime
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	state := g.state
+	if state == genStateSuspendedStart && typ == completionThrow {
+		g.state = genStateCompleted
+		state = genStateCompleted
+	}
+	if state == genStateCompleted {
+		switch typ {
+		case completionNormal:
+			promiseCap.resolve(r.createIterResultObject(_undefined, true))
+			return promiseCap.promise
+		case completionThrow:
+			promiseCap.reject(v)
+			return promiseCap.promise
+		}
+	}
+	g.queue = append(g.queue, asyncGeneratorRequest{
+		completionType: typ,
+		value:          v,
+		promiseCap:     promiseCap,
+	})
+	switch state {
+	case genStateSuspendedStart:
+		if typ == completionReturn {
+			g.awaitReturn()
+		} else {
+			g.resume()
+		}
+	case genStateCompleted:
+		// only a return() can get here
+		g.awaitReturn()
+	case genStateSuspendedYield:
+		g.resume()
+	}
+	return promiseCap.promise
+}
+
+// resume resumes the generator with the completion of the first request in the queue.
+func (g *asyncGeneratorObject) resume() {
+	req := g.queue[0]
+	start := g.state == genStateSuspendedStart
+	g.state = genStateExecuting
+	if g.delegated != nil {
+		if req.completionType == completionReturn {
+			// the value is awaited before it's passed to the delegated iterator
+			g.await(req.value, func(v Value) {
+				g.delegateStep(completionReturn, v)
+			}, func(reason interface{}) {
+				g.delegateStep(completionThrow, g.val.runtime.vm.exceptionFromValue(reason).val)
+			})
+		} else {
+			g.delegateStep(req.completionType, req.value)
+		}
+		return
+	}
+	switch req.completionType {
+	case completionNormal:
+		v := req.value
+		if start {
+			v = nil
+		}
+		g.step(g.next(v))
+	case completionReturn:
+		g.step(g.next(&asyncGenReturn{value: req.value}))
+	default:
+		g.step(g.gen.nextThrow(req.value))
+	}
+}
+
+// next is like generator.next(), but in addition it closes the iterators that are left on the stack when
+// the generator returns from the middle of a destructuring assignment (i.e. from a yield in a default value).
+func (g *asyncGeneratorObject) next(v Value) (res Value, resType resultType, ex *Exception) {
+	vm := g.gen.vm
+	g.gen.enterNext()
+	if v != nil {
+		vm.push(v)
+	}
+	res, resType, ex = g.gen.step()
+	if ex == nil && resType == resultNormal {
+		ex = vm.restoreStacks(g.gen.iterStackLen, g.gen.refStackLen)
+	}
+	vm.popTryFrame()
+	vm.popCtx()
+	return
+}
+
+func (g *asyncGeneratorObject) step(res Value, resType resultType, ex *Exception) {
+	if ex != nil {
+		g.delegated = nil
+		g.state = genStateCompleted
+		g.rejectStep(ex.val)
+		g.drainQueue()
+		return
+	}
+	switch resType {
+	case resultAwait:
+		g.await(res, func(v Value) {
+			g.step(g.next(v))
+		}, g.throwInto)
+	case resultYieldRes:
+		g.resolveStep(res, false)
+		g.suspendOrResume()
+	case resultYieldDelegateRes:
+		r := g.val.runtime
+		var iter *iteratorRecord
+		if ex := r.vm.try(func() {
+			iter = r.getAsyncIterator(res)
+		}); ex != nil {
+			g.throwInto(ex)
+			return
+		}
+		g.delegated = iter
+		g.delegateStep(completionNormal, _undefined)
+	case resultNormal:
+		g.state = genStateCompleted
+		g.resolveStep(res, true)
+		g.drainQueue()
+	default:
+		panic(g.val.runtime.NewTypeError("Runtime bug: unexpected result type: %v", resType))
+	}
+}
+
+// throwInto resumes the generator by throwing v at the point where it's suspended.
+func (g *asyncGeneratorObject) throwInto(v interface{}) {
+	g.delegated = nil
+	g.step(g.gen.nextThrow(v))
+}
+
+// suspendOrResume is called after a yield: the generator continues with the next request if there is one.
+func (g *asyncGeneratorObject) suspendOrResume() {
+	if len(g.queue) > 0 {
+		g.resume()
+	} else {
+		g.state = genStateSuspendedYield
+	}
+}
+
+// await calls onFulfilled or onRejected (in a promise job) once v is settled.
+func (g *asyncGeneratorObject) await(v Value, onFulfilled func(Value), onRejected func(interface{})) {
+	r := g.val.runtime
+	var promise *Object
+	if ex := r.vm.try(func() {
+		promise = r.promiseResolve(r.getPromise(), v)
+	}); ex != nil {
+		onRejected(ex)
+		return
+	}
+	promise.self.(*Promise).addReactions(&promiseReaction{
+		typ: promiseReactionFulfill,
+		handler: &jobCallback{callback: func(call FunctionCall) Value {
+			onFulfilled(call.Argument(0))
+			return _undefined
+		}},
+	}, &promiseReaction{
+		typ: promiseReactionReject,
+		handler: &jobCallback{callback: func(call FunctionCall) Value {
+			onRejected(call.Argument(0))
+			return _undefined
+		}},
+	})
+}
+
+func (g *asyncGeneratorObject) shift() *promiseCapability {
+	promiseCap := g.queue[0].promiseCap
+	g.queue[0] = asyncGeneratorRequest{}
+	g.queue = g.queue[1:]
+	return promiseCap
+}
+
+func (g *asyncGeneratorObject) resolveStep(v Value, done bool) {
+	g.shift().resolve(g.val.runtime.createIterResultObject(v, done))
+}
+
+func (g *asyncGeneratorObject) rejectStep(reason Value) {
+	g.shift().reject(reason)
+}
+
+// drainQueue settles the requests made after the generator has completed.
+func (g *asyncGeneratorObject) drainQueue() {
+	for len(g.queue) > 0 {
+		req := &g.queue[0]
+		switch req.completionType {
+		case completionReturn:
+			g.awaitReturn()
+			return
+		case completionThrow:
+			g.rejectStep(req.value)
+		default:
+			g.resolveStep(_undefined, true)
+		}
+	}
+}
+
+// awaitReturn completes a return() request made when the generator is not running any code.
+func (g *asyncGeneratorObject) awaitReturn() {
+	g.state = genStateAwaitingReturn
+	g.await(g.queue[0].value, func(v Value) {
+		g.state = genStateCompleted
+		g.resolveStep(v, true)
+		g.drainQueue()
+	}, func(reason interface{}) {
+		g.state = genStateCompleted
+		g.rejectStep(g.val.runtime.vm.exceptionFromValue(reason).val)
+		g.drainQueue()
+	})
+}
+
+// delegateStep implements a step of yield* in an async generator: it calls the method of the delegated
+// iterator that corresponds to the completion and awaits the result.
+func (g *asyncGeneratorObject) delegateStep(typ completionType, v Value) {
+	r := g.val.runtime
+	iter := g.delegated
+	var method func(FunctionCall) Value
+	ex := r.vm.try(func() {
+		switch typ {
+		case completionNormal:
+			method = iter.next
+			if method == nil {
+				panic(r.NewTypeError("iterator.next is missing or not a function"))
+			}
+		case completionThrow:
+			method = toMethod(iter.iterator.self.getStr("throw", nil))
+		default:
+			method = toMethod(iter.iterator.self.getStr("return", nil))
+		}
+	})
+	if ex == nil && method == nil {
+		g.delegated = nil
+		if typ == completionReturn {
+			g.step(g.next(&asyncGenReturn{value: v}))
+		} else {
+			g.closeDelegated(iter)
+		}
+		return
+	}
+	var res Value
+	if ex == nil {
+		ex = r.vm.try(func() {
+			res = method(FunctionCall{This: iter.iterator, Arguments: []Value{v}})
+		})
+	}
+	if ex != nil {
+		g.throwInto(ex)
+		return
+	}
+	g.await(res, func(res Value) {
+		g.delegateResult(typ, res)
+	}, g.throwInto)
+}
+
+func (g *asyncGeneratorObject) delegateResult(typ completionType, res Value) {
+	r := g.val.runtime
+	var done bool
+	var value Value
+	if ex := r.vm.try(func() {
+		obj := r.toObject(res)
+		done = iteratorComplete(obj)
+		value = iteratorValue(obj)
+	}); ex != nil {
+		g.throwInto(ex)
+		return
+	}
+	if done {
+		g.delegated = nil
+		if typ == completionReturn {
+			g.step(g.next(&asyncGenReturn{value: value}))
+		} else {
+			g.step(g.next(value))
+		}
+		return
+	}
+	// unlike yield, yield* does not await the value
+	g.resolveStep(value, false)
+	g.suspendOrResume()
+}
+
+// closeDelegated is called when the delegated iterator does not have a 'throw' method. It closes the
+// iterator and throws a TypeError into the generator.
+func (g *asyncGeneratorObject) closeDelegated(iter *iteratorRecord) {
+	r := g.val.runtime
+	var res Value
+	hasReturn := false
+	if ex := r.vm.try(func() {
+		if method := toMethod(iter.iterator.self.getStr("return", nil)); method != nil {
+			hasReturn = true
+			res = method(FunctionCall{This: iter.iterator})
+		}
+	}); ex != nil {
+		g.throwInto(ex)
+		return
+	}
+	throwTypeError := func() {
+		g.throwInto(r.NewTypeError("The iterator does not provide a 'throw' method"))
+	}
+	if !hasReturn {
+		throwTypeError()
+		return
+	}
+	g.await(res, func(res Value) {
+		if _, ok := res.(*Object); !ok {
+			g.throwInto(r.NewTypeError("Value is not an object: %s", res.toString()))
+			return
+		}
+		throwTypeError()
+	}, g.throwInto)
+}
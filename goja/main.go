@@ -20,6 +20,8 @@ import (
 
 var cpuprofile = flag.String("cpuprofile", "", "write cpu profile to file")
 var timelimit = flag.Int("timelimit", 0, "max time to run (in seconds)")
+var gaslimit = flag.Uint64("gaslimit", 0, "max number of instructions to run")
+var memlimit = flag.Int64("memlimit", 0, "max memory to allocate (in bytes)")
 
 func readSource(filename string) ([]byte, error) {
 	if filename == "" || filename == "-" {
@@ -84,6 +86,13 @@ func run() error {
 		})
 	}
 
+	if *gaslimit > 0 {
+		vm.StartMetering(goja.MeteringOptions{Budget: *gaslimit})
+	}
+	if *memlimit > 0 {
+		vm.StartMemoryAccounting(*memlimit)
+	}
+
 	//log.Println("Compiling...")
 	prg, err := goja.Compile(filename, string(src), false)
 	if err != nil {
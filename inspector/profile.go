@@ -0,0 +1,80 @@
+package inspector
+
+import (
+	"time"
+
+	"github.com/google/pprof/profile"
+)
+
+type profileNodeKey struct {
+	parent       int
+	functionName string
+	url          string
+	line         int64
+}
+
+// convertProfile converts a profile produced by goja's sampling profiler (see goja.Runtime.StartProfile)
+// into the .cpuprofile format. The profiler aggregates identical stacks, so the samples are not in
+// chronological order: each stack is repeated as many times as it was sampled, the time it took split
+// evenly between them. scriptID returns the id of the script a file name was reported with.
+func convertProfile(p *profile.Profile, start, end time.Time, scriptID func(url string) string) *Profile {
+	cp := &Profile{
+		Nodes: []ProfileNode{{
+			ID:        1,
+			CallFrame: RuntimeCallFrame{FunctionName: "(root)", ScriptID: "0", LineNumber: -1, ColumnNumber: -1},
+		}},
+		StartTime:  start.UnixMicro(),
+		EndTime:    end.UnixMicro(),
+		Samples:    []int{},
+		TimeDeltas: []int64{},
+	}
+	ids := make(map[profileNodeKey]int)
+
+	for _, sample := range p.Sample {
+		if len(sample.Value) < 2 || sample.Value[0] <= 0 {
+			continue
+		}
+		node := 1
+		// Locations are ordered from the leaf to the root
+		for i := len(sample.Location) - 1; i >= 0; i-- {
+			loc := sample.Location[i]
+			if len(loc.Line) == 0 || loc.Line[0].Function == nil {
+				continue
+			}
+			line := loc.Line[0]
+			key := profileNodeKey{
+				parent:       node,
+				functionName: line.Function.Name,
+				url:          line.Function.Filename,
+				line:         line.Line,
+			}
+			id, exists := ids[key]
+			if !exists {
+				id = len(cp.Nodes) + 1
+				ids[key] = id
+				cp.Nodes = append(cp.Nodes, ProfileNode{
+					ID: id,
+					CallFrame: RuntimeCallFrame{
+						FunctionName: key.functionName,
+						ScriptID:     scriptID(key.url),
+						URL:          key.url,
+						LineNumber:   int(key.line) - 1,
+						ColumnNumber: -1,
+					},
+				})
+				parent := &cp.Nodes[node-1]
+				parent.Children = append(parent.Children, id)
+			}
+			node = id
+		}
+
+		count := sample.Value[0]
+		cp.Nodes[node-1].HitCount += int(count)
+		delta := time.Duration(sample.Value[1] / count).Microseconds()
+		for j := int64(0); j < count; j++ {
+			cp.Samples = append(cp.Samples, node)
+			cp.TimeDeltas = append(cp.TimeDeltas, delta)
+		}
+	}
+	return cp
+}
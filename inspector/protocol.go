@@ -0,0 +1,173 @@
+package inspector
+
+import "encoding/json"
+
+// The subset of the Chrome DevTools Protocol (https://chromedevtools.github.io/devtools-protocol/v8/)
+// the server implements. Lines and columns are 0-based, ids are strings.
+
+// Message is a command sent by the client, a response to it or an event sent by the server.
+type Message struct {
+	ID     int             `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result interface{}     `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is the error of a failed command.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error codes, as defined by JSON-RPC.
+const (
+	ErrCodeInvalidParams  = -32602
+	ErrCodeMethodNotFound = -32601
+	ErrCodeServerError    = -32000
+)
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Target is an entry of the /json list the clients discover the debuggable targets with.
+type Target struct {
+	Description          string `json:"description"`
+	DevtoolsFrontendURL  string `json:"devtoolsFrontendUrl"`
+	ID                   string `json:"id"`
+	Title                string `json:"title"`
+	Type                 string `json:"type"`
+	URL                  string `json:"url"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+// Version is the answer to /json/version.
+type Version struct {
+	Browser         string `json:"Browser"`
+	ProtocolVersion string `json:"Protocol-Version"`
+}
+
+// Location is a position in a script.
+type Location struct {
+	ScriptID     string `json:"scriptId"`
+	LineNumber   int    `json:"lineNumber"`
+	ColumnNumber int    `json:"columnNumber"`
+}
+
+// RemoteObject is a mirror of a JS value. Objects are referred to by their ObjectID, primitive values
+// are passed in Value or, for those JSON can't represent, in UnserializableValue.
+type RemoteObject struct {
+	Type                string          `json:"type"`
+	Subtype             string          `json:"subtype,omitempty"`
+	ClassName           string          `json:"className,omitempty"`
+	Value               json.RawMessage `json:"value,omitempty"`
+	UnserializableValue string          `json:"unserializableValue,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	ObjectID            string          `json:"objectId,omitempty"`
+}
+
+// CallArgument is a value given by the client, see RemoteObject.
+type CallArgument struct {
+	Value               json.RawMessage `json:"value,omitempty"`
+	UnserializableValue string          `json:"unserializableValue,omitempty"`
+	ObjectID            string          `json:"objectId,omitempty"`
+}
+
+// PropertyDescriptor is a property of an object or a variable of a scope.
+type PropertyDescriptor struct {
+	Name         string        `json:"name"`
+	Value        *RemoteObject `json:"value,omitempty"`
+	Writable     bool          `json:"writable"`
+	Configurable bool          `json:"configurable"`
+	Enumerable   bool          `json:"enumerable"`
+	IsOwn        bool          `json:"isOwn"`
+}
+
+// ExceptionDetails describes an exception thrown by evaluated code.
+type ExceptionDetails struct {
+	ExceptionID  int           `json:"exceptionId"`
+	Text         string        `json:"text"`
+	LineNumber   int           `json:"lineNumber"`
+	ColumnNumber int           `json:"columnNumber"`
+	Exception    *RemoteObject `json:"exception,omitempty"`
+}
+
+// Scope is a scope of a call frame, its variables are the properties of Object.
+type Scope struct {
+	Type   string       `json:"type"`
+	Object RemoteObject `json:"object"`
+	Name   string       `json:"name,omitempty"`
+}
+
+// CallFrame is a JS stack frame of a paused program.
+type CallFrame struct {
+	CallFrameID  string       `json:"callFrameId"`
+	FunctionName string       `json:"functionName"`
+	Location     Location     `json:"location"`
+	URL          string       `json:"url"`
+	ScopeChain   []Scope      `json:"scopeChain"`
+	This         RemoteObject `json:"this"`
+}
+
+// PausedEvent is the Debugger.paused event.
+type PausedEvent struct {
+	CallFrames     []CallFrame   `json:"callFrames"`
+	Reason         string        `json:"reason"`
+	Data           *RemoteObject `json:"data,omitempty"`
+	HitBreakpoints []string      `json:"hitBreakpoints,omitempty"`
+}
+
+// ScriptParsedEvent is the Debugger.scriptParsed event.
+type ScriptParsedEvent struct {
+	ScriptID           string `json:"scriptId"`
+	URL                string `json:"url"`
+	StartLine          int    `json:"startLine"`
+	StartColumn        int    `json:"startColumn"`
+	EndLine            int    `json:"endLine"`
+	EndColumn          int    `json:"endColumn"`
+	ExecutionContextID int    `json:"executionContextId"`
+	Hash               string `json:"hash"`
+	SourceMapURL       string `json:"sourceMapURL,omitempty"`
+	Length             int    `json:"length"`
+}
+
+// BreakpointResolvedEvent is the Debugger.breakpointResolved event.
+type BreakpointResolvedEvent struct {
+	BreakpointID string   `json:"breakpointId"`
+	Location     Location `json:"location"`
+}
+
+// ExecutionContextDescription describes the only execution context of a Runtime.
+type ExecutionContextDescription struct {
+	ID       int    `json:"id"`
+	Origin   string `json:"origin"`
+	Name     string `json:"name"`
+	UniqueID string `json:"uniqueId"`
+}
+
+// RuntimeCallFrame is a frame of a stack trace in the Profiler domain.
+type RuntimeCallFrame struct {
+	FunctionName string `json:"functionName"`
+	ScriptID     string `json:"scriptId"`
+	URL          string `json:"url"`
+	LineNumber   int    `json:"lineNumber"`
+	ColumnNumber int    `json:"columnNumber"`
+}
+
+// ProfileNode is a node of the call tree of a Profile.
+type ProfileNode struct {
+	ID        int              `json:"id"`
+	CallFrame RuntimeCallFrame `json:"callFrame"`
+	HitCount  int              `json:"hitCount"`
+	Children  []int            `json:"children,omitempty"`
+}
+
+// Profile is a CPU profile, the content of a .cpuprofile file. Times are in microseconds.
+type Profile struct {
+	Nodes      []ProfileNode `json:"nodes"`
+	StartTime  int64         `json:"startTime"`
+	EndTime    int64         `json:"endTime"`
+	Samples    []int         `json:"samples"`
+	TimeDeltas []int64       `json:"timeDeltas"`
+}
@@ -0,0 +1,1093 @@
+// Package inspector implements a Chrome DevTools Protocol (https://chromedevtools.github.io/devtools-protocol/)
+// server on top of goja's Debugger, so that a Runtime can be debugged and profiled from Chrome DevTools
+// (chrome://inspect) or any other CDP client.
+package inspector
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// contextID is the id of the only execution context a Runtime has.
+const contextID = 1
+
+var errNotPaused = &Error{Code: ErrCodeServerError, Message: "Can only perform operation while paused."}
+
+// noops are the methods the server accepts without doing anything, because there is nothing to do for
+// them in goja or because DevTools sends them whether they are supported or not.
+var noops = map[string]bool{
+	"Debugger.setAsyncCallStackDepth":         true,
+	"Debugger.setBlackboxPatterns":            true,
+	"Debugger.setBlackboxExecutionContexts":   true,
+	"Runtime.releaseObject":                   true,
+	"Runtime.releaseObjectGroup":              true,
+	"Runtime.discardConsoleEntries":           true,
+	"Runtime.setAsyncCallStackDepth":          true,
+	"Runtime.addBinding":                      true,
+	"Runtime.setCustomObjectFormatterEnabled": true,
+}
+
+// breakpoint is a breakpoint set by the client.
+type breakpoint struct {
+	id       int // The id of the goja breakpoint, which is also the protocol's id
+	url      string
+	line     int // 0-based
+	column   int // 0-based
+	resolved bool
+}
+
+// Server is a Chrome DevTools Protocol server for a single Runtime. It serves the endpoints clients
+// discover targets with (/json, /json/list and /json/version) and the WebSocket the protocol runs on,
+// accepting a single client at a time.
+//
+// The server only installs its handlers on the Runtime's Debugger, the embedder runs the scripts as usual.
+// Execution pauses at breakpoints while a client is connected and has enabled the Debugger domain.
+// Commands that inspect the program (Debugger.evaluateOnCallFrame, Runtime.evaluate, Runtime.getProperties,
+// ...) are only served while the program is paused. They are executed on the VM goroutine through a
+// goja.AsyncDebugger.
+//
+// Locations are reported in the code that runs, ignoring the source maps of the scripts: DevTools loads
+// the maps itself, from the sourceMappingURL of the scripts.
+//
+// A client can run arbitrary code in the process, so the server must not be reachable from untrusted
+// networks or web pages. Requests whose Host header is not "localhost" or an IP address are rejected
+// (this defeats DNS rebinding), and so are WebSocket upgrades coming from a web page, i.e. carrying an Origin
+// header other than the DevTools frontend's one, unless the origin is listed in AllowedOrigins.
+type Server struct {
+	// AllowedOrigins are the values of the Origin header, besides "devtools://devtools", WebSocket upgrades
+	// are accepted with. Upgrades without an Origin header (which browsers always send) are always accepted.
+	// Must not be modified while the server is serving.
+	AllowedOrigins []string
+
+	r   *goja.Runtime
+	dbg *goja.Debugger
+	id  string
+
+	mu              sync.Mutex
+	attached        bool
+	conn            *wsConn
+	async           *goja.AsyncDebugger
+	done            chan struct{}
+	debuggerEnabled bool
+	paused          bool
+	lastCmd         goja.DebugCommand
+
+	scripts     map[string]goja.Script // Scripts by id
+	scriptIDs   map[string]string      // Id of the latest script of each file name
+	reported    map[int]bool           // Scripts the client has been told about
+	breakpoints map[string]*breakpoint
+
+	profiler         *goja.Profiler
+	profileStart     time.Time
+	samplingInterval time.Duration
+}
+
+// NewServer creates a server for the Runtime. The Runtime's Debugger is enabled if it isn't already.
+// Locals held in registers are only visible if the Runtime was created in debug mode
+// (see RuntimeOptions.EnableDebugMode).
+func NewServer(r *goja.Runtime) *Server {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return &Server{
+		r:   r,
+		dbg: r.EnableDebugger(),
+		id:  hex.EncodeToString(id),
+	}
+}
+
+// ListenAndServe listens on the TCP network address addr (e.g. "127.0.0.1:9229", the port chrome://inspect
+// looks at by default) and serves the clients that connect to it. If addr has no host (e.g. ":9229") the server
+// listens on 127.0.0.1 only. Listening on other interfaces exposes the Runtime to anyone who can reach them.
+func (s *Server) ListenAndServe(addr string) error {
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		addr = net.JoinHostPort("127.0.0.1", port)
+	}
+	return http.ListenAndServe(addr, s)
+}
+
+// allowedHost returns true if the Host header of a request names the server by "localhost" or an IP address.
+// Any other name may have been rebound by a web page to the address of the server.
+func allowedHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	return net.ParseIP(host) != nil
+}
+
+// allowedOrigin returns true if a WebSocket upgrade with the Origin header origin may be accepted.
+func (s *Server) allowedOrigin(origin string) bool {
+	if origin == "" || origin == "devtools://devtools" {
+		return true
+	}
+	for _, o := range s.AllowedOrigins {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP serves the discovery endpoints and the WebSocket of the debugging sessions. The session of a
+// WebSocket lasts until the client disconnects.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowedHost(r.Host) {
+		http.Error(w, "Host header is not localhost or an IP address", http.StatusForbidden)
+		return
+	}
+	switch r.URL.Path {
+	case "/json", "/json/list":
+		addr := r.Host + "/" + s.id
+		writeJSON(w, []Target{{
+			Description:          "goja instance",
+			DevtoolsFrontendURL:  "devtools://devtools/bundled/js_app.html?experiments=true&v8only=true&ws=" + addr,
+			ID:                   s.id,
+			Title:                "goja",
+			Type:                 "node",
+			URL:                  "goja://",
+			WebSocketDebuggerURL: "ws://" + addr,
+		}})
+	case "/json/version":
+		writeJSON(w, Version{Browser: "goja", ProtocolVersion: "1.3"})
+	case "/" + s.id:
+		if !isUpgrade(r) {
+			http.Error(w, "WebSocket connection expected", http.StatusBadRequest)
+			return
+		}
+		if !s.allowedOrigin(r.Header.Get("Origin")) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		s.mu.Lock()
+		busy := s.attached
+		s.attached = true
+		s.mu.Unlock()
+		if busy {
+			http.Error(w, "another client is attached", http.StatusConflict)
+			return
+		}
+		conn, err := upgrade(w, r)
+		if err != nil {
+			s.mu.Lock()
+			s.attached = false
+			s.mu.Unlock()
+			return
+		}
+		s.serve(conn)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// serve runs a debugging session.
+func (s *Server) serve(conn *wsConn) {
+	s.attach(conn)
+	defer s.detach()
+
+	for {
+		b, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg Message
+		if err := json.Unmarshal(b, &msg); err != nil || msg.Method == "" {
+			continue
+		}
+		result, err := s.dispatch(&msg)
+		resp := &Message{ID: msg.ID}
+		if err != nil {
+			var perr *Error
+			if !errors.As(err, &perr) {
+				perr = &Error{Code: ErrCodeServerError, Message: err.Error()}
+			}
+			resp.Error = perr
+		} else {
+			if result == nil {
+				result = struct{}{}
+			}
+			resp.Result = result
+		}
+		s.send(resp)
+	}
+}
+
+func (s *Server) attach(conn *wsConn) {
+	s.mu.Lock()
+	s.conn = conn
+	s.done = make(chan struct{})
+	s.debuggerEnabled = false
+	s.paused = false
+	s.lastCmd = goja.DebugContinue
+	s.scripts = make(map[string]goja.Script)
+	s.scriptIDs = make(map[string]string)
+	s.reported = make(map[int]bool)
+	s.breakpoints = make(map[string]*breakpoint)
+	s.async = s.dbg.EnableAsync()
+	go s.watch(s.async, s.done)
+	s.mu.Unlock()
+
+	s.dbg.SetScriptHandler(s.onScript)
+	for _, script := range s.dbg.Scripts() {
+		s.addScript(script)
+	}
+}
+
+// detach removes the server's breakpoints and handlers, letting a paused program go on.
+func (s *Server) detach() {
+	s.dbg.SetScriptHandler(nil)
+	s.dbg.SetExceptionBreakMode(goja.ExceptionBreakNone)
+
+	s.mu.Lock()
+	for _, bp := range s.breakpoints {
+		s.dbg.RemoveBreakpoint(bp.id)
+	}
+	s.breakpoints = nil
+	profiler := s.profiler
+	s.profiler = nil
+	s.samplingInterval = 0
+	s.async.Close()
+	close(s.done)
+	s.conn.Close()
+	s.conn = nil
+	s.attached = false
+	s.mu.Unlock()
+
+	if profiler != nil {
+		profiler.Stop()
+	}
+}
+
+func (s *Server) send(msg *Message) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn != nil {
+		_ = conn.WriteMessage(b)
+	}
+}
+
+func (s *Server) sendEvent(method string, params interface{}) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+	s.send(&Message{Method: method, Params: b})
+}
+
+func unmarshalParams(msg *Message, params interface{}) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		return &Error{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("invalid parameters for %s: %v", msg.Method, err)}
+	}
+	return nil
+}
+
+// dispatch executes a command and returns its result.
+func (s *Server) dispatch(msg *Message) (interface{}, error) {
+	switch msg.Method {
+	case "Debugger.enable":
+		s.mu.Lock()
+		s.debuggerEnabled = true
+		s.mu.Unlock()
+		for _, script := range s.dbg.Scripts() {
+			s.addScript(script)
+			s.reportScript(script)
+		}
+		return map[string]string{"debuggerId": s.id}, nil
+	case "Debugger.disable":
+		s.mu.Lock()
+		s.debuggerEnabled = false
+		s.reported = make(map[int]bool)
+		s.mu.Unlock()
+		_ = s.resume(goja.DebugContinue)
+		return nil, nil
+	case "Debugger.getScriptSource":
+		var params struct {
+			ScriptID string `json:"scriptId"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		script, err := s.script(params.ScriptID)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"scriptSource": script.Source}, nil
+	case "Debugger.setBreakpointByUrl":
+		var params struct {
+			LineNumber   int    `json:"lineNumber"`
+			URL          string `json:"url"`
+			ColumnNumber int    `json:"columnNumber"`
+			Condition    string `json:"condition"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		if params.URL == "" {
+			return nil, &Error{Code: ErrCodeInvalidParams, Message: "only breakpoints by url are supported"}
+		}
+		bp, err := s.setBreakpoint(params.URL, params.LineNumber, params.ColumnNumber, params.Condition)
+		if err != nil {
+			return nil, err
+		}
+		locations := []Location{}
+		if bp.resolved {
+			locations = append(locations, s.location(bp))
+		}
+		return map[string]interface{}{"breakpointId": strconv.Itoa(bp.id), "locations": locations}, nil
+	case "Debugger.setBreakpoint":
+		var params struct {
+			Location  Location `json:"location"`
+			Condition string   `json:"condition"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		script, err := s.script(params.Location.ScriptID)
+		if err != nil {
+			return nil, err
+		}
+		bp, err := s.setBreakpoint(script.Filename, params.Location.LineNumber, params.Location.ColumnNumber, params.Condition)
+		if err != nil {
+			return nil, err
+		}
+		if !bp.resolved {
+			s.removeBreakpoint(strconv.Itoa(bp.id))
+			return nil, &Error{Code: ErrCodeServerError, Message: "Could not resolve breakpoint"}
+		}
+		return map[string]interface{}{"breakpointId": strconv.Itoa(bp.id), "actualLocation": s.location(bp)}, nil
+	case "Debugger.removeBreakpoint":
+		var params struct {
+			BreakpointID string `json:"breakpointId"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		s.removeBreakpoint(params.BreakpointID)
+		return nil, nil
+	case "Debugger.setPauseOnExceptions":
+		var params struct {
+			State string `json:"state"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		switch params.State {
+		case "none":
+			s.dbg.SetExceptionBreakMode(goja.ExceptionBreakNone)
+		case "uncaught":
+			s.dbg.SetExceptionBreakMode(goja.ExceptionBreakUncaught)
+		case "caught", "all":
+			s.dbg.SetExceptionBreakMode(goja.ExceptionBreakAll)
+		default:
+			return nil, &Error{Code: ErrCodeInvalidParams, Message: "unknown pause on exceptions state " + strconv.Quote(params.State)}
+		}
+		return nil, nil
+	case "Debugger.resume":
+		return nil, s.resume(goja.DebugContinue)
+	case "Debugger.stepOver":
+		return nil, s.resume(goja.DebugStepOver)
+	case "Debugger.stepInto":
+		return nil, s.resume(goja.DebugStepInto)
+	case "Debugger.stepOut":
+		return nil, s.resume(goja.DebugStepOut)
+	case "Debugger.pause":
+		s.dbg.Pause()
+		return nil, nil
+	case "Debugger.continueToLocation":
+		var params struct {
+			Location Location `json:"location"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		script, err := s.script(params.Location.ScriptID)
+		if err != nil {
+			return nil, err
+		}
+		var ferr error
+		err = s.onVM(func(*goja.DebuggerState) {
+			ferr = s.dbg.RunToLocation(script.Filename, params.Location.LineNumber+1)
+		})
+		if err == nil {
+			err = ferr
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.resume(goja.DebugContinue)
+	case "Debugger.restartFrame":
+		var params struct {
+			CallFrameID string `json:"callFrameId"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		idx, err := strconv.Atoi(params.CallFrameID)
+		if err != nil {
+			return nil, &Error{Code: ErrCodeInvalidParams, Message: "invalid call frame id"}
+		}
+		var ferr error
+		err = s.onVM(func(*goja.DebuggerState) {
+			ferr = s.dbg.RestartFrame(idx)
+		})
+		if err == nil {
+			err = ferr
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.resume(goja.DebugContinue)
+	case "Debugger.evaluateOnCallFrame":
+		var params struct {
+			CallFrameID string `json:"callFrameId"`
+			Expression  string `json:"expression"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		idx, err := strconv.Atoi(params.CallFrameID)
+		if err != nil {
+			return nil, &Error{Code: ErrCodeInvalidParams, Message: "invalid call frame id"}
+		}
+		return s.evaluate(params.Expression, idx)
+	case "Debugger.setVariableValue":
+		var params struct {
+			ScopeNumber  int          `json:"scopeNumber"`
+			VariableName string       `json:"variableName"`
+			NewValue     CallArgument `json:"newValue"`
+			CallFrameID  string       `json:"callFrameId"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.setVariable(params.CallFrameID, params.ScopeNumber, params.VariableName, &params.NewValue)
+	case "Runtime.enable":
+		s.sendEvent("Runtime.executionContextCreated", map[string]interface{}{
+			"context": ExecutionContextDescription{ID: contextID, Name: "goja", UniqueID: s.id},
+		})
+		return nil, nil
+	case "Runtime.disable", "Runtime.runIfWaitingForDebugger":
+		return nil, nil
+	case "Runtime.evaluate":
+		var params struct {
+			Expression string `json:"expression"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		// The Runtime is not safe for concurrent use, code can only run while it's paused
+		return s.evaluate(params.Expression, 0)
+	case "Runtime.getProperties":
+		var params struct {
+			ObjectID               string `json:"objectId"`
+			AccessorPropertiesOnly bool   `json:"accessorPropertiesOnly"`
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.getProperties(params.ObjectID, params.AccessorPropertiesOnly)
+	case "Profiler.enable":
+		return nil, nil
+	case "Profiler.disable":
+		s.mu.Lock()
+		profiler := s.profiler
+		s.profiler = nil
+		s.mu.Unlock()
+		if profiler != nil {
+			profiler.Stop()
+		}
+		return nil, nil
+	case "Profiler.setSamplingInterval":
+		var params struct {
+			Interval int `json:"interval"` // In microseconds
+		}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		if params.Interval <= 0 {
+			return nil, &Error{Code: ErrCodeInvalidParams, Message: "Invalid sample interval"}
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.profiler != nil {
+			return nil, &Error{Code: ErrCodeServerError, Message: "Cannot change sampling interval when profiling."}
+		}
+		s.samplingInterval = time.Duration(params.Interval) * time.Microsecond
+		return nil, nil
+	case "Profiler.start":
+		return nil, s.startProfile()
+	case "Profiler.stop":
+		p, err := s.stopProfile()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"profile": p}, nil
+	}
+	if noops[msg.Method] {
+		return nil, nil
+	}
+	return nil, &Error{Code: ErrCodeMethodNotFound, Message: fmt.Sprintf("'%s' wasn't found", msg.Method)}
+}
+
+// addScript records a script loaded by the Runtime.
+func (s *Server) addScript(script goja.Script) {
+	id := strconv.Itoa(script.ID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[id] = script
+	s.scriptIDs[script.Filename] = id
+}
+
+func (s *Server) script(id string) (goja.Script, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	script, exists := s.scripts[id]
+	if !exists {
+		return goja.Script{}, &Error{Code: ErrCodeInvalidParams, Message: "No script for id: " + id}
+	}
+	return script, nil
+}
+
+func (s *Server) scriptID(filename string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, exists := s.scriptIDs[filename]; exists {
+		return id
+	}
+	return "0"
+}
+
+// onScript is the Debugger's script handler. It runs on the VM goroutine, before the script's code.
+func (s *Server) onScript(script goja.Script) {
+	s.addScript(script)
+	s.mu.Lock()
+	enabled := s.debuggerEnabled
+	var pending []*breakpoint
+	for _, bp := range s.breakpoints {
+		if bp.url == script.Filename && !bp.resolved {
+			pending = append(pending, bp)
+		}
+	}
+	s.mu.Unlock()
+	if !enabled {
+		return
+	}
+	s.reportScript(script)
+
+	for _, bp := range pending {
+		if !s.resolved(bp.id) {
+			continue
+		}
+		s.mu.Lock()
+		bp.resolved = true
+		s.mu.Unlock()
+		s.sendEvent("Debugger.breakpointResolved", BreakpointResolvedEvent{
+			BreakpointID: strconv.Itoa(bp.id),
+			Location:     s.location(bp),
+		})
+	}
+}
+
+// reportScript sends the Debugger.scriptParsed event of a script unless it's already been sent.
+func (s *Server) reportScript(script goja.Script) {
+	s.mu.Lock()
+	reported := s.reported[script.ID]
+	s.reported[script.ID] = true
+	s.mu.Unlock()
+	if reported {
+		return
+	}
+
+	src := script.Source
+	lastLine := strings.LastIndexByte(src, '\n') + 1
+	hash := sha1.Sum([]byte(src))
+	s.sendEvent("Debugger.scriptParsed", ScriptParsedEvent{
+		ScriptID:           strconv.Itoa(script.ID),
+		URL:                script.Filename,
+		EndLine:            strings.Count(src, "\n"),
+		EndColumn:          len(src) - lastLine,
+		ExecutionContextID: contextID,
+		Hash:               hex.EncodeToString(hash[:]),
+		SourceMapURL:       sourceMapURL(src),
+		Length:             len(src),
+	})
+}
+
+// sourceMapURL returns the URL given by the sourceMappingURL comment of a script, if it has one.
+func sourceMapURL(src string) string {
+	for _, prefix := range []string{"//# sourceMappingURL=", "//@ sourceMappingURL="} {
+		if i := strings.LastIndex(src, prefix); i >= 0 {
+			url := src[i+len(prefix):]
+			if j := strings.IndexAny(url, "\r\n"); j >= 0 {
+				url = url[:j]
+			}
+			return strings.TrimSpace(url)
+		}
+	}
+	return ""
+}
+
+func (s *Server) setBreakpoint(url string, line, column int, condition string) (*breakpoint, error) {
+	col := 0
+	if column > 0 {
+		col = column + 1
+	}
+	id, err := s.dbg.AddBreakpointWithOptions(url, line+1, col, goja.BreakpointOptions{Condition: condition})
+	if err != nil {
+		return nil, &Error{Code: ErrCodeInvalidParams, Message: err.Error()}
+	}
+	bp := &breakpoint{id: id, url: url, line: line, column: column, resolved: s.resolved(id)}
+	s.mu.Lock()
+	s.breakpoints[strconv.Itoa(id)] = bp
+	s.mu.Unlock()
+	return bp, nil
+}
+
+func (s *Server) removeBreakpoint(id string) {
+	s.mu.Lock()
+	bp := s.breakpoints[id]
+	delete(s.breakpoints, id)
+	s.mu.Unlock()
+	if bp != nil {
+		s.dbg.RemoveBreakpoint(bp.id)
+	}
+}
+
+func (s *Server) resolved(id int) bool {
+	for _, bp := range s.dbg.GetBreakpoints() {
+		if bp.ID() == id {
+			return bp.Resolved()
+		}
+	}
+	return false
+}
+
+func (s *Server) location(bp *breakpoint) Location {
+	return Location{ScriptID: s.scriptID(bp.url), LineNumber: bp.line, ColumnNumber: bp.column}
+}
+
+// watch receives the pauses of the program until the session ends.
+func (s *Server) watch(a *goja.AsyncDebugger, done chan struct{}) {
+	for {
+		select {
+		case state := <-a.Events():
+			s.onPause(a, state)
+		case <-done:
+			return
+		}
+	}
+}
+
+// onPause reports a pause to the client. Pauses where there is nothing to show, such as native calls,
+// are stepped through, and the program doesn't pause at all if the Debugger domain is disabled.
+func (s *Server) onPause(a *goja.AsyncDebugger, state *goja.DebuggerState) {
+	s.mu.Lock()
+	enabled, lastCmd := s.debuggerEnabled, s.lastCmd
+	s.mu.Unlock()
+
+	if !enabled {
+		_ = a.Resume(goja.DebugContinue)
+		return
+	}
+	if state.InNativeCall && !s.dbg.ShouldStepInNativeCall() || state.SourcePos.Line == 0 {
+		// Keep going until the next line of JS code
+		if lastCmd == goja.DebugContinue {
+			lastCmd = goja.DebugStepInto
+		}
+		_ = a.Resume(lastCmd)
+		return
+	}
+
+	var event PausedEvent
+	if err := a.Do(func(state *goja.DebuggerState) {
+		event = s.pausedEvent(state)
+	}); err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.paused = true
+	s.mu.Unlock()
+	s.sendEvent("Debugger.paused", event)
+}
+
+// Must be called on the VM goroutine.
+func (s *Server) pausedEvent(state *goja.DebuggerState) PausedEvent {
+	event := PausedEvent{
+		CallFrames: []CallFrame{},
+		Reason:     "other",
+	}
+	if state.Exception != nil {
+		event.Reason = "exception"
+		data := s.remoteObject(state.Exception.Value())
+		event.Data = &data
+	}
+	if state.Breakpoint != nil {
+		event.HitBreakpoints = []string{strconv.Itoa(state.Breakpoint.ID())}
+	}
+
+	for i := range state.DebugStack {
+		frame := &state.DebugStack[i]
+		if frame.SrcName() == "<native>" {
+			continue
+		}
+		pos := frame.GeneratedPosition()
+		column := pos.Column - 1
+		if column < 0 {
+			column = 0
+		}
+		cf := CallFrame{
+			// The id is the index in the stack, which includes the native frames
+			CallFrameID:  strconv.Itoa(i),
+			FunctionName: frame.FuncName(),
+			Location:     Location{ScriptID: s.scriptID(pos.Filename), LineNumber: pos.Line - 1, ColumnNumber: column},
+			URL:          pos.Filename,
+			ScopeChain:   make([]Scope, 0, len(frame.Scopes)),
+			This:         RemoteObject{Type: "undefined"},
+		}
+		if cf.FunctionName == "<anonymous>" {
+			cf.FunctionName = ""
+		}
+		if frame.This != nil {
+			cf.This = s.remoteObject(frame.This.Value)
+		}
+		for _, scope := range frame.Scopes {
+			cf.ScopeChain = append(cf.ScopeChain, Scope{
+				Type: scopeType(scope.Name),
+				Name: scope.Name,
+				Object: RemoteObject{
+					Type:        "object",
+					ClassName:   "Object",
+					Description: "Object",
+					ObjectID:    strconv.Itoa(scope.VariablesRef),
+				},
+			})
+		}
+		event.CallFrames = append(event.CallFrames, cf)
+	}
+	return event
+}
+
+func scopeType(name string) string {
+	switch name {
+	case "Local":
+		return "local"
+	case "Closure":
+		return "closure"
+	case "Global":
+		return "global"
+	case "Exception":
+		return "catch"
+	}
+	return "block"
+}
+
+// onVM runs f on the VM goroutine while the program is paused.
+func (s *Server) onVM(f func(state *goja.DebuggerState)) error {
+	s.mu.Lock()
+	paused := s.paused
+	s.mu.Unlock()
+	if !paused {
+		return errNotPaused
+	}
+	if err := s.async.Do(f); err != nil {
+		return errNotPaused
+	}
+	return nil
+}
+
+// resume ends the pause with a command.
+func (s *Server) resume(cmd goja.DebugCommand) error {
+	s.mu.Lock()
+	paused := s.paused
+	s.paused = false
+	s.lastCmd = cmd
+	s.mu.Unlock()
+	if !paused {
+		return errNotPaused
+	}
+	s.sendEvent("Debugger.resumed", struct{}{})
+	_ = s.async.Resume(cmd)
+	return nil
+}
+
+// evaluate runs an expression in the scope of a frame. Exceptions are part of the result.
+func (s *Server) evaluate(expression string, frameIndex int) (interface{}, error) {
+	var result map[string]interface{}
+	err := s.onVM(func(*goja.DebuggerState) {
+		v, err := s.dbg.EvaluateInFrame(expression, frameIndex)
+		if err == nil {
+			result = map[string]interface{}{"result": s.remoteObject(v)}
+			return
+		}
+		details := ExceptionDetails{ExceptionID: 1, Text: "Uncaught"}
+		var exception RemoteObject
+		var ex *goja.Exception
+		if errors.As(err, &ex) {
+			exception = s.remoteObject(ex.Value())
+		} else {
+			details.Text = err.Error()
+			exception = RemoteObject{Type: "object", Subtype: "error", ClassName: "Error", Description: err.Error()}
+		}
+		details.Exception = &exception
+		result = map[string]interface{}{"result": exception, "exceptionDetails": details}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Server) getProperties(objectID string, accessorsOnly bool) (interface{}, error) {
+	ref, err := strconv.Atoi(objectID)
+	if err != nil {
+		return nil, &Error{Code: ErrCodeInvalidParams, Message: "Invalid remote object id"}
+	}
+	props := []PropertyDescriptor{}
+	err = s.onVM(func(*goja.DebuggerState) {
+		if accessorsOnly {
+			return
+		}
+		for _, v := range s.dbg.GetVariables(ref) {
+			value := s.remoteObject(v.Value)
+			props = append(props, PropertyDescriptor{
+				Name:         v.Name,
+				Value:        &value,
+				Writable:     true,
+				Configurable: true,
+				Enumerable:   true,
+				IsOwn:        true,
+			})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"result": props}, nil
+}
+
+// setVariable assigns a variable of a scope listed in a Debugger.paused event.
+func (s *Server) setVariable(callFrameID string, scopeNumber int, name string, value *CallArgument) error {
+	idx, err := strconv.Atoi(callFrameID)
+	if err != nil {
+		return &Error{Code: ErrCodeInvalidParams, Message: "invalid call frame id"}
+	}
+	expr := "undefined"
+	switch {
+	case value.ObjectID != "":
+		return &Error{Code: ErrCodeInvalidParams, Message: "objects can't be assigned to variables"}
+	case value.UnserializableValue != "":
+		expr = value.UnserializableValue
+	case len(value.Value) > 0:
+		// JSON values are JS expressions
+		expr = string(value.Value)
+	}
+
+	var ferr error
+	err = s.onVM(func(state *goja.DebuggerState) {
+		if idx < 0 || idx >= len(state.DebugStack) || scopeNumber < 0 || scopeNumber >= len(state.DebugStack[idx].Scopes) {
+			ferr = &Error{Code: ErrCodeInvalidParams, Message: "invalid scope"}
+			return
+		}
+		_, ferr = s.dbg.SetVariable(state.DebugStack[idx].Scopes[scopeNumber].VariablesRef, name, expr)
+	})
+	if err == nil {
+		err = ferr
+	}
+	return err
+}
+
+func (s *Server) startProfile() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.profiler != nil {
+		return &Error{Code: ErrCodeServerError, Message: "the profiler is already started"}
+	}
+	p, err := s.r.StartProfile(goja.ProfileOptions{Interval: s.samplingInterval})
+	if err != nil {
+		return err
+	}
+	s.profiler = p
+	s.profileStart = time.Now()
+	return nil
+}
+
+func (s *Server) stopProfile() (*Profile, error) {
+	s.mu.Lock()
+	profiler := s.profiler
+	s.profiler = nil
+	start := s.profileStart
+	s.mu.Unlock()
+	if profiler == nil {
+		return nil, &Error{Code: ErrCodeServerError, Message: "the profiler is not started"}
+	}
+	p := profiler.Stop()
+	return convertProfile(p, start, time.Now(), s.scriptID), nil
+}
+
+// remoteObject returns the mirror of a value.
+// Must be called on the VM goroutine.
+func (s *Server) remoteObject(v goja.Value) RemoteObject {
+	switch {
+	case v == nil || goja.IsUndefined(v):
+		return RemoteObject{Type: "undefined"}
+	case goja.IsNull(v):
+		return RemoteObject{Type: "object", Subtype: "null", Value: json.RawMessage("null")}
+	}
+	switch v := v.(type) {
+	case *goja.Object:
+		return s.objectMirror(v)
+	case *goja.Symbol:
+		return RemoteObject{Type: "symbol", Description: v.String()}
+	}
+	switch e := v.Export().(type) {
+	case string:
+		return RemoteObject{Type: "string", Value: marshal(e)}
+	case bool:
+		return RemoteObject{Type: "boolean", Value: marshal(e), Description: v.String()}
+	case int64:
+		return RemoteObject{Type: "number", Value: marshal(e), Description: v.String()}
+	case float64:
+		if math.IsNaN(e) || math.IsInf(e, 0) || e == 0 && math.Signbit(e) {
+			desc := v.String()
+			if e == 0 {
+				desc = "-0"
+			}
+			return RemoteObject{Type: "number", UnserializableValue: desc, Description: desc}
+		}
+		return RemoteObject{Type: "number", Value: marshal(e), Description: v.String()}
+	case *big.Int:
+		desc := e.String() + "n"
+		return RemoteObject{Type: "bigint", UnserializableValue: desc, Description: desc}
+	}
+	return RemoteObject{Type: "object", Description: v.String()}
+}
+
+var subtypes = map[string]string{
+	"Array":             "array",
+	"RegExp":            "regexp",
+	"Date":              "date",
+	"Map":               "map",
+	"Set":               "set",
+	"WeakMap":           "weakmap",
+	"WeakSet":           "weakset",
+	"Promise":           "promise",
+	"Proxy":             "proxy",
+	"ArrayBuffer":       "arraybuffer",
+	"DataView":          "dataview",
+	"Int8Array":         "typedarray",
+	"Uint8Array":        "typedarray",
+	"Uint8ClampedArray": "typedarray",
+	"Int16Array":        "typedarray",
+	"Uint16Array":       "typedarray",
+	"Int32Array":        "typedarray",
+	"Uint32Array":       "typedarray",
+	"Float32Array":      "typedarray",
+	"Float64Array":      "typedarray",
+	"BigInt64Array":     "typedarray",
+	"BigUint64Array":    "typedarray",
+}
+
+func (s *Server) objectMirror(o *goja.Object) RemoteObject {
+	className := o.ClassName()
+	ro := RemoteObject{
+		Type:        "object",
+		Subtype:     subtypes[className],
+		ClassName:   className,
+		Description: className,
+		ObjectID:    strconv.Itoa(s.dbg.ValueRef(o)),
+	}
+	if _, isFunc := goja.AssertFunction(o); isFunc {
+		ro.Type = "function"
+		ro.Subtype = ""
+		ro.ClassName = "Function"
+		ro.Description = "function " + propertyString(o, "name") + "()"
+		return ro
+	}
+	switch className {
+	case "Array":
+		ro.Description = "Array(" + propertyString(o, "length") + ")"
+	case "Error":
+		ro.Subtype = "error"
+		if stack := propertyString(o, "stack"); stack != "" {
+			ro.Description = stack
+		} else {
+			ro.Description = propertyString(o, "name") + ": " + propertyString(o, "message")
+		}
+	case "RegExp":
+		ro.Description = "/" + propertyString(o, "source") + "/" + propertyString(o, "flags")
+	case "Date":
+		if t, ok := o.Export().(time.Time); ok {
+			ro.Description = t.Format(time.RFC3339Nano)
+		}
+	case "Object":
+		if name := propertyString(property(o, "constructor"), "name"); name != "" {
+			ro.ClassName = name
+			ro.Description = name
+		}
+	}
+	return ro
+}
+
+// property returns a property of an object, nil if v is not an object or the getter of the property throws.
+func property(v goja.Value, name string) (p goja.Value) {
+	o, ok := v.(*goja.Object)
+	if !ok {
+		return nil
+	}
+	defer func() {
+		if recover() != nil {
+			p = nil
+		}
+	}()
+	return o.Get(name)
+}
+
+// propertyString returns a property of an object converted to a string, "" if it's undefined.
+func propertyString(v goja.Value, name string) (str string) {
+	p := property(v, name)
+	if p == nil || goja.IsUndefined(p) {
+		return ""
+	}
+	// toString() can throw too
+	defer func() {
+		if recover() != nil {
+			str = ""
+		}
+	}()
+	return p.String()
+}
+
+func marshal(v interface{}) json.RawMessage {
+	b, _ := json.Marshal(v)
+	return b
+}
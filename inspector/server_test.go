@@ -0,0 +1,586 @@
+package inspector
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+type client struct {
+	t      *testing.T
+	ws     *wsConn
+	seq    int
+	events []*Message
+}
+
+// dial opens a WebSocket to a URL as advertised by /json.
+func dial(t *testing.T, wsURL string) (*wsConn, *http.Response) {
+	t.Helper()
+	addr := strings.TrimPrefix(wsURL, "ws://")
+	host, path, _ := strings.Cut(addr, "/")
+	conn, err := net.Dial("tcp", host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := make([]byte, 16)
+	_, _ = rand.Read(key)
+	encodedKey := base64.StdEncoding.EncodeToString(key)
+	fmt.Fprintf(conn, "GET /%s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n", path, host, encodedKey)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+		return nil, resp
+	}
+	if accept := resp.Header.Get("Sec-WebSocket-Accept"); accept != acceptKey(encodedKey) {
+		t.Fatalf("wrong accept key %q", accept)
+	}
+	return &wsConn{conn: conn, br: br, client: true}, resp
+}
+
+func targets(t *testing.T, srv *httptest.Server) []Target {
+	t.Helper()
+	resp, err := http.Get(srv.URL + "/json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var list []Target
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+func newClient(t *testing.T, srv *httptest.Server) *client {
+	t.Helper()
+	list := targets(t, srv)
+	if len(list) != 1 {
+		t.Fatalf("expected one target, got %v", list)
+	}
+	ws, resp := dial(t, list[0].WebSocketDebuggerURL)
+	if ws == nil {
+		t.Fatalf("unexpected response %s", resp.Status)
+	}
+	return &client{t: t, ws: ws}
+}
+
+func (c *client) read() *Message {
+	c.t.Helper()
+	_ = c.ws.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	b, err := c.ws.ReadMessage()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	var msg Message
+	if err := json.Unmarshal(b, &msg); err != nil {
+		c.t.Fatal(err)
+	}
+	return &msg
+}
+
+// call sends a command and returns its response, events received meanwhile are queued.
+func (c *client) call(method string, params interface{}) *Message {
+	c.t.Helper()
+	c.seq++
+	msg := map[string]interface{}{"id": c.seq, "method": method}
+	if params != nil {
+		msg["params"] = params
+	}
+	b, _ := json.Marshal(msg)
+	if err := c.ws.WriteMessage(b); err != nil {
+		c.t.Fatal(err)
+	}
+	for {
+		m := c.read()
+		if m.Method != "" {
+			c.events = append(c.events, m)
+			continue
+		}
+		if m.ID == c.seq {
+			return m
+		}
+	}
+}
+
+// mustCall sends a command and returns its result, which is decoded into a generic map.
+func (c *client) mustCall(method string, params interface{}) map[string]interface{} {
+	c.t.Helper()
+	resp := c.call(method, params)
+	if resp.Error != nil {
+		c.t.Fatalf("%s failed: %s", method, resp.Error.Message)
+	}
+	var result map[string]interface{}
+	b, _ := json.Marshal(resp.Result)
+	_ = json.Unmarshal(b, &result)
+	return result
+}
+
+func (c *client) expectEvent(method string) map[string]interface{} {
+	c.t.Helper()
+	for {
+		var m *Message
+		if len(c.events) > 0 {
+			m, c.events = c.events[0], c.events[1:]
+		} else {
+			m = c.read()
+		}
+		if m.Method == method {
+			var params map[string]interface{}
+			_ = json.Unmarshal(m.Params, &params)
+			return params
+		}
+	}
+}
+
+func (c *client) close() {
+	c.ws.Close()
+}
+
+func runAsync(r *goja.Runtime, name, src string) chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.RunScript(name, src)
+		done <- err
+	}()
+	return done
+}
+
+func waitDone(t *testing.T, done chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the script didn't finish")
+	}
+}
+
+func newTestServer() (*goja.Runtime, *httptest.Server) {
+	r := goja.NewWithOptions(goja.RuntimeOptions{EnableDebugMode: true})
+	return r, httptest.NewServer(NewServer(r))
+}
+
+func TestDiscovery(t *testing.T) {
+	_, srv := newTestServer()
+	defer srv.Close()
+
+	list := targets(t, srv)
+	if len(list) != 1 || !strings.HasPrefix(list[0].WebSocketDebuggerURL, "ws://"+srv.Listener.Addr().String()+"/") {
+		t.Fatalf("unexpected targets %+v", list)
+	}
+	resp, err := http.Get(srv.URL + "/json/version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var v Version
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil || v.ProtocolVersion == "" {
+		t.Fatalf("unexpected version %+v, %v", v, err)
+	}
+
+	c := newClient(t, srv)
+	defer c.close()
+	c.mustCall("Runtime.enable", nil)
+	if ctx := c.expectEvent("Runtime.executionContextCreated")["context"].(map[string]interface{}); ctx["id"] != float64(contextID) {
+		t.Errorf("unexpected context %v", ctx)
+	}
+	// A single client at a time
+	if ws, resp := dial(t, list[0].WebSocketDebuggerURL); ws != nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("expected the second client to be rejected")
+	}
+	if resp := c.call("Debugger.unknownMethod", nil); resp.Error == nil || resp.Error.Code != ErrCodeMethodNotFound {
+		t.Errorf("expected an error for an unknown method, got %+v", resp)
+	}
+}
+
+func TestRebinding(t *testing.T) {
+	_, srv := newTestServer()
+	defer srv.Close()
+
+	for _, host := range []string{"attacker.example:9229", "localhost.attacker.example", "127.0.0.1.nip.io"} {
+		req, _ := http.NewRequest("GET", srv.URL+"/json", nil)
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %s", host, resp.Status)
+		}
+	}
+	for _, host := range []string{"localhost:9229", "[::1]:9229", "127.0.0.1"} {
+		req, _ := http.NewRequest("GET", srv.URL+"/json/version", nil)
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s: expected 200, got %s", host, resp.Status)
+		}
+	}
+
+	list := targets(t, srv)
+	req, _ := http.NewRequest("GET", srv.URL+"/"+list[0].ID, nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Origin", "http://attacker.example")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the upgrade from a web page to be rejected, got %s", resp.Status)
+	}
+}
+
+func TestBreakpoint(t *testing.T) {
+	const SCRIPT = `var total = 0;
+function add(a, b) {
+	var sum = a + b;
+	total += sum;
+	return sum;
+}
+add(1, 2);
+`
+	r, srv := newTestServer()
+	defer srv.Close()
+	c := newClient(t, srv)
+	defer c.close()
+
+	c.mustCall("Debugger.enable", nil)
+	bp := c.mustCall("Debugger.setBreakpointByUrl", map[string]interface{}{"url": "test.js", "lineNumber": 3})
+	if locations := bp["locations"].([]interface{}); len(locations) != 0 {
+		t.Errorf("the script is not loaded, got %v", locations)
+	}
+
+	done := runAsync(r, "test.js", SCRIPT)
+
+	script := c.expectEvent("Debugger.scriptParsed")
+	if script["url"] != "test.js" || script["endLine"] != float64(7) {
+		t.Errorf("unexpected script %v", script)
+	}
+	scriptID := script["scriptId"]
+	if resolved := c.expectEvent("Debugger.breakpointResolved"); resolved["breakpointId"] != bp["breakpointId"] {
+		t.Errorf("unexpected breakpoint %v", resolved)
+	}
+
+	paused := c.expectEvent("Debugger.paused")
+	if hit := paused["hitBreakpoints"].([]interface{}); len(hit) != 1 || hit[0] != bp["breakpointId"] {
+		t.Errorf("unexpected breakpoints %v", hit)
+	}
+	frames := paused["callFrames"].([]interface{})
+	top := frames[0].(map[string]interface{})
+	loc := top["location"].(map[string]interface{})
+	if top["functionName"] != "add" || loc["scriptId"] != scriptID || loc["lineNumber"] != float64(3) {
+		t.Errorf("unexpected frame %v", top)
+	}
+
+	// Variables
+	scope := top["scopeChain"].([]interface{})[0].(map[string]interface{})
+	if scope["type"] != "local" {
+		t.Errorf("unexpected scope %v", scope)
+	}
+	objectID := scope["object"].(map[string]interface{})["objectId"]
+	props := c.mustCall("Runtime.getProperties", map[string]interface{}{"objectId": objectID, "ownProperties": true})
+	values := make(map[string]interface{})
+	for _, p := range props["result"].([]interface{}) {
+		p := p.(map[string]interface{})
+		values[p["name"].(string)] = p["value"].(map[string]interface{})["value"]
+	}
+	if values["a"] != float64(1) || values["b"] != float64(2) || values["sum"] != float64(3) {
+		t.Errorf("unexpected variables %v", values)
+	}
+
+	// Evaluation
+	res := c.mustCall("Debugger.evaluateOnCallFrame", map[string]interface{}{"callFrameId": top["callFrameId"], "expression": "sum * 10"})
+	if v := res["result"].(map[string]interface{}); v["type"] != "number" || v["value"] != float64(30) {
+		t.Errorf("unexpected result %v", v)
+	}
+	res = c.mustCall("Runtime.evaluate", map[string]interface{}{"expression": "({x: 1})"})
+	if v := res["result"].(map[string]interface{}); v["type"] != "object" || v["objectId"] == nil {
+		t.Errorf("unexpected result %v", v)
+	}
+	res = c.mustCall("Runtime.evaluate", map[string]interface{}{"expression": "missing"})
+	if res["exceptionDetails"] == nil {
+		t.Errorf("expected an exception, got %v", res)
+	}
+	c.mustCall("Debugger.setVariableValue", map[string]interface{}{
+		"callFrameId":  top["callFrameId"],
+		"scopeNumber":  0,
+		"variableName": "sum",
+		"newValue":     map[string]interface{}{"value": 10},
+	})
+
+	// Stepping
+	c.mustCall("Debugger.stepOver", nil)
+	c.expectEvent("Debugger.resumed")
+	paused = c.expectEvent("Debugger.paused")
+	top = paused["callFrames"].([]interface{})[0].(map[string]interface{})
+	if line := top["location"].(map[string]interface{})["lineNumber"]; line != float64(4) {
+		t.Errorf("expected to step to line 4, got %v", line)
+	}
+
+	c.mustCall("Debugger.resume", nil)
+	c.expectEvent("Debugger.resumed")
+	waitDone(t, done)
+	if total := r.Get("total").ToInteger(); total != 10 {
+		t.Errorf("expected the modified sum, got %d", total)
+	}
+	if resp := c.call("Runtime.evaluate", map[string]interface{}{"expression": "total"}); resp.Error == nil {
+		t.Error("expected an error while running")
+	}
+
+	source := c.mustCall("Debugger.getScriptSource", map[string]interface{}{"scriptId": scriptID})
+	if source["scriptSource"] != SCRIPT {
+		t.Errorf("unexpected source %v", source)
+	}
+}
+
+func TestPauseOnException(t *testing.T) {
+	r, srv := newTestServer()
+	defer srv.Close()
+	c := newClient(t, srv)
+	defer c.close()
+
+	c.mustCall("Debugger.enable", nil)
+	c.mustCall("Debugger.setPauseOnExceptions", map[string]interface{}{"state": "all"})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.RunScript("exception.js", "try {\n\tthrow new TypeError('boom');\n} catch (e) {}\n")
+		done <- err
+	}()
+
+	paused := c.expectEvent("Debugger.paused")
+	data := paused["data"].(map[string]interface{})
+	if paused["reason"] != "exception" || data["subtype"] != "error" || !strings.Contains(data["description"].(string), "boom") {
+		t.Errorf("unexpected pause %v", paused)
+	}
+	c.mustCall("Debugger.resume", nil)
+	waitDone(t, done)
+}
+
+func TestSourceMapURL(t *testing.T) {
+	r, srv := newTestServer()
+	defer srv.Close()
+
+	// Scripts loaded before the client attaches are reported when it enables the Debugger domain
+	sm := "data:application/json;base64," +
+		base64.StdEncoding.EncodeToString([]byte(`{"version":3,"sources":["../src/app.ts"],"names":[],"mappings":"AAAA"}`))
+	if _, err := r.RunScript("dist/app.js", "var x = 1;\n//# sourceMappingURL="+sm+"\n"); err != nil {
+		t.Fatal(err)
+	}
+	c := newClient(t, srv)
+	defer c.close()
+	c.mustCall("Debugger.enable", nil)
+	if script := c.expectEvent("Debugger.scriptParsed"); script["url"] != "dist/app.js" || script["sourceMapURL"] != sm {
+		t.Errorf("unexpected script %v", script)
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	r, srv := newTestServer()
+	defer srv.Close()
+	c := newClient(t, srv)
+	defer c.close()
+
+	c.mustCall("Profiler.enable", nil)
+	c.mustCall("Profiler.setSamplingInterval", map[string]interface{}{"interval": 1000})
+	c.mustCall("Profiler.start", nil)
+	if resp := c.call("Profiler.setSamplingInterval", map[string]interface{}{"interval": 100}); resp.Error == nil {
+		t.Error("expected an error for a change of interval while profiling")
+	}
+	if resp := c.call("Profiler.start", nil); resp.Error == nil {
+		t.Error("expected an error for a profile already started")
+	}
+	_, err := r.RunScript("busy.js", `
+	function busy() {
+		var start = Date.now(), n = 0;
+		while (Date.now() - start < 100) {
+			n++;
+		}
+		return n;
+	}
+	busy();
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := c.mustCall("Profiler.stop", nil)["profile"].(map[string]interface{})
+
+	nodes := p["nodes"].([]interface{})
+	found := false
+	for _, n := range nodes {
+		cf := n.(map[string]interface{})["callFrame"].(map[string]interface{})
+		if cf["functionName"] == "busy" && cf["url"] == "busy.js" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("busy() is not in the profile: %v", nodes)
+	}
+	samples, deltas := p["samples"].([]interface{}), p["timeDeltas"].([]interface{})
+	if len(samples) == 0 || len(samples) != len(deltas) {
+		t.Errorf("unexpected samples %v, %v", samples, deltas)
+	}
+	if p["endTime"].(float64) < p["startTime"].(float64) {
+		t.Errorf("unexpected times %v", p)
+	}
+	if resp := c.call("Profiler.stop", nil); resp.Error == nil {
+		t.Error("expected an error for a profile not started")
+	}
+}
+
+func TestWebSocketMessages(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+	sc := &wsConn{conn: server, br: bufio.NewReader(server)}
+	cc := &wsConn{conn: client, br: bufio.NewReader(client), client: true}
+
+	for _, size := range []int{0, 125, 126, 65535, 70000} {
+		msg := strings.Repeat("x", size)
+		go func() {
+			_ = cc.WriteMessage([]byte(msg))
+		}()
+		b, err := sc.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != msg {
+			t.Fatalf("message of %d bytes corrupted", size)
+		}
+	}
+
+	// Fragments and pings in between
+	go func() {
+		// Short frames written by hand, masked with a zero key
+		_, _ = client.Write([]byte{opText, 0x80 | 3, 0, 0, 0, 0, 'h', 'e', 'l'})
+		_ = cc.writeFrame(opPing, []byte("p"))
+		_, _ = client.Write([]byte{0x80 | opContinuation, 0x80 | 2, 0, 0, 0, 0, 'l', 'o'})
+	}()
+	var msg []byte
+	errs := make(chan error, 1)
+	go func() {
+		var err error
+		msg, err = sc.ReadMessage()
+		errs <- err
+	}()
+	if _, op, payload, err := cc.readFrame(); err != nil || op != opPong || string(payload) != "p" {
+		t.Fatalf("expected a pong, got %d %q %v", op, payload, err)
+	}
+	if err := <-errs; err != nil || string(msg) != "hello" {
+		t.Fatalf("unexpected message %q, %v", msg, err)
+	}
+}
+
+func TestWebSocketUnmaskedFrame(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+	sc := &wsConn{conn: server, br: bufio.NewReader(server)}
+	cc := &wsConn{conn: client, br: bufio.NewReader(client), client: true}
+
+	go func() {
+		_, _ = client.Write([]byte{0x80 | opText, 2, 'h', 'i'})
+	}()
+	errs := make(chan error, 1)
+	go func() {
+		_, err := sc.ReadMessage()
+		errs <- err
+	}()
+	// The server closes the connection with a protocol error
+	_, op, payload, err := cc.readFrame()
+	if err != nil || op != opClose || len(payload) != 2 || binary.BigEndian.Uint16(payload) != closeProtocolError {
+		t.Fatalf("expected a close frame with status 1002, got %d %v %v", op, payload, err)
+	}
+	if err := <-errs; err == nil {
+		t.Fatal("expected an error for an unmasked frame")
+	}
+}
+
+func TestWebSocketInvalidControlFrame(t *testing.T) {
+	for _, frame := range [][]byte{
+		{opPing, 0x80 | 1, 0, 0, 0, 0, 'p'},             // Fragmented
+		{0x80 | opPing, 0x80 | 126, 0, 126, 0, 0, 0, 0}, // Payload over 125 bytes
+		{opClose, 0x80, 0, 0, 0, 0},                     // Fragmented
+	} {
+		server, client := net.Pipe()
+		sc := &wsConn{conn: server, br: bufio.NewReader(server)}
+		cc := &wsConn{conn: client, br: bufio.NewReader(client), client: true}
+
+		go func() {
+			_, _ = client.Write(frame)
+		}()
+		errs := make(chan error, 1)
+		go func() {
+			_, err := sc.ReadMessage()
+			errs <- err
+		}()
+		_, op, payload, err := cc.readFrame()
+		if err != nil || op != opClose || len(payload) != 2 || binary.BigEndian.Uint16(payload) != closeProtocolError {
+			t.Fatalf("%v: expected a close frame with status 1002, got %d %v %v", frame, op, payload, err)
+		}
+		if err := <-errs; err == nil {
+			t.Fatalf("%v: expected an error for an invalid control frame", frame)
+		}
+		server.Close()
+		client.Close()
+	}
+}
+
+func TestWebSocketProtocolError(t *testing.T) {
+	for _, frame := range [][]byte{
+		{0x80 | opContinuation, 0x80 | 1, 0, 0, 0, 0, 'a'},                            // Continuation without a message
+		{opText, 0x80 | 1, 0, 0, 0, 0, 'a', 0x80 | opText, 0x80 | 1, 0, 0, 0, 0, 'b'}, // New message in a fragmented one
+		{0x80 | 0x3, 0x80, 0, 0, 0, 0},                                                // Unknown opcode
+		{0x80 | 0x40 | opText, 0x80 | 1, 0, 0, 0, 0, 'a'},                             // RSV1 set
+		{0x80 | 0x10 | opPing, 0x80, 0, 0, 0, 0},                                      // RSV3 set
+	} {
+		server, client := net.Pipe()
+		sc := &wsConn{conn: server, br: bufio.NewReader(server)}
+		cc := &wsConn{conn: client, br: bufio.NewReader(client), client: true}
+
+		go func() {
+			_, _ = client.Write(frame)
+		}()
+		errs := make(chan error, 1)
+		go func() {
+			_, err := sc.ReadMessage()
+			errs <- err
+		}()
+		_, op, payload, err := cc.readFrame()
+		if err != nil || op != opClose || len(payload) != 2 || binary.BigEndian.Uint16(payload) != closeProtocolError {
+			t.Fatalf("%v: expected a close frame with status 1002, got %d %v %v", frame, op, payload, err)
+		}
+		if err := <-errs; err == nil {
+			t.Fatalf("%v: expected a protocol error", frame)
+		}
+		server.Close()
+		client.Close()
+	}
+}
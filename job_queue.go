@@ -0,0 +1,106 @@
+package goja
+
+import (
+	gocontext "context"
+	"sync"
+)
+
+// hostJobQueue holds the jobs enqueued from outside of the Runtime, possibly from other goroutines. They
+// are run by the goroutine running the Runtime once the JavaScript code returns, after the promise jobs.
+type hostJobQueue struct {
+	mu      sync.Mutex
+	jobs    []func()
+	pending int // the number of jobs that are expected to be enqueued, see hold()
+	wakeup  chan struct{}
+}
+
+// hold records that a job is going to be enqueued (with release set), so that RunJobs waits for it.
+func (q *hostJobQueue) hold() {
+	q.mu.Lock()
+	q.pending++
+	q.mu.Unlock()
+}
+
+// release undoes hold() when the job is not going to be enqueued after all.
+func (q *hostJobQueue) release() {
+	q.mu.Lock()
+	q.pending--
+	q.mu.Unlock()
+}
+
+func (q *hostJobQueue) enqueue(job func(), release bool) {
+	q.mu.Lock()
+	q.jobs = append(q.jobs, job)
+	if release {
+		q.pending--
+	}
+	if q.wakeup != nil {
+		select {
+		case q.wakeup <- struct{}{}:
+		default:
+		}
+	}
+	q.mu.Unlock()
+}
+
+// take removes and returns the first enqueued job. If there is none, it returns whether there are jobs
+// expected to be enqueued and a channel that is signalled when a job is enqueued.
+// The jobs are taken one at a time, so that the ones left are still run if a job fails.
+func (q *hostJobQueue) take() (job func(), wait bool, wakeup chan struct{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.jobs) > 0 {
+		job = q.jobs[0]
+		q.jobs[0] = nil
+		q.jobs = q.jobs[1:]
+		if len(q.jobs) == 0 {
+			q.jobs = nil
+		}
+		return
+	}
+	if q.pending > 0 {
+		if q.wakeup == nil {
+			q.wakeup = make(chan struct{}, 1)
+		}
+		return nil, true, q.wakeup
+	}
+	return
+}
+
+// EnqueueJob schedules job to be called by the goroutine running the Runtime. Unlike other methods of the
+// Runtime, it's safe to call from any goroutine. It's the way to use the Runtime (e.g. to resolve a Promise
+// created with NewPromise) once a background operation completes.
+//
+// The job is called when the JavaScript code that is running returns (after the promise jobs), or by
+// RunJobs if no code is running. It's called with no JavaScript code on the stack, the same way as the Go
+// code calling into the Runtime.
+func (r *Runtime) EnqueueJob(job func()) {
+	r.hostJobs.enqueue(job, false)
+}
+
+// RunJobs runs the jobs enqueued with EnqueueJob, and the promise jobs they create, until there are none
+// left. As long as there are dynamic imports whose modules are being loaded (see SetHostLoadModule), it
+// waits for them to finish loading. It returns when there is nothing left to wait for, when ctx is done
+// (with its error) or when a job fails with an uncatchable error or a JavaScript exception. The jobs left are
+// run by the next call.
+//
+// It must be called by the goroutine using the Runtime, when no JavaScript code is running.
+func (r *Runtime) RunJobs(ctx gocontext.Context) error {
+	for {
+		job, wait, wakeup := r.hostJobs.take()
+		if job == nil {
+			if !wait {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-wakeup:
+			}
+			continue
+		}
+		if err := r.runWrapped(job); err != nil {
+			return err
+		}
+	}
+}
@@ -33,10 +33,12 @@ func (m *orderedMap) lookup(key Value) (h uint64, entry, hPrev *mapEntry) {
 	return
 }
 
-func (m *orderedMap) set(key, value Value) {
+// set sets the value of key and returns true if it's a new entry.
+func (m *orderedMap) set(key, value Value) bool {
 	h, entry, hPrev := m.lookup(key)
 	if entry != nil {
 		entry.value = value
+		return false
 	} else {
 		if key == _negativeZero {
 			key = intToValue(0)
@@ -56,6 +58,7 @@ func (m *orderedMap) set(key, value Value) {
 		m.iterLast = entry
 		m.size++
 	}
+	return true
 }
 
 func (m *orderedMap) get(key Value) Value {
@@ -0,0 +1,248 @@
+package goja
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// MemoryUsage is the memory accounted to a Runtime, in bytes, see StartMemoryAccounting.
+type MemoryUsage struct {
+	Objects     int64 // Objects, including functions and the property maps of ordinary objects
+	Arrays      int64 // Elements of arrays
+	Strings     int64 // Strings built by concatenation, String.prototype.repeat, padStart, padEnd, concat and Array.prototype.join
+	Buffers     int64 // Data of ArrayBuffers, including those of typed arrays
+	Collections int64 // Entries of Maps and Sets
+	Total       int64
+	Limit       int64 // 0 if there is no limit
+}
+
+// MemoryLimitError is returned when the code run by a Runtime exceeds its memory limit, see
+// StartMemoryAccounting. Like *InterruptedError it can't be caught by JavaScript code.
+type MemoryLimitError struct {
+	baseUncatchableException
+	usage MemoryUsage
+}
+
+// Usage returns the memory accounted when the limit was exceeded.
+func (e *MemoryLimitError) Usage() MemoryUsage {
+	return e.usage
+}
+
+func (e *MemoryLimitError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "memory limit of %d bytes exceeded", e.usage.Limit)
+	e.writeShortStack(&b)
+	return b.String()
+}
+
+type memoryKind int
+
+const (
+	memoryObjects memoryKind = iota
+	memoryArrays
+	memoryStrings
+	memoryBuffers
+	memoryCollections
+)
+
+type memoryAccount struct {
+	limit int64
+	usage [memoryCollections + 1]int64
+	total int64
+}
+
+// StartMemoryAccounting starts accounting the memory allocated by the code run by the Runtime and
+// limits it to limit bytes, 0 for no limit. When the limit is exceeded, the code is aborted with a
+// *MemoryLimitError. It must not be called while the Runtime is running code.
+//
+// The accounting is approximate: it estimates the size of what the code allocates and nothing is
+// credited back when it becomes garbage, so the usage is the memory allocated since the accounting was
+// started or ResetMemoryUsage was called rather than the memory in use. Once the limit has been exceeded,
+// the code the Runtime runs fails as soon as it allocates until the usage is reset.
+func (r *Runtime) StartMemoryAccounting(limit int64) {
+	r.vm.memory = &memoryAccount{
+		limit: limit,
+	}
+}
+
+// StopMemoryAccounting stops accounting the memory allocated by the Runtime.
+func (r *Runtime) StopMemoryAccounting() {
+	r.vm.memory = nil
+}
+
+// MemoryUsage returns the memory accounted to the Runtime, see StartMemoryAccounting. It's all zeroes if
+// the memory isn't accounted.
+func (r *Runtime) MemoryUsage() MemoryUsage {
+	if m := r.vm.memory; m != nil {
+		return m.report()
+	}
+	return MemoryUsage{}
+}
+
+// ResetMemoryUsage sets the memory accounted to the Runtime back to 0. It must not be called while the
+// Runtime is running code.
+func (r *Runtime) ResetMemoryUsage() {
+	if m := r.vm.memory; m != nil {
+		*m = memoryAccount{limit: m.limit}
+	}
+}
+
+func (m *memoryAccount) report() MemoryUsage {
+	return MemoryUsage{
+		Objects:     m.usage[memoryObjects],
+		Arrays:      m.usage[memoryArrays],
+		Strings:     m.usage[memoryStrings],
+		Buffers:     m.usage[memoryBuffers],
+		Collections: m.usage[memoryCollections],
+		Total:       m.total,
+		Limit:       m.limit,
+	}
+}
+
+// charge accounts bytes and aborts the running code if that exceeds the limit. Allocations made while
+// no code runs (e.g. a Go caller creating objects) are accounted, the next ones made by code fail.
+func (m *memoryAccount) charge(vm *vm, kind memoryKind, bytes int64) {
+	m.usage[kind] += bytes
+	m.total += bytes
+	if m.limit == 0 || m.total <= m.limit || len(vm.callStack) == 0 {
+		return
+	}
+	m.exceeded(vm, m.report())
+}
+
+// reserve aborts the running code if allocating bytes would exceed the limit. Unlike charge it doesn't
+// account them: it's called before a bulk allocation, which is charged once it's been made. The error
+// reports the usage the allocation would have led to.
+func (m *memoryAccount) reserve(vm *vm, kind memoryKind, bytes int64) {
+	if m.limit == 0 || m.total+bytes <= m.limit || len(vm.callStack) == 0 {
+		return
+	}
+	pending := *m
+	pending.usage[kind] += bytes
+	pending.total += bytes
+	m.exceeded(vm, pending.report())
+}
+
+func (m *memoryAccount) exceeded(vm *vm, usage MemoryUsage) {
+	ex := &MemoryLimitError{
+		usage: usage,
+	}
+	ex.stack = vm.captureStack(nil, 0)
+	panic(ex)
+}
+
+// trackAlloc records an allocation in the memory accounting and the allocation profile, see
+// profileAlloc.
+func (r *Runtime) trackAlloc(kind memoryKind, objects, bytes int64) {
+	if r == nil {
+		return
+	}
+	if vm := r.vm; vm != nil {
+		if vm.memory != nil && bytes > 0 {
+			vm.memory.charge(vm, kind, bytes)
+		}
+		vm.profileAlloc(objects, bytes)
+	}
+}
+
+// checkAlloc aborts the running code if allocating count items of size bytes would exceed the memory
+// limit, so that a bulk allocation whose size is known in advance fails before it's made. The
+// allocation itself is accounted afterwards as usual.
+func (r *Runtime) checkAlloc(kind memoryKind, count, size int64) {
+	if vm := r.vm; vm != nil && vm.memory != nil {
+		bytes := int64(maxAccountedSize)
+		if size == 0 || count <= maxAccountedSize/size {
+			bytes = count * size
+		}
+		vm.memory.reserve(vm, kind, bytes)
+	}
+}
+
+// stringSizeCheck checks the size of a string built piece by piece against the memory limit before
+// each piece is appended, see checkAlloc.
+type stringSizeCheck struct {
+	r       *Runtime
+	length  int64
+	unicode bool
+}
+
+func (c *stringSizeCheck) add(s String) {
+	if c.r.vm.memory == nil {
+		return
+	}
+	c.length += int64(s.Length())
+	if !c.unicode {
+		if _, u := devirtualizeString(s); u != nil {
+			c.unicode = true
+		}
+	}
+	if c.unicode {
+		c.r.checkAlloc(memoryStrings, c.length, 2)
+	} else {
+		c.r.checkAlloc(memoryStrings, c.length, 1)
+	}
+}
+
+// mapSize is the estimated size of the property map of a new object
+const mapSize = 48
+
+// valueSize is the size of an element of an array
+const valueSize = int64(unsafe.Sizeof(Value(nil)))
+
+// sparseArrayItemSize is the size of an element of a sparse array
+const sparseArrayItemSize = int64(unsafe.Sizeof(sparseArrayItem{}))
+
+// maxAccountedSize bounds the size of a single allocation so that the usage can't overflow
+const maxAccountedSize = 1 << 50
+
+// mapEntrySize is the estimated size of an entry of a Map or a Set, with its slot in the hash table
+const mapEntrySize = int64(unsafe.Sizeof(mapEntry{})) + 16
+
+// tracksAllocs returns whether the allocations are counted, by the memory accounting or by an allocation
+// profile. The sizes of objects and strings are only worked out when they are.
+func (vm *vm) tracksAllocs() bool {
+	if vm.memory != nil {
+		return true
+	}
+	p := vm.profiler.Load()
+	return p != nil && p.allocRate > 0
+}
+
+// trackObject records the creation of an object, see trackAlloc
+func (r *Runtime) trackObject(o *Object) {
+	if vm := r.vm; vm != nil && vm.tracksAllocs() {
+		size := int64(unsafe.Sizeof(Object{})) + mapSize
+		if o.self != nil {
+			size += int64(reflect.TypeOf(o.self).Elem().Size())
+		} else {
+			size += int64(unsafe.Sizeof(baseObject{}))
+		}
+		r.trackAlloc(memoryObjects, 1, size)
+	}
+}
+
+// trackString records the creation of a string, see trackAlloc
+func (r *Runtime) trackString(s String) {
+	if vm := r.vm; vm != nil && vm.tracksAllocs() {
+		switch s := s.(type) {
+		case asciiString:
+			r.trackAlloc(memoryStrings, 1, int64(unsafe.Sizeof(s))+int64(len(s)))
+		case unicodeString:
+			r.trackAlloc(memoryStrings, 1, int64(unsafe.Sizeof(s))+2*int64(len(s)))
+		}
+	}
+}
+
+// checkStringAlloc checks a string of count times length characters against the memory limit before it's
+// built, see checkAlloc. The string is accounted with trackString once it's been built.
+func (r *Runtime) checkStringAlloc(length, count int64, unicode bool) {
+	if unicode {
+		length *= 2
+	}
+	r.checkAlloc(memoryStrings, count, length)
+}
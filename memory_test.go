@@ -0,0 +1,148 @@
+package goja
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	for _, script := range []string{
+		"var a = []; for (;;) a.push(1);",
+		"var a = []; a[1e9] = 1; for (var i = 0; ; i++) a[i * 10] = i;",
+		"var s = 'x'; for (;;) s += s;",
+		"'x'.repeat(1e9)",
+		"''.padStart(1e9)",
+		"new ArrayBuffer(1e9)",
+		"new Uint8Array(1e9)",
+		"var m = new Map(); for (var i = 0; ; i++) m.set(i, i);",
+		"var s = new Set(); for (var i = 0; ; i++) s.add(i);",
+		"for (;;) ({});",
+	} {
+		vm := New()
+		vm.StartMemoryAccounting(1 << 20)
+		_, err := vm.RunString(script)
+		var me *MemoryLimitError
+		if !errors.As(err, &me) {
+			t.Fatalf("%s: unexpected error %v", script, err)
+		}
+		if usage := me.Usage(); usage.Total <= usage.Limit || usage.Limit != 1<<20 {
+			t.Fatalf("%s: unexpected usage %+v", script, usage)
+		}
+	}
+}
+
+func TestMemoryLimitUncatchable(t *testing.T) {
+	vm := New()
+	vm.StartMemoryAccounting(1 << 20)
+	_, err := vm.RunString(`
+	try {
+		new ArrayBuffer(1e9);
+	} catch (e) {
+	} finally {
+		for (;;) {}
+	}
+	`)
+	var me *MemoryLimitError
+	if !errors.As(err, &me) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The rejected buffer isn't accounted, unlike the objects that were made before the limit was exceeded
+	if _, err := vm.RunString("({})"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.RunString("var l = null; for (;;) { l = {next: l}; }"); !errors.As(err, &me) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The limit stays exceeded until the usage is reset
+	if _, err := vm.RunString("({})"); !errors.As(err, &me) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	vm.ResetMemoryUsage()
+	if _, err := vm.RunString("({})"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryUsage(t *testing.T) {
+	vm := New()
+	if vm.MemoryUsage() != (MemoryUsage{}) {
+		t.Fatal("Memory is accounted")
+	}
+	vm.StartMemoryAccounting(0)
+	_, err := vm.RunString(`
+	var objects = [];
+	for (var i = 0; i < 100; i++) {
+		objects.push({i: i});
+	}
+	var s = "abc".repeat(1000);
+	var buf = new ArrayBuffer(1000);
+	var m = new Map([[1, 1], [2, 2]]);
+	m.set(1, 2);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage := vm.MemoryUsage()
+	if usage.Objects < 100*mapSize || usage.Arrays < 100*valueSize || usage.Strings < 3000 ||
+		usage.Buffers != 1000 || usage.Collections != 2*mapEntrySize {
+		t.Fatalf("Unexpected usage: %+v", usage)
+	}
+	if usage.Total != usage.Objects+usage.Arrays+usage.Strings+usage.Buffers+usage.Collections {
+		t.Fatalf("Unexpected total: %+v", usage)
+	}
+
+	vm.StopMemoryAccounting()
+	if vm.MemoryUsage() != (MemoryUsage{}) {
+		t.Fatal("Memory is still accounted")
+	}
+}
+
+func TestMemoryLimitBeforeAllocation(t *testing.T) {
+	// Without the limit these would allocate gigabytes before it's checked
+	for _, script := range []string{
+		"Array.prototype.join.call({length: 1e9}, 'abc')",
+		"var s = 'x'.repeat(6e5); s + s",
+		"var s = 'x'.repeat(6e5); `${s}${s}`",
+		"Array.from.call(null, {length: 1e9})",
+		"Array.prototype.toSorted.call({length: 1e9})",
+		"Array.prototype.sort.call({length: 1e9})",
+		"Array.prototype.toSpliced.call({length: 1e9}, 0, 0, 1)",
+	} {
+		vm := New()
+		vm.StartMemoryAccounting(1 << 20)
+		_, err := vm.RunString(script)
+		var me *MemoryLimitError
+		if !errors.As(err, &me) {
+			t.Fatalf("%s: unexpected error %v", script, err)
+		}
+		if usage := me.Usage(); usage.Total <= usage.Limit {
+			t.Fatalf("%s: unexpected usage %+v", script, usage)
+		}
+	}
+}
+
+func TestMemoryLimitRejectedAllocationNotCharged(t *testing.T) {
+	for _, script := range []string{
+		"'x'.repeat(1e8)",
+		"'x'.padStart(1e8)",
+		"'x'.padEnd(1e8, 'é')",
+		"'x'.concat('y'.repeat(6e5), 'z'.repeat(6e5))",
+		"Array.prototype.join.call({length: 1e9}, 'abc')",
+		"Array.from({length: 1e9})",
+		"new ArrayBuffer(1e8)",
+	} {
+		vm := New()
+		vm.StartMemoryAccounting(1 << 20)
+		_, err := vm.RunString("try { " + script + " } catch (e) {}")
+		var me *MemoryLimitError
+		if !errors.As(err, &me) {
+			t.Fatalf("%s: unexpected error %v", script, err)
+		}
+		// The rejected allocation isn't accounted, so the Runtime can still run code
+		if _, err := vm.RunString("[1,2,3].map(x => ({x}))"); err != nil {
+			t.Fatalf("%s: %v (usage %+v)", script, err, vm.MemoryUsage())
+		}
+	}
+}
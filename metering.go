@@ -0,0 +1,141 @@
+package goja
+
+import (
+	"errors"
+)
+
+// ErrBudgetExceeded is the error a Runtime raises when the code it runs exceeds its gas budget, see
+// StartMetering. Use errors.Is to check for it: it's the value of the *InterruptedError returned when
+// the budget is exceeded, or wrapped in the *Exception of a catchable error.
+var ErrBudgetExceeded = errors.New("execution budget exceeded")
+
+// GasCosts are the amounts of gas charged for the execution of JavaScript code. The same code charges
+// the same amount of gas whatever the speed of the machine.
+type GasCosts struct {
+	Instruction uint64 // Charged for every VM instruction, 1 if 0
+	Call        uint64 // Charged in addition to Instruction for the instructions calling a function
+	Allocation  uint64 // Charged in addition to Instruction for the instructions creating objects, arrays, functions and classes
+	// Charged for every call of a Go function (including the built-ins) from JavaScript. It's the same
+	// for every function, whatever the work it does: the cost of the JavaScript code it calls back (e.g.
+	// the callback of Array.prototype.map) is charged separately, but not the cost of the Go code itself.
+	NativeCall uint64
+}
+
+// MeteringOptions are the options of StartMetering.
+type MeteringOptions struct {
+	// Budget is the amount of gas the Runtime may consume, 0 for no limit.
+	Budget uint64
+
+	Costs GasCosts
+
+	// If Catchable is set, exceeding the budget throws an error the JavaScript code can catch, a
+	// GoError wrapping ErrBudgetExceeded. The code may then consume Grace gas more (e.g. to run its catch
+	// and finally blocks) before an uncatchable *InterruptedError is raised. Otherwise the budget is
+	// enforced with an *InterruptedError straight away.
+	Catchable bool
+	Grace     uint64
+}
+
+type meter struct {
+	opts   MeteringOptions
+	used   uint64
+	thrown bool // The catchable error has been thrown
+
+	lastPrg *Program
+	last    []uint64
+}
+
+// programGasCosts is the gas charged for each instruction of a Program with the given costs. It's kept
+// with the Program, so that it's only computed once (for each GasCosts) and released with the Program.
+type programGasCosts struct {
+	costs GasCosts
+	table []uint64
+}
+
+// StartMetering starts charging the code run by the Runtime with gas and enforcing the budget set in
+// opts. The consumption starts at 0, see GasUsed. It must not be called while the Runtime is running code.
+//
+// Once the budget has been exceeded, any code the Runtime runs fails straight away until the consumption
+// is reset with ResetGasUsed or the metering is stopped.
+func (r *Runtime) StartMetering(opts MeteringOptions) {
+	if opts.Costs.Instruction == 0 {
+		opts.Costs.Instruction = 1
+	}
+	r.vm.meter = &meter{
+		opts: opts,
+	}
+}
+
+// StopMetering stops charging the code run by the Runtime with gas.
+func (r *Runtime) StopMetering() {
+	r.vm.meter = nil
+}
+
+// GasUsed returns the amount of gas consumed since the metering was started or ResetGasUsed was called,
+// 0 if the Runtime isn't metered.
+func (r *Runtime) GasUsed() uint64 {
+	if m := r.vm.meter; m != nil {
+		return m.used
+	}
+	return 0
+}
+
+// ResetGasUsed sets the gas consumption back to 0, giving the Runtime its whole budget again. It must not
+// be called while the Runtime is running code.
+func (r *Runtime) ResetGasUsed() {
+	if m := r.vm.meter; m != nil {
+		m.used = 0
+		m.thrown = false
+	}
+}
+
+// instructionCosts returns the gas charged for each instruction of prg.
+func (m *meter) instructionCosts(prg *Program) []uint64 {
+	if prg == m.lastPrg {
+		return m.last
+	}
+	var costs []uint64
+	if c := prg.gasCosts.Load(); c != nil && c.costs == m.opts.Costs {
+		costs = c.table
+	} else {
+		costs = make([]uint64, len(prg.code))
+		for pc, ins := range prg.code {
+			costs[pc] = m.opts.Costs.Instruction
+			switch ins.(type) {
+			case call, callEval, callEvalStrict, _callVariadic, _callEvalVariadic, _callEvalVariadicStrict,
+				_new, _newVariadic, superCall, _superCallVariadic:
+				costs[pc] += m.opts.Costs.Call
+			case _newObject, newArray, _newArrayFromIter, _newDisposeCapability, *newRegexp, *newFunc, *newAsyncFunc, *newGeneratorFunc, *newAsyncGeneratorFunc,
+				*newMethod, *newAsyncMethod, *newGeneratorMethod, *newAsyncGeneratorMethod, *newArrowFunc, *newAsyncArrowFunc, *newClass,
+				*newDerivedClass:
+				costs[pc] += m.opts.Costs.Allocation
+			}
+		}
+		prg.gasCosts.Store(&programGasCosts{
+			costs: m.opts.Costs,
+			table: costs,
+		})
+	}
+	m.lastPrg, m.last = prg, costs
+	return costs
+}
+
+// charge consumes gas and raises an error if the budget is exceeded.
+func (m *meter) charge(vm *vm, gas uint64) {
+	m.used += gas
+	if m.opts.Budget == 0 || m.used <= m.opts.Budget {
+		return
+	}
+	if m.opts.Catchable && !m.thrown {
+		m.thrown = true
+		panic(vm.r.NewGoError(ErrBudgetExceeded))
+	}
+	if m.opts.Catchable && m.used-m.opts.Budget <= m.opts.Grace {
+		return
+	}
+	ex := &InterruptedError{
+		iface: ErrBudgetExceeded,
+	}
+	ex.stack = vm.captureStack(nil, 0)
+	panic(ex)
+}
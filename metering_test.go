@@ -0,0 +1,168 @@
+package goja
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMeteringBudget(t *testing.T) {
+	vm := New()
+	vm.StartMetering(MeteringOptions{Budget: 1000})
+	_, err := vm.RunString(`
+	try {
+		for (;;) {}
+	} catch (e) {
+	} finally {
+		for (;;) {}
+	}
+	`)
+	var ie *InterruptedError
+	if !errors.As(err, &ie) || !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if used := vm.GasUsed(); used != 1001 {
+		t.Fatalf("Unexpected gas used: %d", used)
+	}
+
+	// The budget stays exceeded until the consumption is reset
+	if _, err := vm.RunString("1"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	vm.ResetGasUsed()
+	v, err := vm.RunString("1 + 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ToInteger() != 2 {
+		t.Fatalf("Unexpected result: %v", v)
+	}
+}
+
+func TestMeteringDeterministic(t *testing.T) {
+	const script = `
+	function fib(n) {
+		return n < 2 ? n : fib(n - 1) + fib(n - 2);
+	}
+	var a = [];
+	for (var i = 0; i < 10; i++) {
+		a.push({v: fib(i)});
+	}
+	`
+	costs := GasCosts{Instruction: 2, Call: 10, Allocation: 5, NativeCall: 100}
+	var used uint64
+	for i := 0; i < 3; i++ {
+		vm := New()
+		vm.StartMetering(MeteringOptions{Costs: costs})
+		if _, err := vm.RunString(script); err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 && vm.GasUsed() != used {
+			t.Fatalf("Run %d used %d, expected %d", i, vm.GasUsed(), used)
+		}
+		used = vm.GasUsed()
+	}
+
+	vm := New()
+	vm.StartMetering(MeteringOptions{})
+	if _, err := vm.RunString(script); err != nil {
+		t.Fatal(err)
+	}
+	if base := vm.GasUsed(); base == 0 || used <= 2*base {
+		t.Fatalf("The costs were not applied: %d with the costs, %d without", used, base)
+	}
+}
+
+func TestMeteringSharedProgram(t *testing.T) {
+	prg := MustCompile("test.js", "var s = 0; for (var i = 0; i < 10; i++) { s += i; }", false)
+	run := func(costs GasCosts) uint64 {
+		vm := New()
+		vm.StartMetering(MeteringOptions{Costs: costs})
+		if _, err := vm.RunProgram(prg); err != nil {
+			t.Fatal(err)
+		}
+		return vm.GasUsed()
+	}
+	base := run(GasCosts{})
+	// The costs computed for a Program are only reused with the same GasCosts
+	if used := run(GasCosts{Instruction: 3}); used != 3*base {
+		t.Fatalf("Expected %d, got %d", 3*base, used)
+	}
+	if used := run(GasCosts{}); used != base {
+		t.Fatalf("Expected %d, got %d", base, used)
+	}
+	if c := prg.gasCosts.Load(); c == nil || c.costs != (GasCosts{Instruction: 1}) {
+		t.Fatalf("Unexpected costs kept with the program: %+v", c)
+	}
+}
+
+func TestMeteringNativeCall(t *testing.T) {
+	vm := New()
+	vm.Set("f", func() {})
+	vm.StartMetering(MeteringOptions{Costs: GasCosts{NativeCall: 1000}})
+	if _, err := vm.RunString("f()"); err != nil {
+		t.Fatal(err)
+	}
+	used := vm.GasUsed()
+	vm.ResetGasUsed()
+	if _, err := vm.RunString("f(); f()"); err != nil {
+		t.Fatal(err)
+	}
+	if delta := vm.GasUsed() - used; delta < 1000 || delta > 1100 {
+		t.Fatalf("Unexpected cost of a native call: %d", delta)
+	}
+}
+
+func TestMeteringCatchable(t *testing.T) {
+	vm := New()
+	vm.StartMetering(MeteringOptions{Budget: 1000, Catchable: true, Grace: 100})
+	v, err := vm.RunString(`
+	var caught;
+	try {
+		for (;;) {}
+	} catch (e) {
+		caught = e.value;
+	}
+	caught;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Export() != ErrBudgetExceeded {
+		t.Fatalf("Unexpected value caught: %v", v)
+	}
+
+	// Past the grace, the error can't be caught
+	_, err = vm.RunString(`
+	try {
+		for (;;) {}
+	} catch (e) {
+	}
+	`)
+	var ie *InterruptedError
+	if !errors.As(err, &ie) || !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if used := vm.GasUsed(); used != 1101 {
+		t.Fatalf("Unexpected gas used: %d", used)
+	}
+
+	// Uncaught, it's returned as an exception
+	vm.ResetGasUsed()
+	_, err = vm.RunString("for (;;) {}")
+	var ex *Exception
+	if !errors.As(err, &ex) || !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestMeteringStop(t *testing.T) {
+	vm := New()
+	vm.StartMetering(MeteringOptions{Budget: 10})
+	vm.StopMetering()
+	if _, err := vm.RunString("for (var i = 0; i < 100; i++) {}"); err != nil {
+		t.Fatal(err)
+	}
+	if vm.GasUsed() != 0 {
+		t.Fatal("Gas was used")
+	}
+}
@@ -0,0 +1,1075 @@
+package goja
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+
+	js_ast "github.com/dop251/goja/ast"
+	"github.com/dop251/goja/parser"
+	"github.com/dop251/goja/unistring"
+)
+
+// ModuleRecord is the internal, compiled representation of an ECMAScript module, produced by
+// CompileModule. Like a Program it's not linked to a Runtime and can be used by several runtimes (possibly
+// at the same time), each of them keeps its own instance of the module, with its own bindings.
+type ModuleRecord struct {
+	name string
+	prg  *Program
+
+	// The code creating the hoisted functions, it's run when the module is linked
+	initPc int
+
+	// The module code uses top-level await, it's run as the body of an async function
+	hasTLA bool
+
+	// The layout of the module environment
+	names     map[unistring.String]uint32
+	stashSize int
+
+	requestedModules []string
+	importEntries    []importEntry
+	importNames      map[unistring.String]uint32
+	localExports     []localExport
+	indirectExports  []indirectExport
+	starExports      []string
+}
+
+type importEntry struct {
+	moduleRequest string
+	importName    unistring.String
+	localName     unistring.String
+	namespace     bool // import * as localName
+}
+
+type localExport struct {
+	exportName unistring.String
+	localName  unistring.String
+	idx        uint32 // of the local binding in the module environment, with the names map flags
+}
+
+type indirectExport struct {
+	exportName    unistring.String
+	moduleRequest string
+	importName    unistring.String
+	namespace     bool // export * as exportName
+}
+
+// HostResolveModuleFunc returns the module the specifier imported by the referrer refers to. The
+// embedder decides what the specifier means (a path, a URL, a package name, ...) and where the source of
+// the module comes from, see Runtime.SetHostResolveModule.
+type HostResolveModuleFunc func(referrer *ModuleRecord, specifier string) (*ModuleRecord, error)
+
+// Name returns the name the module was compiled with.
+func (m *ModuleRecord) Name() string {
+	return m.name
+}
+
+// RequestedModules returns the specifiers of the modules imported by the module, in the order they appear
+// in the source.
+func (m *ModuleRecord) RequestedModules() []string {
+	return append([]string(nil), m.requestedModules...)
+}
+
+// CompileModule creates an internal representation of the ECMAScript module that can be later linked and
+// evaluated using Runtime.EvaluateModule. Module code is always strict. The name is what the module is
+// reported as in stack traces, it doesn't have to be the specifier the module is imported with.
+func CompileModule(name, src string) (*ModuleRecord, error) {
+	prg, err := ParseModule(name, src)
+	if err != nil {
+		return nil, err
+	}
+	return CompileModuleAST(prg)
+}
+
+// CompileModuleAST is like CompileModule, but takes a module parsed with ParseModule.
+func CompileModuleAST(prg *js_ast.Program) (m *ModuleRecord, err error) {
+	c := newCompiler()
+
+	defer func() {
+		if x := recover(); x != nil {
+			m = nil
+			switch x1 := x.(type) {
+			case *CompilerSyntaxError:
+				err = x1
+			default:
+				panic(x)
+			}
+		}
+	}()
+
+	m = &ModuleRecord{}
+	if prg.File != nil {
+		m.name = prg.File.Name()
+	}
+	c.compileModule(prg, m)
+	return
+}
+
+// ParseModule is like Parse, but parses the source as an ECMAScript module.
+func ParseModule(name, src string, options ...parser.Option) (prg *js_ast.Program, err error) {
+	prg, err1 := parser.ParseModule(nil, name, src, 0, options...)
+	if err1 != nil {
+		err = &CompilerSyntaxError{
+			CompilerError: CompilerError{
+				Message: err1.Error(),
+			},
+		}
+	}
+	return
+}
+
+type moduleStatus uint8
+
+const (
+	moduleUnlinked moduleStatus = iota
+	moduleLinking
+	moduleLinked
+	moduleEvaluating
+	moduleEvaluatingAsync
+	moduleEvaluated
+)
+
+// ErrModuleEvaluationPending is returned by Runtime.EvaluateModule when the evaluation of the module awaits
+// something that isn't settled by the time the jobs have run.
+var ErrModuleEvaluationPending = errors.New("the evaluation of the module is pending")
+
+// moduleInstance is the state of a module in a Runtime.
+type moduleInstance struct {
+	record    *ModuleRecord
+	env       *stash
+	namespace *Object
+	meta      *Object
+	status    moduleStatus
+	evalErr   *Exception // What the evaluation of the module failed with
+
+	// The modules the specifiers imported by the module resolved to
+	resolved map[string]*moduleInstance
+
+	dfsIndex, dfsAncestorIndex int
+
+	// The state of the asynchronous evaluation, when the module or one of the modules it imports uses
+	// top-level await
+	cycleRoot                *moduleInstance
+	asyncEvaluation          bool
+	asyncEvaluationOrder     uint64
+	asyncParentModules       []*moduleInstance
+	pendingAsyncDependencies int
+	topLevelCapability       *promiseCapability
+}
+
+// resolvedBinding is the binding an export resolves to: a binding in the environment of a module or its
+// namespace object.
+type resolvedBinding struct {
+	module    *moduleInstance
+	idx       uint32
+	namespace bool
+}
+
+var ambiguousBinding = &resolvedBinding{}
+
+type resolveSetItem struct {
+	module *moduleInstance
+	name   unistring.String
+}
+
+// importBinding is the value of an imported binding in the environment of a module, it refers to a binding
+// of the exporting module so that the importer sees the updates.
+type importBinding struct {
+	valueUnresolved
+	env *stash
+	idx uint32
+}
+
+// get returns the value of the binding, nil if it's a lexical binding that hasn't been initialised yet.
+func (b *importBinding) get() Value {
+	v := b.env.values[b.idx&^maskTyp]
+	if v == nil && b.idx&maskVar != 0 {
+		return _undefined
+	}
+	return v
+}
+
+type importRef struct {
+	n unistring.String
+	b *importBinding
+}
+
+func (r *importRef) get() Value {
+	v := r.b.get()
+	if v == nil {
+		panic(errAccessBeforeInit)
+	}
+	return v
+}
+
+func (r *importRef) set(Value) {
+	panic(errAssignToConst)
+}
+
+func (r *importRef) init(Value) {
+	panic(errAssignToConst)
+}
+
+func (r *importRef) refname() unistring.String {
+	return r.n
+}
+
+// SetHostResolveModule sets the function resolving the specifiers of the modules imported by other
+// modules. It must return the same *ModuleRecord every time it's called with the same specifier and
+// referrer, the Runtime caches the result anyway. If it returns an error, the import fails with a GoError
+// wrapping it.
+func (r *Runtime) SetHostResolveModule(resolve HostResolveModuleFunc) {
+	r.resolveModule = resolve
+}
+
+// SetHostInitializeImportMeta sets a function called with the import.meta object of a module when the
+// module is linked, so that it can add properties to it (e.g. "url"). The object has no prototype and no
+// properties otherwise.
+func (r *Runtime) SetHostInitializeImportMeta(init func(meta *Object, m *ModuleRecord)) {
+	r.initImportMeta = init
+}
+
+// LinkModule resolves the imports of the module and of the modules it imports, recursively, and binds them
+// to the exports of the imported modules. It's done by EvaluateModule if needed, linking first only
+// reports the problems earlier. Linking doesn't run any code of the modules.
+func (r *Runtime) LinkModule(m *ModuleRecord) error {
+	return r.runWrapped(func() {
+		r.linkModule(r.getModuleInstance(m))
+	})
+}
+
+// EvaluateModule links the module if it hasn't been linked yet, then runs its code, and the code of the
+// modules it imports, once. It returns the namespace object of the module, which holds its exports.
+//
+// If the evaluation fails, the error is returned again by subsequent calls and by the evaluation of the
+// modules importing the module.
+//
+// The modules using top-level await are evaluated asynchronously, in the promise jobs which are run before
+// EvaluateModule returns. If the evaluation is still pending by then (because it awaits something settled
+// later, e.g. by a job enqueued with EnqueueJob), it returns ErrModuleEvaluationPending, use
+// EvaluateModuleAsync and RunJobs instead.
+func (r *Runtime) EvaluateModule(m *ModuleRecord) (ns *Object, err error) {
+	var mi *moduleInstance
+	var p *Promise
+	err = r.runWrapped(func() {
+		mi = r.getModuleInstance(m)
+		r.linkModule(mi)
+		p = r.evaluateModule(mi)
+		p.handled = true
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch p.state {
+	case PromiseStatePending:
+		return nil, ErrModuleEvaluationPending
+	case PromiseStateRejected:
+		if ex := mi.cycleRootError(); ex != nil {
+			return nil, ex
+		}
+		return nil, &Exception{val: p.result}
+	}
+	return r.getModuleNamespace(mi), nil
+}
+
+// EvaluateModuleAsync is like EvaluateModule, but returns a Promise that is fulfilled with the namespace
+// object of the module once the evaluation is complete, or rejected with the error it failed with. Linking
+// errors are returned directly.
+func (r *Runtime) EvaluateModuleAsync(m *ModuleRecord) (promise *Promise, err error) {
+	err = r.runWrapped(func() {
+		mi := r.getModuleInstance(m)
+		r.linkModule(mi)
+		p, resolve, reject := r.NewPromise()
+		r.evaluateModule(mi).addReactions(&promiseReaction{
+			typ: promiseReactionFulfill,
+			handler: &jobCallback{callback: func(FunctionCall) Value {
+				if err := resolve(r.getModuleNamespace(mi)); err != nil {
+					panic(err)
+				}
+				return _undefined
+			}},
+		}, &promiseReaction{
+			typ: promiseReactionReject,
+			handler: &jobCallback{callback: func(call FunctionCall) Value {
+				if err := reject(call.Argument(0)); err != nil {
+					panic(err)
+				}
+				return _undefined
+			}},
+		})
+		promise = p
+	})
+	return
+}
+
+func (r *Runtime) getModuleInstance(m *ModuleRecord) *moduleInstance {
+	mi := r.modules[m]
+	if mi == nil {
+		if r.modules == nil {
+			r.modules = make(map[*ModuleRecord]*moduleInstance)
+		}
+		mi = &moduleInstance{
+			record: m,
+		}
+		r.modules[m] = mi
+	}
+	return mi
+}
+
+// resolveImportedModule returns the module the specifier imported by m refers to.
+func (r *Runtime) resolveImportedModule(m *moduleInstance, specifier string) *moduleInstance {
+	if mi := m.resolved[specifier]; mi != nil {
+		return mi
+	}
+	if r.resolveModule == nil {
+		panic(r.NewTypeError("Cannot find module '%s': no module resolver is set", specifier))
+	}
+	rec, err := r.resolveModule(m.record, specifier)
+	if err != nil {
+		panic(r.moduleLoadError(err))
+	}
+	if rec == nil {
+		panic(r.NewTypeError("Cannot find module '%s'", specifier))
+	}
+	mi := r.getModuleInstance(rec)
+	if m.resolved == nil {
+		m.resolved = make(map[string]*moduleInstance)
+	}
+	m.resolved[specifier] = mi
+	return mi
+}
+
+// moduleLoadError converts an error returned by the host when loading a module into a JS value. Syntax
+// errors in the loaded module become SyntaxErrors, other errors are wrapped as GoErrors.
+func (r *Runtime) moduleLoadError(err error) Value {
+	if se, ok := err.(*CompilerSyntaxError); ok {
+		return r.newSyntaxError(se.Error(), -1)
+	}
+	return r.NewGoError(err)
+}
+
+// moduleEnv returns the environment of the module, creating it if needed. The environment of a module
+// can be referred to by the modules importing it before the module itself is linked, when there are
+// cycles.
+func (r *Runtime) moduleEnv(m *moduleInstance) *stash {
+	if m.env == nil {
+		rec := m.record
+		outer := &r.global.stash
+		if len(rec.importEntries) > 0 {
+			outer = &stash{
+				outer:  outer,
+				names:  rec.importNames,
+				values: make([]Value, len(rec.importEntries)),
+			}
+		}
+		m.env = &stash{
+			outer:    outer,
+			names:    rec.names,
+			values:   make([]Value, rec.stashSize),
+			funcType: funcModule,
+		}
+	}
+	return m.env
+}
+
+func (r *Runtime) linkModule(m *moduleInstance) {
+	var stack []*moduleInstance
+	defer func() {
+		if x := recover(); x != nil {
+			for _, m := range stack {
+				m.status = moduleUnlinked
+				m.env = nil
+			}
+			panic(x)
+		}
+	}()
+	r.innerLinkModule(m, &stack, 0)
+}
+
+func (r *Runtime) innerLinkModule(m *moduleInstance, stack *[]*moduleInstance, index int) int {
+	if m.status != moduleUnlinked {
+		return index
+	}
+	m.status = moduleLinking
+	m.dfsIndex, m.dfsAncestorIndex = index, index
+	index++
+	*stack = append(*stack, m)
+	for _, specifier := range m.record.requestedModules {
+		required := r.resolveImportedModule(m, specifier)
+		index = r.innerLinkModule(required, stack, index)
+		if required.status == moduleLinking && required.dfsAncestorIndex < m.dfsAncestorIndex {
+			m.dfsAncestorIndex = required.dfsAncestorIndex
+		}
+	}
+	r.initializeModuleEnvironment(m)
+	if m.dfsAncestorIndex == m.dfsIndex {
+		for {
+			l := len(*stack) - 1
+			required := (*stack)[l]
+			*stack = (*stack)[:l]
+			required.status = moduleLinked
+			if required == m {
+				break
+			}
+		}
+	}
+	return index
+}
+
+func (r *Runtime) throwUnresolvedImport(res *resolvedBinding, specifier string, name unistring.String) {
+	if res == ambiguousBinding {
+		panic(r.newSyntaxError("The requested module '"+specifier+"' contains conflicting star exports for name '"+name.String()+"'", -1))
+	}
+	panic(r.newSyntaxError("The requested module '"+specifier+"' does not provide an export named '"+name.String()+"'", -1))
+}
+
+func (r *Runtime) initializeModuleEnvironment(m *moduleInstance) {
+	rec := m.record
+	for _, e := range rec.indirectExports {
+		if !e.namespace {
+			if res := r.resolveExport(m, e.exportName, nil); res == nil || res == ambiguousBinding {
+				r.throwUnresolvedImport(res, e.moduleRequest, e.importName)
+			}
+		}
+	}
+	env := r.moduleEnv(m)
+	for i, e := range rec.importEntries {
+		imported := r.resolveImportedModule(m, e.moduleRequest)
+		var v Value
+		if e.namespace {
+			v = r.getModuleNamespace(imported)
+		} else {
+			res := r.resolveExport(imported, e.importName, nil)
+			if res == nil || res == ambiguousBinding {
+				r.throwUnresolvedImport(res, e.moduleRequest, e.importName)
+			}
+			if res.namespace {
+				v = r.getModuleNamespace(res.module)
+			} else {
+				v = &importBinding{
+					env: r.moduleEnv(res.module),
+					idx: res.idx,
+				}
+			}
+		}
+		env.outer.values[i] = v
+	}
+	if idx, exists := rec.names[importMetaBindingName]; exists {
+		if m.meta == nil {
+			m.meta = r.newBaseObject(nil, classObject).val
+			if r.initImportMeta != nil {
+				r.initImportMeta(m.meta, rec)
+			}
+		}
+		env.values[idx&^maskTyp] = m.meta
+	}
+	if rec.initPc < len(rec.prg.code) {
+		r.runModuleCode(m, rec.initPc)
+	}
+}
+
+// resolveExport returns the binding the export of m named name resolves to, nil if there is no such export
+// (or it's circular) and ambiguousBinding if it's exported by several modules m re-exports all the exports
+// of.
+func (r *Runtime) resolveExport(m *moduleInstance, name unistring.String, resolveSet map[resolveSetItem]struct{}) *resolvedBinding {
+	item := resolveSetItem{module: m, name: name}
+	if _, exists := resolveSet[item]; exists {
+		return nil
+	}
+	if resolveSet == nil {
+		resolveSet = make(map[resolveSetItem]struct{})
+	}
+	resolveSet[item] = struct{}{}
+	rec := m.record
+	for _, e := range rec.localExports {
+		if e.exportName == name {
+			return &resolvedBinding{module: m, idx: e.idx}
+		}
+	}
+	for _, e := range rec.indirectExports {
+		if e.exportName == name {
+			imported := r.resolveImportedModule(m, e.moduleRequest)
+			if e.namespace {
+				return &resolvedBinding{module: imported, namespace: true}
+			}
+			return r.resolveExport(imported, e.importName, resolveSet)
+		}
+	}
+	if name == "default" {
+		return nil
+	}
+	var starResolution *resolvedBinding
+	for _, specifier := range rec.starExports {
+		res := r.resolveExport(r.resolveImportedModule(m, specifier), name, resolveSet)
+		if res == ambiguousBinding {
+			return res
+		}
+		if res != nil {
+			if starResolution == nil {
+				starResolution = res
+			} else if *res != *starResolution {
+				return ambiguousBinding
+			}
+		}
+	}
+	return starResolution
+}
+
+// exportedNames returns the names of the exports of m, including the ones that are ambiguous.
+func (r *Runtime) exportedNames(m *moduleInstance, exportStarSet map[*moduleInstance]struct{}) (names []unistring.String) {
+	if _, exists := exportStarSet[m]; exists {
+		return nil
+	}
+	exportStarSet[m] = struct{}{}
+	rec := m.record
+	for _, e := range rec.localExports {
+		names = append(names, e.exportName)
+	}
+	for _, e := range rec.indirectExports {
+		names = append(names, e.exportName)
+	}
+	for _, specifier := range rec.starExports {
+		for _, name := range r.exportedNames(r.resolveImportedModule(m, specifier), exportStarSet) {
+			if name == "default" {
+				continue
+			}
+			found := false
+			for _, n := range names {
+				if n == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				names = append(names, name)
+			}
+		}
+	}
+	return
+}
+
+func (r *Runtime) getModuleNamespace(m *moduleInstance) *Object {
+	if m.namespace == nil {
+		o := &namespaceObject{
+			module:  m,
+			exports: make(map[unistring.String]*resolvedBinding),
+		}
+		for _, name := range r.exportedNames(m, make(map[*moduleInstance]struct{})) {
+			if res := r.resolveExport(m, name, nil); res != nil && res != ambiguousBinding {
+				o.exports[name] = res
+				o.names = append(o.names, name)
+			}
+		}
+		sort.Slice(o.names, func(i, j int) bool {
+			return stringValueFromRaw(o.names[i]).CompareTo(stringValueFromRaw(o.names[j])) < 0
+		})
+		obj := &Object{runtime: r}
+		o.class = classObject
+		o.val = obj
+		obj.self = o
+		o.init()
+		o._putSym(SymToStringTag, valueProp(asciiString("Module"), false, false, false))
+		m.namespace = obj
+	}
+	return m.namespace
+}
+
+// evaluateModule evaluates the module and the modules it imports (see Evaluate() of Cyclic Module Records in
+// the specification). It returns the promise which is settled once the evaluation is complete, which only
+// happens asynchronously if one of the modules uses top-level await.
+func (r *Runtime) evaluateModule(m *moduleInstance) *Promise {
+	if (m.status == moduleEvaluatingAsync || m.status == moduleEvaluated) && m.cycleRoot != nil {
+		m = m.cycleRoot
+	}
+	if m.topLevelCapability == nil {
+		var stack []*moduleInstance
+		capability := r.newPromiseCapability(r.getPromise())
+		m.topLevelCapability = capability
+		defer func() {
+			if x := recover(); x != nil {
+				// The evaluation was aborted (by an uncatchable exception), it may be attempted again
+				for _, m := range stack {
+					m.status = moduleLinked
+				}
+				m.topLevelCapability = nil
+				panic(x)
+			}
+		}()
+		if ex := r.vm.try(func() {
+			r.innerEvaluateModule(m, &stack, 0)
+		}); ex != nil {
+			for _, m := range stack {
+				m.status = moduleEvaluated
+				m.evalErr = ex
+			}
+			stack = nil
+			capability.reject(ex.val)
+		} else if !m.asyncEvaluation {
+			capability.resolve(_undefined)
+		}
+	}
+	return m.topLevelCapability.promise.self.(*Promise)
+}
+
+// cycleRootError returns the error the evaluation of the module (or of the root of its cycle) failed with.
+func (m *moduleInstance) cycleRootError() *Exception {
+	if m.evalErr == nil && m.cycleRoot != nil {
+		return m.cycleRoot.evalErr
+	}
+	return m.evalErr
+}
+
+func (r *Runtime) innerEvaluateModule(m *moduleInstance, stack *[]*moduleInstance, index int) int {
+	switch m.status {
+	case moduleEvaluatingAsync, moduleEvaluated:
+		if m.evalErr != nil {
+			panic(m.evalErr)
+		}
+		return index
+	case moduleEvaluating:
+		return index
+	}
+	m.status = moduleEvaluating
+	m.dfsIndex, m.dfsAncestorIndex = index, index
+	m.pendingAsyncDependencies = 0
+	index++
+	*stack = append(*stack, m)
+	for _, specifier := range m.record.requestedModules {
+		required := r.resolveImportedModule(m, specifier)
+		index = r.innerEvaluateModule(required, stack, index)
+		if required.status == moduleEvaluating {
+			if required.dfsAncestorIndex < m.dfsAncestorIndex {
+				m.dfsAncestorIndex = required.dfsAncestorIndex
+			}
+		} else {
+			required = required.cycleRoot
+			if required.evalErr != nil {
+				panic(required.evalErr)
+			}
+		}
+		if required.asyncEvaluation {
+			m.pendingAsyncDependencies++
+			required.asyncParentModules = append(required.asyncParentModules, m)
+		}
+	}
+	if m.pendingAsyncDependencies > 0 || m.record.hasTLA {
+		m.asyncEvaluation = true
+		r.moduleAsyncEvaluationCount++
+		m.asyncEvaluationOrder = r.moduleAsyncEvaluationCount
+		if m.pendingAsyncDependencies == 0 {
+			r.executeAsyncModule(m)
+		}
+	} else {
+		r.runModuleCode(m, 0)
+	}
+	if m.dfsAncestorIndex == m.dfsIndex {
+		for {
+			l := len(*stack) - 1
+			required := (*stack)[l]
+			*stack = (*stack)[:l]
+			if required.asyncEvaluation {
+				required.status = moduleEvaluatingAsync
+			} else {
+				required.status = moduleEvaluated
+			}
+			required.cycleRoot = m
+			if required == m {
+				break
+			}
+		}
+	}
+	return index
+}
+
+// executeAsyncModule starts running the code of a module using top-level await, as the body of an async
+// function. The evaluation of the modules importing it continues once it's complete.
+func (r *Runtime) executeAsyncModule(m *moduleInstance) {
+	sp := r.vm.sp
+	r.vm.push(_undefined) // callee
+	r.vm.push(_undefined) // this
+	ar := &asyncRunner{
+		r: r,
+		vmCall: func(*vm, int) {
+			r.enterModuleCode(m, 0)
+		},
+	}
+	ar.start(0)
+	r.vm.sp = sp
+	ar.promiseCap.promise.self.(*Promise).addReactions(&promiseReaction{
+		typ: promiseReactionFulfill,
+		handler: &jobCallback{callback: func(FunctionCall) Value {
+			r.asyncModuleExecutionFulfilled(m)
+			return _undefined
+		}},
+	}, &promiseReaction{
+		typ: promiseReactionReject,
+		handler: &jobCallback{callback: func(call FunctionCall) Value {
+			r.asyncModuleExecutionRejected(m, &Exception{val: call.Argument(0)})
+			return _undefined
+		}},
+	})
+}
+
+func (r *Runtime) asyncModuleExecutionFulfilled(m *moduleInstance) {
+	if m.status == moduleEvaluated {
+		// It has failed because one of the modules in its cycle has
+		return
+	}
+	m.asyncEvaluation = false
+	m.status = moduleEvaluated
+	if m.topLevelCapability != nil {
+		m.topLevelCapability.resolve(_undefined)
+	}
+	var execList []*moduleInstance
+	r.gatherAvailableAncestors(m, &execList)
+	sort.Slice(execList, func(i, j int) bool {
+		return execList[i].asyncEvaluationOrder < execList[j].asyncEvaluationOrder
+	})
+	for _, m := range execList {
+		switch {
+		case m.status == moduleEvaluated:
+			// It has failed while the others were running
+		case m.record.hasTLA:
+			r.executeAsyncModule(m)
+		default:
+			if ex := r.vm.try(func() {
+				r.runModuleCode(m, 0)
+			}); ex != nil {
+				r.asyncModuleExecutionRejected(m, ex)
+				continue
+			}
+			m.asyncEvaluation = false
+			m.status = moduleEvaluated
+			if m.topLevelCapability != nil {
+				m.topLevelCapability.resolve(_undefined)
+			}
+		}
+	}
+}
+
+// gatherAvailableAncestors adds the modules importing m which are ready to run now that m is evaluated to
+// execList, as well as the modules importing them which are ready if they don't use top-level await.
+func (r *Runtime) gatherAvailableAncestors(m *moduleInstance, execList *[]*moduleInstance) {
+outer:
+	for _, parent := range m.asyncParentModules {
+		for _, m := range *execList {
+			if m == parent {
+				continue outer
+			}
+		}
+		if parent.cycleRoot.evalErr != nil {
+			continue
+		}
+		parent.pendingAsyncDependencies--
+		if parent.pendingAsyncDependencies == 0 {
+			*execList = append(*execList, parent)
+			if !parent.record.hasTLA {
+				r.gatherAvailableAncestors(parent, execList)
+			}
+		}
+	}
+}
+
+func (r *Runtime) asyncModuleExecutionRejected(m *moduleInstance, ex *Exception) {
+	if m.status == moduleEvaluated {
+		return
+	}
+	m.evalErr = ex
+	m.status = moduleEvaluated
+	for _, parent := range m.asyncParentModules {
+		r.asyncModuleExecutionRejected(parent, ex)
+	}
+	if m.topLevelCapability != nil {
+		m.topLevelCapability.reject(ex.val)
+	}
+}
+
+// enterModuleCode prepares the VM to run the code of the module from pc, in the module environment. The
+// callee and this (both undefined) must be on the stack.
+func (r *Runtime) enterModuleCode(m *moduleInstance, pc int) {
+	vm := r.vm
+	vm.pushCtx()
+	vm.prg = m.record.prg
+	vm.stash = m.env
+	vm.privEnv = nil
+	vm.newTarget = nil
+	vm.args = 0
+	vm.sb = vm.sp - 1
+	vm.pc = pc
+	vm.result = _undefined
+
+	if r.debugger != nil {
+		r.debugger.resolvePendingBreakpoints(vm.prg)
+	}
+}
+
+// runModuleCode runs the code of the module from pc until it halts, in the module environment.
+func (r *Runtime) runModuleCode(m *moduleInstance, pc int) {
+	vm := r.vm
+	vm.push(_undefined) // callee
+	vm.push(_undefined) // this
+	r.enterModuleCode(m, pc)
+
+	ex := vm.runTry()
+	vm.popCtx()
+	if ex != nil {
+		panic(ex)
+	}
+	vm.sp -= 2
+}
+
+// namespaceObject is a module namespace exotic object, its properties are the exports of the module.
+type namespaceObject struct {
+	baseObject
+	module  *moduleInstance
+	exports map[unistring.String]*resolvedBinding
+	names   []unistring.String // sorted
+}
+
+func (o *namespaceObject) init() {
+	o.baseObject.init()
+	o.extensible = false
+}
+
+func (o *namespaceObject) exportValue(b *resolvedBinding) Value {
+	if b.namespace {
+		return o.val.runtime.getModuleNamespace(b.module)
+	}
+	v := b.module.env.values[b.idx&^maskTyp]
+	if v == nil {
+		if b.idx&maskVar == 0 {
+			panic(errAccessBeforeInit)
+		}
+		return _undefined
+	}
+	return v
+}
+
+func (o *namespaceObject) getStr(name unistring.String, receiver Value) Value {
+	if b := o.exports[name]; b != nil {
+		return o.exportValue(b)
+	}
+	return o.baseObject.getStr(name, receiver)
+}
+
+func (o *namespaceObject) getOwnPropStr(name unistring.String) Value {
+	if b := o.exports[name]; b != nil {
+		return &valueProperty{
+			value:      o.exportValue(b),
+			writable:   true,
+			enumerable: true,
+		}
+	}
+	return o.baseObject.getOwnPropStr(name)
+}
+
+// hasOwnPropertyStr gets the value of the export, like [[GetOwnProperty]], so that it throws if the binding
+// is not initialised yet.
+func (o *namespaceObject) hasOwnPropertyStr(name unistring.String) bool {
+	if b := o.exports[name]; b != nil {
+		o.exportValue(b)
+		return true
+	}
+	return false
+}
+
+func (o *namespaceObject) hasPropertyStr(name unistring.String) bool {
+	return o.exports[name] != nil
+}
+
+func (o *namespaceObject) setOwnStr(name unistring.String, _ Value, throw bool) bool {
+	o.val.runtime.typeErrorResult(throw, "Cannot assign to read only property '%s' of module namespace", name)
+	return false
+}
+
+func (o *namespaceObject) setForeignStr(name unistring.String, _, _ Value, throw bool) (bool, bool) {
+	o.val.runtime.typeErrorResult(throw, "Cannot assign to read only property '%s' of module namespace", name)
+	return false, true
+}
+
+func (o *namespaceObject) defineOwnPropertyStr(name unistring.String, descr PropertyDescriptor, throw bool) bool {
+	if b := o.exports[name]; b != nil {
+		if descr.Configurable != FLAG_TRUE && descr.Enumerable != FLAG_FALSE && descr.Writable != FLAG_FALSE &&
+			descr.Getter == nil && descr.Setter == nil && (descr.Value == nil || descr.Value.SameAs(o.exportValue(b))) {
+			return true
+		}
+	}
+	o.val.runtime.typeErrorResult(throw, "Cannot redefine property: %s", name)
+	return false
+}
+
+func (o *namespaceObject) deleteStr(name unistring.String, throw bool) bool {
+	if o.exports[name] != nil {
+		o.val.runtime.typeErrorResult(throw, "Cannot delete property '%s' of module namespace", name)
+		return false
+	}
+	return true
+}
+
+type namespacePropIter struct {
+	o   *namespaceObject
+	idx int
+}
+
+func (i *namespacePropIter) next() (propIterItem, iterNextFunc) {
+	if i.idx < len(i.o.names) {
+		name := i.o.names[i.idx]
+		i.idx++
+		// Same as in stringKeys(), throws if the binding is not initialised yet
+		i.o.exportValue(i.o.exports[name])
+		return propIterItem{name: stringValueFromRaw(name), enumerable: _ENUM_TRUE}, i.next
+	}
+	return propIterItem{}, nil
+}
+
+func (o *namespaceObject) iterateStringKeys() iterNextFunc {
+	return (&namespacePropIter{
+		o: o,
+	}).next
+}
+
+func (o *namespaceObject) stringKeys(all bool, accum []Value) []Value {
+	for _, name := range o.names {
+		if !all {
+			// Checking whether the property is enumerable throws if the binding is not initialised yet
+			o.exportValue(o.exports[name])
+		}
+		accum = append(accum, stringValueFromRaw(name))
+	}
+	return accum
+}
+
+func (o *namespaceObject) export(ctx *objectExportCtx) interface{} {
+	if v, exists := ctx.get(o.val); exists {
+		return v
+	}
+	m := make(map[string]interface{}, len(o.names))
+	ctx.put(o.val, m)
+	for _, name := range o.names {
+		m[name.String()] = exportValue(o.exportValue(o.exports[name]), ctx)
+	}
+	return m
+}
+
+func (o *namespaceObject) exportType() reflect.Type {
+	return reflectTypeMap
+}
+
+func (o *namespaceObject) equal(other objectImpl) bool {
+	return o == other
+}
+
+// HostLoadModuleFunc loads the module imported by the referrer with import(). The referrer is nil if the
+// import() call is in a script. Loading may be asynchronous: finish must be called exactly once, with the
+// module or with the error the import fails with, either before the function returns or later from any
+// goroutine. In the latter case Runtime.RunJobs must be called to complete the import, see
+// Runtime.SetHostLoadModule. Until finish is called, RunJobs keeps waiting for it (or for its context to be
+// done). If the function panics instead, the import is abandoned and later calls of finish are ignored.
+type HostLoadModuleFunc func(referrer *ModuleRecord, specifier string, finish func(*ModuleRecord, error))
+
+// SetHostLoadModule sets the function loading the modules imported with import(). The static imports of
+// the loaded module, and of the modules it imports, are resolved using the function set by
+// SetHostResolveModule, which is also used to load the module itself if no loader is set.
+//
+// import() returns a Promise that is settled once the module is loaded, linked and evaluated. If the loader
+// finishes from another goroutine, the rest of the import runs as a job enqueued with EnqueueJob, so that
+// the Runtime is only used by the goroutine running it:
+//
+//	vm.SetHostLoadModule(func(referrer *goja.ModuleRecord, specifier string, finish func(*goja.ModuleRecord, error)) {
+//	    go func() {
+//	        finish(fetchAndCompile(specifier)) // safe to call from any goroutine
+//	    }()
+//	})
+//	_, err := vm.RunString(`import("plugin.js").then(ns => ns.init())`)
+//	// ...
+//	err = vm.RunJobs(ctx) // waits for the plugin to be loaded and runs the continuation
+func (r *Runtime) SetHostLoadModule(load HostLoadModuleFunc) {
+	r.loadModule = load
+}
+
+func (r *Runtime) loadModuleWithResolver(referrer *ModuleRecord, specifier string, finish func(*ModuleRecord, error)) {
+	if r.resolveModule == nil {
+		finish(nil, nil)
+		return
+	}
+	finish(r.resolveModule(referrer, specifier))
+}
+
+// importModuleDynamically implements import(), it returns the Promise for the namespace of the module.
+func (r *Runtime) importModuleDynamically(referrer *ModuleRecord, specifierValue Value) Value {
+	p, resolve, reject := r.NewPromise()
+	var specifier string
+	if ex := r.vm.try(func() {
+		specifier = specifierValue.toString().String()
+	}); ex != nil {
+		if err := reject(ex.val); err != nil {
+			panic(err)
+		}
+		return p.val
+	}
+	load := r.loadModule
+	if load == nil {
+		load = r.loadModuleWithResolver
+	}
+	var once sync.Once
+	r.hostJobs.hold()
+	defer func() {
+		if x := recover(); x != nil {
+			once.Do(r.hostJobs.release)
+			panic(x)
+		}
+	}()
+	load(referrer, specifier, func(m *ModuleRecord, err error) {
+		once.Do(func() {
+			r.hostJobs.enqueue(func() {
+				var mi *moduleInstance
+				var evaluated *Promise
+				if ex := r.vm.try(func() {
+					if err != nil {
+						panic(r.moduleLoadError(err))
+					}
+					if m == nil {
+						panic(r.NewTypeError("Cannot find module '%s'", specifier))
+					}
+					mi = r.getModuleInstance(m)
+					r.linkModule(mi)
+					evaluated = r.evaluateModule(mi)
+				}); ex != nil {
+					if err := reject(ex.val); err != nil {
+						panic(err)
+					}
+					return
+				}
+				evaluated.addReactions(&promiseReaction{
+					typ: promiseReactionFulfill,
+					handler: &jobCallback{callback: func(FunctionCall) Value {
+						if err := resolve(r.getModuleNamespace(mi)); err != nil {
+							panic(err)
+						}
+						return _undefined
+					}},
+				}, &promiseReaction{
+					typ: promiseReactionReject,
+					handler: &jobCallback{callback: func(call FunctionCall) Value {
+						if err := reject(call.Argument(0)); err != nil {
+							panic(err)
+						}
+						return _undefined
+					}},
+				})
+			}, true)
+		})
+	})
+	return p.val
+}
+
+type importCall struct {
+	referrer *ModuleRecord
+}
+
+func (i *importCall) exec(vm *vm) {
+	vm.stack[vm.sp-1] = vm.r.importModuleDynamically(i.referrer, vm.stack[vm.sp-1])
+	vm.pc++
+}
@@ -0,0 +1,738 @@
+package goja
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testModules struct {
+	t       *testing.T
+	sources map[string]string
+	records map[string]*ModuleRecord
+}
+
+func newTestModules(t *testing.T, sources map[string]string) *testModules {
+	return &testModules{
+		t:       t,
+		sources: sources,
+		records: make(map[string]*ModuleRecord),
+	}
+}
+
+func (m *testModules) get(name string) (*ModuleRecord, error) {
+	if rec := m.records[name]; rec != nil {
+		return rec, nil
+	}
+	src, exists := m.sources[name]
+	if !exists {
+		return nil, fmt.Errorf("no such module: %s", name)
+	}
+	rec, err := CompileModule(name, src)
+	if err != nil {
+		return nil, err
+	}
+	m.records[name] = rec
+	return rec, nil
+}
+
+func (m *testModules) resolve(_ *ModuleRecord, specifier string) (*ModuleRecord, error) {
+	return m.get(specifier)
+}
+
+func (m *testModules) evaluate(r *Runtime, name string) (*Object, error) {
+	rec, err := m.get(name)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	r.SetHostResolveModule(m.resolve)
+	return r.EvaluateModule(rec)
+}
+
+func (m *testModules) run(name string) *Object {
+	ns, err := m.evaluate(New(), name)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	return ns
+}
+
+func TestModuleLiveBindings(t *testing.T) {
+	ns := newTestModules(t, map[string]string{
+		"main.js": `
+		import { count, inc } from "counter.js";
+		import * as counter from "counter.js";
+		const before = count;
+		inc();
+		export const after = count;
+		export const nsCount = counter.count;
+		export { before };
+		`,
+		"counter.js": `
+		export let count = 0;
+		export function inc() {
+			count++;
+		}
+		`,
+	}).run("main.js")
+	if v := ns.Get("before"); !v.SameAs(intToValue(0)) {
+		t.Fatalf("before: %v", v)
+	}
+	if v := ns.Get("after"); !v.SameAs(intToValue(1)) {
+		t.Fatalf("after: %v", v)
+	}
+	if v := ns.Get("nsCount"); !v.SameAs(intToValue(1)) {
+		t.Fatalf("nsCount: %v", v)
+	}
+}
+
+func TestModuleCycle(t *testing.T) {
+	ns := newTestModules(t, map[string]string{
+		"a.js": `
+		import { b, getA } from "b.js";
+		export function a() {
+			return "a";
+		}
+		export const res = b() + getA();
+		`,
+		"b.js": `
+		import { a } from "a.js";
+		export function b() {
+			return "b";
+		}
+		export function getA() {
+			return a();
+		}
+		export const early = a();
+		`,
+	}).run("a.js")
+	if v := ns.Get("res"); v.String() != "ba" {
+		t.Fatalf("res: %v", v)
+	}
+}
+
+func TestModuleCycleTDZ(t *testing.T) {
+	_, err := newTestModules(t, map[string]string{
+		"a.js": `
+		import "b.js";
+		export let x = 1;
+		`,
+		"b.js": `
+		import { x } from "a.js";
+		x;
+		`,
+	}).evaluate(New(), "a.js")
+	var ex *Exception
+	if !errors.As(err, &ex) || !strings.Contains(ex.Error(), "ReferenceError") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestModuleExports(t *testing.T) {
+	mods := newTestModules(t, map[string]string{
+		"main.js": `
+		export * from "a.js";
+		export * from "b.js";
+		export * as nsA from "a.js";
+		export { a as renamed, default as aDefault } from "a.js";
+		import def, { fromB } from "b.js";
+		export { def as bDefault, fromB as "string name" };
+		`,
+		"a.js": `
+		export const a = "a";
+		export const common = "a";
+		export default "default a";
+		`,
+		"b.js": `
+		export const common = "b";
+		export const fromB = "b";
+		export default function () {
+			return "default b";
+		}
+		`,
+	})
+	ns := mods.run("main.js")
+	keys := ns.Keys()
+	if strings.Join(keys, ",") != "a,aDefault,bDefault,fromB,nsA,renamed,string name" {
+		t.Fatalf("Unexpected keys: %v", keys)
+	}
+	if v := ns.Get("common"); v != nil {
+		t.Fatalf("Ambiguous export should not be present: %v", v)
+	}
+	if v := ns.Get("renamed"); v.String() != "a" {
+		t.Fatalf("renamed: %v", v)
+	}
+	if v := ns.Get("aDefault"); v.String() != "default a" {
+		t.Fatalf("aDefault: %v", v)
+	}
+	if v := ns.Get("nsA").ToObject(nil).Get("a"); v.String() != "a" {
+		t.Fatalf("nsA.a: %v", v)
+	}
+	f, ok := AssertFunction(ns.Get("bDefault"))
+	if !ok {
+		t.Fatal("bDefault is not a function")
+	}
+	if v, err := f(nil); err != nil || v.String() != "default b" {
+		t.Fatalf("bDefault(): %v, %v", v, err)
+	}
+	if v := ns.Get("bDefault").ToObject(nil).Get("name"); v.String() != "default" {
+		t.Fatalf("bDefault.name: %v", v)
+	}
+}
+
+func TestModuleAmbiguousImport(t *testing.T) {
+	_, err := newTestModules(t, map[string]string{
+		"main.js": `import { common } from "star.js";`,
+		"star.js": `
+		export * from "a.js";
+		export * from "b.js";
+		`,
+		"a.js": `export const common = 1;`,
+		"b.js": `export const common = 2;`,
+	}).evaluate(New(), "main.js")
+	if err == nil || !strings.Contains(err.Error(), "conflicting star exports for name 'common'") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestModuleMissingExport(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"main.js": `import { missing } from "a.js";`,
+		"a.js":    `export const present = 1;`,
+	})
+	_, err := mods.evaluate(r, "main.js")
+	if err == nil || !strings.Contains(err.Error(), "does not provide an export named 'missing'") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Linking is attempted again
+	_, err = mods.evaluate(r, "main.js")
+	if err == nil || !strings.Contains(err.Error(), "does not provide an export named 'missing'") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestModuleNamespaceObject(t *testing.T) {
+	ns := newTestModules(t, map[string]string{
+		"main.js": `
+		import * as ns from "a.js";
+		const res = [];
+		res.push(Object.prototype.toString.call(ns));
+		res.push(Object.getPrototypeOf(ns) === null);
+		res.push(Object.isExtensible(ns));
+		res.push(JSON.stringify(Object.getOwnPropertyDescriptor(ns, "x")));
+		try {
+			ns.x = 2;
+		} catch (e) {
+			res.push(e instanceof TypeError);
+		}
+		try {
+			delete ns.x;
+		} catch (e) {
+			res.push(e instanceof TypeError);
+		}
+		res.push(delete ns.missing);
+		res.push("x" in ns, "missing" in ns);
+		res.push(Reflect.defineProperty(ns, "x", {value: 1}), Reflect.defineProperty(ns, "x", {value: 2}));
+		export const result = res.join();
+		`,
+		"a.js": `export var x = 1;`,
+	}).run("main.js")
+	const expected = `[object Module],true,false,{"value":1,"writable":true,"enumerable":true,"configurable":false},true,true,true,true,false,true,false`
+	if v := ns.Get("result"); v.String() != expected {
+		t.Fatalf("Unexpected result: %v", v)
+	}
+	if exp := ns.Export(); fmt.Sprint(exp) != "map[result:"+expected+"]" {
+		t.Fatalf("Unexpected export: %v", exp)
+	}
+}
+
+func TestModuleImportMeta(t *testing.T) {
+	r := New()
+	r.SetHostInitializeImportMeta(func(meta *Object, m *ModuleRecord) {
+		_ = meta.Set("url", "file:///"+m.Name())
+	})
+	ns, err := newTestModules(t, map[string]string{
+		"main.js": `
+		export const url = import.meta.url;
+		export const same = (() => import.meta)() === import.meta;
+		export const proto = Object.getPrototypeOf(import.meta);
+		`,
+	}).evaluate(r, "main.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ns.Get("url"); v.String() != "file:///main.js" {
+		t.Fatalf("url: %v", v)
+	}
+	if v := ns.Get("same"); !v.SameAs(valueTrue) {
+		t.Fatalf("same: %v", v)
+	}
+	if v := ns.Get("proto"); !v.SameAs(_null) {
+		t.Fatalf("proto: %v", v)
+	}
+
+	if _, err := Compile("test.js", "import.meta", false); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestModuleEvaluateOnce(t *testing.T) {
+	r := New()
+	var calls int
+	_ = r.Set("called", func() {
+		calls++
+	})
+	mods := newTestModules(t, map[string]string{
+		"main.js": `
+		import "a.js";
+		import "b.js";
+		`,
+		"a.js": `import "c.js";`,
+		"b.js": `import "c.js";`,
+		"c.js": `called();`,
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := mods.evaluate(r, "main.js"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("calls: %d", calls)
+	}
+}
+
+func TestModuleEvaluationError(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"main.js": `import "a.js";`,
+		"a.js":    `throw new Error("boom");`,
+	})
+	_, err := mods.evaluate(r, "main.js")
+	var ex *Exception
+	if !errors.As(err, &ex) || !strings.Contains(ex.Error(), "boom") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The same error is returned again
+	_, err1 := mods.evaluate(r, "main.js")
+	var ex1 *Exception
+	if !errors.As(err1, &ex1) || ex1.Value() != ex.Value() {
+		t.Fatalf("Unexpected error: %v", err1)
+	}
+}
+
+func TestModuleTopLevelAwait(t *testing.T) {
+	r := New()
+	_, _ = r.RunString(`var order = [];`)
+	mods := newTestModules(t, map[string]string{
+		"main.js": `
+		import { x } from "a.js";
+		import "b.js";
+		order.push("main");
+		export const y = x;
+		`,
+		"a.js": `
+		import "c.js";
+		export let x = 1;
+		order.push("a");
+		await Promise.resolve();
+		x = await new Promise(resolve => resolve(2));
+		order.push("a done");
+		`,
+		"b.js": `order.push("b");`,
+		"c.js": `
+		for await (const v of [Promise.resolve("c")]) {
+			order.push(v);
+		}
+		`,
+	})
+	ns, err := mods.evaluate(r, "main.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y := ns.Get("y"); y.ToInteger() != 2 {
+		t.Fatalf("y: %v", y)
+	}
+	if v := r.Get("order").String(); v != "b,c,a,a done,main" {
+		t.Fatalf("Unexpected order: %s", v)
+	}
+}
+
+func TestModuleTopLevelAwaitRejected(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"main.js": `import "a.js"; throw new Error("not reached");`,
+		"a.js":    `await null; throw new Error("boom");`,
+	})
+	_, err := mods.evaluate(r, "main.js")
+	var ex *Exception
+	if !errors.As(err, &ex) || !strings.Contains(ex.Error(), "boom") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err1 := mods.evaluate(r, "main.js")
+	var ex1 *Exception
+	if !errors.As(err1, &ex1) || ex1.Value() != ex.Value() {
+		t.Fatalf("Unexpected error: %v", err1)
+	}
+}
+
+func TestModuleTopLevelAwaitPending(t *testing.T) {
+	r := New()
+	p, resolve, _ := r.NewPromise()
+	_ = r.Set("p", p)
+	mods := newTestModules(t, map[string]string{
+		"main.js": `export const v = await p;`,
+	})
+	if _, err := mods.evaluate(r, "main.js"); err != ErrModuleEvaluationPending {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rec, _ := mods.get("main.js")
+	evaluated, err := r.EvaluateModuleAsync(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go r.EnqueueJob(func() {
+		if err := resolve("done"); err != nil {
+			panic(err)
+		}
+	})
+	for evaluated.State() == PromiseStatePending {
+		if err := r.RunJobs(gocontext.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if evaluated.State() != PromiseStateFulfilled {
+		t.Fatalf("Unexpected state: %v (%v)", evaluated.State(), evaluated.Result())
+	}
+	if v := evaluated.Result().(*Object).Get("v"); v.String() != "done" {
+		t.Fatalf("v: %v", v)
+	}
+	if ns, err := r.EvaluateModule(rec); err != nil || ns.Get("v").String() != "done" {
+		t.Fatalf("Unexpected result: %v, %v", ns, err)
+	}
+}
+
+func TestDynamicImportTopLevelAwait(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"a.js": `export const x = await Promise.resolve(1);`,
+	})
+	r.SetHostResolveModule(mods.resolve)
+	_, err := r.RunString(`
+	var res = [];
+	import("a.js").then(ns => res.push(ns.x));
+	import("a.js").then(ns => res.push(ns.x));
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Get("res").String(); v != "1,1" {
+		t.Fatalf("Unexpected result: %s", v)
+	}
+}
+
+func TestModuleUsing(t *testing.T) {
+	ns := newTestModules(t, map[string]string{
+		"main.js": `
+		import { log } from "log.js";
+		using res = {
+			[Symbol.dispose]() {
+				log.push("disposed");
+			}
+		};
+		log.push("body");
+		export { log };
+		`,
+		"log.js": `export const log = [];`,
+	}).run("main.js")
+	if v := ns.Get("log").Export(); !reflect.DeepEqual(v, []interface{}{"body", "disposed"}) {
+		t.Fatalf("log: %v", v)
+	}
+}
+
+func TestModuleResolveError(t *testing.T) {
+	r := New()
+	_, err := newTestModules(t, map[string]string{
+		"main.js": `import "missing.js";`,
+	}).evaluate(r, "main.js")
+	if err == nil || !strings.Contains(err.Error(), "no such module: missing.js") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	m, err := CompileModule("main.js", `import "a.js";`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New().EvaluateModule(m); err == nil || !strings.Contains(err.Error(), "Cannot find module 'a.js'") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestModuleStrictAndScope(t *testing.T) {
+	r := New()
+	_ = r.Set("global1", 1)
+	ns, err := newTestModules(t, map[string]string{
+		"main.js": `
+		var v = 1;
+		export const thisValue = this;
+		export const global = global1;
+		export let strict;
+		try {
+			undeclared = 1;
+		} catch (e) {
+			strict = e instanceof ReferenceError;
+		}
+		export const varIsGlobal = globalThis.hasOwnProperty("v");
+		`,
+	}).evaluate(r, "main.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ns.Get("thisValue"); v != _undefined {
+		t.Fatalf("thisValue: %v", v)
+	}
+	if v := ns.Get("global"); !v.SameAs(intToValue(1)) {
+		t.Fatalf("global: %v", v)
+	}
+	if v := ns.Get("strict"); !v.SameAs(valueTrue) {
+		t.Fatalf("strict: %v", v)
+	}
+	if v := ns.Get("varIsGlobal"); !v.SameAs(valueFalse) {
+		t.Fatalf("varIsGlobal: %v", v)
+	}
+}
+
+func TestModuleImportIsConst(t *testing.T) {
+	_, err := newTestModules(t, map[string]string{
+		"main.js": `
+		import { x } from "a.js";
+		x = 2;
+		`,
+		"a.js": `export let x = 1;`,
+	}).evaluate(New(), "main.js")
+	if err == nil || !strings.Contains(err.Error(), "Assignment to constant variable") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestModuleCompileErrors(t *testing.T) {
+	for _, src := range []string{
+		`export { x };`,
+		`export const a = 1; export { a };`,
+		`import { a } from "a.js"; let a;`,
+		`import { a, b as a } from "a.js";`,
+		`export default 1; export default 2;`,
+		`new.target`,
+		`return;`,
+		`var await;`,
+		`with ({}) {}`,
+		`if (true) { import "a.js"; }`,
+		`import { "string" } from "a.js";`,
+		`export { "string" };`,
+		`function f() { await 1; }`,
+		`() => await 1;`,
+		`class C { static { await 1; } }`,
+	} {
+		if _, err := CompileModule("test.js", src); err == nil {
+			t.Fatalf("%s: expected an error", src)
+		}
+	}
+}
+
+func TestDynamicImport(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"a.js": `
+		export const x = 1;
+		export default "a";
+		`,
+		"throws.js":  `throw new Error("boom");`,
+		"invalid.js": `export {`,
+	})
+	r.SetHostResolveModule(mods.resolve)
+	_, err := r.RunString(`
+	var res = [];
+	import("a.js").then(ns => res.push(ns.x, ns.default));
+	import("invalid.js").catch(e => res.push(e instanceof SyntaxError));
+	import("a.js").then(ns => import("a.js").then(ns1 => res.push(ns === ns1)));
+	import("missing.js").catch(e => res.push(e.message));
+	import("throws.js").catch(e => res.push(e.message));
+	import({toString() { throw new Error("toString"); }}).catch(e => res.push(e.message));
+	import(Symbol()).catch(e => res.push(e instanceof TypeError));
+	res.push(import("a.js") instanceof Promise);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Get("res").String(); v != "true,toString,true,1,a,true,no such module: missing.js,boom,true" {
+		t.Fatalf("Unexpected result: %s", v)
+	}
+}
+
+func TestDynamicImportReferrer(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"main.js": `
+		export function load() {
+			return import("a.js");
+		}
+		`,
+		"a.js": `export const x = 1;`,
+	})
+	var referrers []string
+	r.SetHostLoadModule(func(referrer *ModuleRecord, specifier string, finish func(*ModuleRecord, error)) {
+		name := "<script>"
+		if referrer != nil {
+			name = referrer.Name()
+		}
+		referrers = append(referrers, name)
+		finish(mods.get(specifier))
+	})
+	if _, err := mods.evaluate(r, "main.js"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := r.RunString(`import("main.js").then(ns => ns.load()).then(ns => { res = ns.x; })`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Get("res"); v == nil || !v.SameAs(intToValue(1)) {
+		t.Fatalf("Unexpected result: %v", v)
+	}
+	if strings.Join(referrers, ",") != "<script>,main.js" {
+		t.Fatalf("Unexpected referrers: %v", referrers)
+	}
+}
+
+func TestDynamicImportAsync(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"a.js": `export const x = 1;`,
+	})
+	r.SetHostLoadModule(func(referrer *ModuleRecord, specifier string, finish func(*ModuleRecord, error)) {
+		// Compile the module in the background
+		go func() {
+			src, exists := mods.sources[specifier]
+			if !exists {
+				finish(nil, fmt.Errorf("no such module: %s", specifier))
+				return
+			}
+			finish(CompileModule(specifier, src))
+		}()
+	})
+	_, err := r.RunString(`
+	var res = [];
+	import("a.js").then(ns => res.push(ns.x));
+	import("missing.js").catch(e => res.push(e.message));
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RunJobs(gocontext.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Get("res").Export().([]interface{}); len(v) != 2 {
+		t.Fatalf("Unexpected result: %v", v)
+	}
+}
+
+func TestDynamicImportLoaderPanic(t *testing.T) {
+	r := New()
+	var finish func(*ModuleRecord, error)
+	r.SetHostLoadModule(func(referrer *ModuleRecord, specifier string, f func(*ModuleRecord, error)) {
+		finish = f
+		panic(r.NewTypeError("cannot load %s", specifier))
+	})
+	_, err := r.RunString(`
+	try {
+		import("a.js");
+	} catch (e) {
+		if (!(e instanceof TypeError)) {
+			throw e;
+		}
+	}
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The import has been abandoned, there is nothing to wait for
+	finish(nil, nil)
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), time.Second)
+	defer cancel()
+	if err := r.RunJobs(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunJobs(t *testing.T) {
+	r := New()
+	p, resolve, _ := r.NewPromise()
+	_ = r.Set("p", p)
+	_, err := r.RunString(`var res; p.then(v => { res = v; })`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go r.EnqueueJob(func() {
+		if err := resolve("done"); err != nil {
+			panic(err)
+		}
+	})
+	// Nothing to wait for
+	for r.Get("res") == _undefined {
+		if err := r.RunJobs(gocontext.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := r.Get("res"); v.String() != "done" {
+		t.Fatalf("Unexpected result: %v", v)
+	}
+
+	// Waiting for a module that never loads
+	r.SetHostLoadModule(func(*ModuleRecord, string, func(*ModuleRecord, error)) {})
+	if _, err := r.RunString(`import("never.js")`); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.RunJobs(ctx); err != gocontext.DeadlineExceeded {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRunJobsFailedJob(t *testing.T) {
+	r := New()
+	mods := newTestModules(t, map[string]string{
+		"a.js": `export const x = 1;`,
+	})
+	var finish func(*ModuleRecord, error)
+	r.SetHostLoadModule(func(_ *ModuleRecord, _ string, f func(*ModuleRecord, error)) {
+		finish = f
+	})
+	_, err := r.RunString(`
+	var res;
+	import("a.js").then(ns => { res = ns.x; });
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A job of the loader fails before a.js is loaded
+	r.EnqueueJob(func() {
+		panic(r.NewTypeError("cannot load b.js"))
+	})
+	finish(mods.get("a.js"))
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), time.Second)
+	defer cancel()
+	if err := r.RunJobs(ctx); err == nil || !strings.Contains(err.Error(), "cannot load b.js") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The import is still settled
+	if err := r.RunJobs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Get("res"); v == nil || !v.SameAs(intToValue(1)) {
+		t.Fatalf("Unexpected result: %v", v)
+	}
+}
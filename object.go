@@ -29,6 +29,11 @@ const (
 	classGlobal        = "global"
 	classPromise       = "Promise"
 
+	classDisposableStack      = "DisposableStack"
+	classAsyncDisposableStack = "AsyncDisposableStack"
+
+	classIterator             = "Iterator"
+	classIteratorHelper       = "Iterator Helper"
 	classArrayIterator        = "Array Iterator"
 	classMapIterator          = "Map Iterator"
 	classSetIterator          = "Set Iterator"
@@ -37,6 +42,9 @@ const (
 
 	classGenerator         = "Generator"
 	classGeneratorFunction = "GeneratorFunction"
+
+	classAsyncGenerator         = "AsyncGenerator"
+	classAsyncGeneratorFunction = "AsyncGeneratorFunction"
 )
 
 var (
@@ -273,6 +281,9 @@ func (f ConstructorCall) Argument(idx int) Value {
 
 func (o *baseObject) init() {
 	o.values = make(map[unistring.String]Value)
+	if o.val != nil && o.val.runtime != nil {
+		o.val.runtime.trackObject(o.val)
+	}
 }
 
 func (o *baseObject) className() string {
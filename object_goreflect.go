@@ -190,6 +190,16 @@ func (o *objectGoReflect) init() {
 	if j, ok := o.origValue.Interface().(JsonEncodable); ok {
 		o.toJson = j.JsonEncodable
 	}
+
+	if d, ok := o.methodsValue.Interface().(Disposable); ok {
+		r := o.val.runtime
+		o._putSym(SymDispose, valueProp(r.newNativeFunc(func(FunctionCall) Value {
+			if err := d.Dispose(); err != nil {
+				panic(r.NewGoError(err))
+			}
+			return _undefined
+		}, "[Symbol.dispose]", 0), true, false, true))
+	}
 }
 
 func (o *objectGoReflect) getStr(name unistring.String, receiver Value) Value {
@@ -108,7 +108,7 @@ func (self *_parser) errorUnexpectedToken(tkn token.Token) error {
 		value = self.literal
 	case token.IDENTIFIER:
 		return self.error(self.idx, "Unexpected identifier")
-	case token.KEYWORD:
+	case token.KEYWORD, token.IMPORT, token.EXPORT:
 		// TODO Might be a future reserved word
 		return self.error(self.idx, "Unexpected reserved word")
 	case token.ESCAPED_RESERVED_WORD:
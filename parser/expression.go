@@ -95,6 +95,13 @@ func (self *_parser) parsePrimaryExpression() ast.Expression {
 		return self.parseFunction(false, false, idx)
 	case token.CLASS:
 		return self.parseClass(false)
+	case token.IMPORT:
+		switch self.peek() {
+		case token.PERIOD:
+			return self.parseImportMeta()
+		case token.LEFT_PARENTHESIS:
+			return self.parseImportCall()
+		}
 	}
 
 	if self.isBindingId(self.token) {
@@ -263,7 +270,8 @@ func (self *_parser) isBindingId(tok token.Token) bool {
 	}
 
 	if tok == token.AWAIT {
-		return !self.scope.allowAwait
+		// await is reserved in modules
+		return !self.scope.allowAwait && !self.module
 	}
 	if tok == token.YIELD {
 		return !self.scope.allowYield
@@ -446,6 +454,10 @@ func (self *_parser) parseObjectProperty() ast.Property {
 				self.errorUnexpectedToken(self.token)
 			}
 		case (literal == "get" || literal == "set" || tkn == token.ASYNC) && self.token != token.COLON:
+			if tkn == token.ASYNC && self.token == token.MULTIPLY {
+				generator = true
+				self.next()
+			}
 			_, _, keyValue, tkn1 := self.parseObjectPropertyKey()
 			if keyValue == nil {
 				return nil
@@ -465,7 +477,7 @@ func (self *_parser) parseObjectProperty() ast.Property {
 			return &ast.PropertyKeyed{
 				Key:      keyValue,
 				Kind:     kind,
-				Value:    self.parseMethodDefinition(keyStartIdx, kind, false, async),
+				Value:    self.parseMethodDefinition(keyStartIdx, kind, generator, async),
 				Computed: tkn1 == token.ILLEGAL,
 			}
 		}
@@ -706,6 +718,10 @@ func (self *_parser) parseNewExpression() ast.Expression {
 		}
 		self.errorUnexpectedToken(token.IDENTIFIER)
 	}
+	if self.token == token.IMPORT && self.peek() == token.LEFT_PARENTHESIS {
+		// import() is not a constructor
+		self.errorUnexpectedToken(self.token)
+	}
 	callee := self.parseLeftHandSideExpression()
 	if bad, ok := callee.(*ast.BadExpression); ok {
 		bad.From = idx
@@ -222,6 +222,32 @@ func (self *_parser) peek() token.Token {
 	return tok
 }
 
+// isUsingDeclaration reports whether the current token starts a 'using' declaration (or an 'await using'
+// declaration if await is set), i.e. whether it's followed by a binding identifier on the same line. In the
+// head of a for statement 'using of' is only a declaration if it's followed by an initializer.
+func (self *_parser) isUsingDeclaration(await, forHead bool) bool {
+	implicitSemicolon, insertSemicolon, chr, chrOffset, offset := self.implicitSemicolon, self.insertSemicolon, self.chr, self.chrOffset, self.offset
+	defer func() {
+		self.implicitSemicolon, self.insertSemicolon, self.chr, self.chrOffset, self.offset = implicitSemicolon, insertSemicolon, chr, chrOffset, offset
+	}()
+	if await {
+		if tok, literal, _, _ := self.scan(); tok != token.IDENTIFIER || literal != "using" || self.implicitSemicolon {
+			return false
+		}
+	}
+	tok, literal, _, _ := self.scan()
+	if self.implicitSemicolon || !self.isBindingId(tok) {
+		// e.g. 'using in x' and 'using instanceof X' are expressions
+		return false
+	}
+	if forHead && tok == token.IDENTIFIER && literal == "of" {
+		// 'for (using of of x)' and 'for (using of x)' are not declarations, but 'for (using of = x;;)' is
+		tok, _, _, _ = self.scan()
+		return tok == token.ASSIGN
+	}
+	return true
+}
+
 func (self *_parser) scan() (tkn token.Token, literal string, parsedLiteral unistring.String, idx file.Idx) {
 
 	self.implicitSemicolon = false
@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"unicode/utf16"
+
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/file"
+	"github.com/dop251/goja/token"
+)
+
+// ParseModule parses the source code of an ECMAScript module and returns the corresponding ast.Program
+// node. The arguments are the same as for ParseFile.
+//
+// Unlike ParseFile, it accepts import and export declarations at the top level and import.meta. Module
+// code is always strict, this is enforced by the compiler.
+func ParseModule(fileSet *file.FileSet, filename string, src interface{}, mode Mode, options ...Option) (*ast.Program, error) {
+	str, err := ReadSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	{
+		str := string(str)
+
+		base := 1
+		if fileSet != nil {
+			base = fileSet.AddFile(filename, str)
+		}
+
+		parser := _newParser(filename, str, base, options...)
+		parser.mode = mode
+		parser.module = true
+		return parser.parse()
+	}
+}
+
+func (self *_parser) parseModuleItem() ast.Statement {
+	switch self.token {
+	case token.IMPORT:
+		// import(...) and import.meta are expressions
+		if tok := self.peek(); tok != token.LEFT_PARENTHESIS && tok != token.PERIOD {
+			return self.parseImportDeclaration()
+		}
+	case token.EXPORT:
+		return self.parseExportDeclaration()
+	}
+	return self.parseStatement()
+}
+
+func (self *_parser) isContextual(name string) bool {
+	return self.token == token.IDENTIFIER && self.literal == name
+}
+
+func (self *_parser) expectContextual(name string) {
+	if !self.isContextual(name) {
+		self.errorUnexpectedToken(self.token)
+	}
+	self.next()
+}
+
+func (self *_parser) parseModuleSpecifier() *ast.StringLiteral {
+	idx, literal, parsedLiteral := self.idx, self.literal, self.parsedLiteral
+	self.expect(token.STRING)
+	return &ast.StringLiteral{
+		Idx:     idx,
+		Literal: literal,
+		Value:   parsedLiteral,
+	}
+}
+
+func (self *_parser) parseModuleExportName() *ast.ModuleExportName {
+	name := &ast.ModuleExportName{
+		Idx:     self.idx,
+		Literal: self.literal,
+		Name:    self.parsedLiteral,
+	}
+	if self.token == token.STRING {
+		if !isWellFormed(name.Name.AsUtf16()) {
+			self.error(name.Idx, "Invalid module export name: contains unpaired surrogate")
+		}
+	} else if !token.IsId(self.token) {
+		self.errorUnexpectedToken(self.token)
+	}
+	self.next()
+	return name
+}
+
+// isWellFormed returns true if s doesn't contain unpaired surrogates.
+func isWellFormed(s []uint16) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; utf16.IsSurrogate(rune(c)) {
+			if c >= 0xDC00 || i+1 >= len(s) || s[i+1] < 0xDC00 || s[i+1] > 0xDFFF {
+				return false
+			}
+			i++
+		}
+	}
+	return true
+}
+
+func (self *_parser) parseImportedBinding() *ast.Identifier {
+	self.tokenToBindingId()
+	if self.token != token.IDENTIFIER {
+		self.errorUnexpectedToken(self.token)
+	}
+	return self.parseIdentifier()
+}
+
+func (self *_parser) parseImportDeclaration() *ast.ImportDeclaration {
+	node := &ast.ImportDeclaration{
+		Import: self.expect(token.IMPORT),
+	}
+	if self.token == token.STRING {
+		node.ModuleSpecifier = self.parseModuleSpecifier()
+		self.semicolon()
+		return node
+	}
+
+	if self.isBindingId(self.token) {
+		node.Default = self.parseImportedBinding()
+		if self.token != token.COMMA {
+			goto from
+		}
+		self.next()
+	}
+
+	switch self.token {
+	case token.MULTIPLY:
+		self.next()
+		self.expectContextual("as")
+		node.Namespace = self.parseImportedBinding()
+	case token.LEFT_BRACE:
+		self.next()
+		for self.token != token.RIGHT_BRACE && self.token != token.EOF {
+			tkn := self.token
+			spec := &ast.ImportSpecifier{
+				ImportName: self.parseModuleExportName(),
+			}
+			if self.isContextual("as") {
+				self.next()
+				spec.Local = self.parseImportedBinding()
+			} else {
+				if !self.isBindingId(tkn) {
+					self.error(spec.ImportName.Idx, "Unexpected reserved word")
+				}
+				spec.Local = &ast.Identifier{
+					Idx:  spec.ImportName.Idx,
+					Name: spec.ImportName.Name,
+				}
+			}
+			node.Specifiers = append(node.Specifiers, spec)
+			if self.token != token.RIGHT_BRACE {
+				self.expect(token.COMMA)
+			}
+		}
+		self.expect(token.RIGHT_BRACE)
+	default:
+		self.errorUnexpectedToken(self.token)
+	}
+
+from:
+	self.expectContextual("from")
+	node.ModuleSpecifier = self.parseModuleSpecifier()
+	self.semicolon()
+	return node
+}
+
+func (self *_parser) parseExportDeclaration() *ast.ExportDeclaration {
+	node := &ast.ExportDeclaration{
+		Export: self.expect(token.EXPORT),
+	}
+	switch self.token {
+	case token.MULTIPLY:
+		self.next()
+		node.Star = true
+		if self.isContextual("as") {
+			self.next()
+			node.Namespace = self.parseModuleExportName()
+		}
+		self.expectContextual("from")
+		node.ModuleSpecifier = self.parseModuleSpecifier()
+		self.semicolon()
+	case token.LEFT_BRACE:
+		self.next()
+		var locals []token.Token
+		for self.token != token.RIGHT_BRACE && self.token != token.EOF {
+			locals = append(locals, self.token)
+			spec := &ast.ExportSpecifier{
+				Local: self.parseModuleExportName(),
+			}
+			if self.isContextual("as") {
+				self.next()
+				spec.Exported = self.parseModuleExportName()
+			} else {
+				spec.Exported = spec.Local
+			}
+			node.Specifiers = append(node.Specifiers, spec)
+			if self.token != token.RIGHT_BRACE {
+				self.expect(token.COMMA)
+			}
+		}
+		node.RightBrace = self.expect(token.RIGHT_BRACE)
+		if self.isContextual("from") {
+			self.next()
+			node.ModuleSpecifier = self.parseModuleSpecifier()
+		} else {
+			// Without a module specifier, the exported bindings are local and must be referenced by identifiers
+			for i, spec := range node.Specifiers {
+				if tkn := locals[i]; tkn == token.STRING {
+					self.error(spec.Local.Idx, "Unexpected string")
+				} else if !self.isBindingId(tkn) {
+					self.error(spec.Local.Idx, "Unexpected reserved word")
+				}
+			}
+		}
+		self.semicolon()
+	case token.DEFAULT:
+		self.next()
+		node.Default = true
+		switch self.token {
+		case token.FUNCTION:
+			node.Declaration = &ast.FunctionDeclaration{
+				Function: self.parseFunction(false, false, self.idx),
+			}
+			return node
+		case token.ASYNC:
+			if f := self.parseMaybeAsyncFunction(false); f != nil {
+				node.Declaration = &ast.FunctionDeclaration{
+					Function: f,
+				}
+				return node
+			}
+		case token.CLASS:
+			node.Declaration = &ast.ClassDeclaration{
+				Class: self.parseClass(false),
+			}
+			return node
+		}
+		node.Expression = self.parseAssignmentExpression()
+		self.semicolon()
+	case token.VAR:
+		node.Declaration = self.parseVariableStatement()
+	case token.LET, token.CONST:
+		node.Declaration = self.parseLexicalDeclaration(self.token)
+	case token.FUNCTION:
+		node.Declaration = &ast.FunctionDeclaration{
+			Function: self.parseFunction(true, false, self.idx),
+		}
+	case token.ASYNC:
+		if f := self.parseMaybeAsyncFunction(true); f != nil {
+			node.Declaration = &ast.FunctionDeclaration{
+				Function: f,
+			}
+			break
+		}
+		self.errorUnexpectedToken(self.token)
+		self.nextStatement()
+	case token.CLASS:
+		node.Declaration = &ast.ClassDeclaration{
+			Class: self.parseClass(true),
+		}
+	default:
+		self.errorUnexpectedToken(self.token)
+		self.nextStatement()
+	}
+	return node
+}
+
+func (self *_parser) parseImportMeta() ast.Expression {
+	idx := self.expect(token.IMPORT)
+	self.expect(token.PERIOD)
+	if !self.module {
+		self.error(idx, "Cannot use 'import.meta' outside a module")
+	} else if self.literal != "meta" {
+		self.errorUnexpectedToken(self.token)
+	} else {
+		return &ast.MetaProperty{
+			Meta: &ast.Identifier{
+				Name: "import",
+				Idx:  idx,
+			},
+			Property: self.parseIdentifier(),
+			Idx:      idx,
+		}
+	}
+	self.nextStatement()
+	return &ast.BadExpression{From: idx, To: self.idx}
+}
+
+func (self *_parser) parseImportCall() ast.Expression {
+	node := &ast.ImportCall{
+		Import: self.expect(token.IMPORT),
+	}
+	self.expect(token.LEFT_PARENTHESIS)
+	node.Argument = self.parseAssignmentExpression()
+	node.RightParenthesis = self.expect(token.RIGHT_PARENTHESIS)
+	return node
+}
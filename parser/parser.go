@@ -103,8 +103,9 @@ type _parser struct {
 		count int
 	}
 
-	mode Mode
-	opts options
+	mode   Mode
+	opts   options
+	module bool // Parsing an ECMAScript module, see ParseModule
 
 	file *file.File
 }
@@ -211,6 +212,11 @@ func (self *_parser) slice(idx0, idx1 file.Idx) string {
 func (self *_parser) parse() (*ast.Program, error) {
 	self.openScope()
 	defer self.closeScope()
+	if self.module {
+		// top-level await
+		self.scope.inAsync = true
+		self.scope.allowAwait = true
+	}
 	self.next()
 	program := self.parseProgram()
 	if false {
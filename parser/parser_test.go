@@ -56,6 +56,89 @@ func TestParseFile(t *testing.T) {
 	})
 }
 
+func TestParseModule(t *testing.T) {
+	tt(t, func() {
+		test := func(src string, expect interface{}) *ast.Program {
+			program, err := ParseModule(nil, "", src, 0)
+			is(firstErr(err), expect)
+			return program
+		}
+
+		program := test(`
+			import def, * as ns from "a";
+			import { a, b as c, "d" as e } from "b";
+			import "c";
+			export * from "d";
+			export * as f from "e";
+			export { a as g, c as "h" };
+			export { i, j as default } from "f";
+			export var k = 1;
+			export default function () {}
+		`, nil)
+		is(len(program.Body), 9)
+		{
+			decl := program.Body[0].(*ast.ImportDeclaration)
+			is(decl.Default.Name, "def")
+			is(decl.Namespace.Name, "ns")
+			is(decl.ModuleSpecifier.Value, "a")
+		}
+		{
+			decl := program.Body[1].(*ast.ImportDeclaration)
+			is(len(decl.Specifiers), 3)
+			is(decl.Specifiers[0].ImportName.Name, "a")
+			is(decl.Specifiers[0].Local.Name, "a")
+			is(decl.Specifiers[1].Local.Name, "c")
+			is(decl.Specifiers[2].ImportName.Name, "d")
+			is(decl.Specifiers[2].Local.Name, "e")
+		}
+		{
+			decl := program.Body[4].(*ast.ExportDeclaration)
+			is(decl.Star, true)
+			is(decl.Namespace.Name, "f")
+		}
+		{
+			decl := program.Body[5].(*ast.ExportDeclaration)
+			is(decl.Specifiers[1].Local.Name, "c")
+			is(decl.Specifiers[1].Exported.Name, "h")
+		}
+		{
+			decl := program.Body[8].(*ast.ExportDeclaration)
+			is(decl.Default, true)
+			is(decl.Declaration.(*ast.FunctionDeclaration).Function.Name == nil, true)
+		}
+
+		test(`export default 1 + 2;`, nil)
+		test(`export default class {}`, nil)
+		test(`export default async function f() {}`, nil)
+		test(`import.meta.url`, nil)
+		test(`import("a").then(f)`, nil)
+		test(`import(a + "b", )`, "(anonymous): Line 1:15 Unexpected token ,")
+		test(`function f() { return import.meta; }`, nil)
+
+		test(`import { default } from "a";`, "(anonymous): Line 1:10 Unexpected reserved word")
+		test(`import { "a" } from "a";`, "(anonymous): Line 1:10 Unexpected reserved word")
+		test(`import a from b;`, "(anonymous): Line 1:15 Unexpected identifier")
+		test(`export { "a" };`, "(anonymous): Line 1:10 Unexpected string")
+		test(`export { default };`, "(anonymous): Line 1:10 Unexpected reserved word")
+		test(`export 1;`, "(anonymous): Line 1:8 Unexpected number")
+		test(`{ import "a"; }`, "(anonymous): Line 1:3 Unexpected reserved word")
+		test(`import.foo`, "(anonymous): Line 1:8 Unexpected identifier")
+		test(`var await;`, "(anonymous): Line 1:5 Unexpected token await")
+		test(`export { "\uD83D\uDE00" } from "a";`, nil)
+		test(`export * as "\uD800" from "a";`, "(anonymous): Line 1:13 Invalid module export name: contains unpaired surrogate")
+		test(`import { "\uDC00\uD800" as a } from "a";`, "(anonymous): Line 1:10 Invalid module export name: contains unpaired surrogate")
+
+		_, err := ParseFile(nil, "", `import.meta`, 0)
+		is(firstErr(err), "(anonymous): Line 1:1 Cannot use 'import.meta' outside a module")
+		_, err = ParseFile(nil, "", `import "a";`, 0)
+		is(firstErr(err), "(anonymous): Line 1:1 Unexpected reserved word")
+		_, err = ParseFile(nil, "", `import("a");`, 0)
+		is(err, nil)
+		_, err = ParseFile(nil, "", `new import("a");`, 0)
+		is(firstErr(err), "(anonymous): Line 1:5 Unexpected reserved word")
+	})
+}
+
 func TestParseFunction(t *testing.T) {
 	tt(t, func() {
 		test := func(prm, bdy string, expect interface{}) *ast.FunctionLiteral {
@@ -512,6 +595,16 @@ func TestParserErr(t *testing.T) {
             st\u0061tic m() {}
 		}
 		`, "(anonymous): Line 3:25 Unexpected identifier")
+		test(`function f() { for await (x of y); }`, "(anonymous): Line 1:20 Unexpected token await")
+		test(`async function f() { for await (x in y); }`, "(anonymous): Line 1:35 Unexpected token in")
+		test(`async function f() { for await (;;); }`, "(anonymous): Line 1:33 Unexpected token ;")
+		test(`using x = y;`, "(anonymous): Line 1:1 Using declarations are not allowed at the top level of a script or directly in a switch case")
+		test(`switch (1) { case 1: using x = y; }`, "(anonymous): Line 1:22 Using declarations are not allowed at the top level of a script or directly in a switch case")
+		test(`if (1) using x = y;`, "(anonymous): Line 1:8 Lexical declaration cannot appear in a single-statement context")
+		test(`{ using x; }`, "(anonymous): Line 1:10 Missing initializer in using declaration")
+		test(`{ using x = 1, {y} = z; }`, "(anonymous): Line 1:16 Using declarations may not have binding patterns")
+		test(`function f() { await using x = y; }`, "(anonymous): Line 1:22 Unexpected identifier")
+		test(`{ for (using x in y); }`, "(anonymous): Line 1:8 for-in loop variable declaration may not be a using declaration")
 	})
 }
 
@@ -930,6 +1023,50 @@ func TestParser(t *testing.T) {
 		`, nil)
 		is(len(program.Body), 1)
 
+		{
+			program := test(`async function f() { for await (const x of y); }`, nil)
+			st := program.Body[0].(*ast.FunctionDeclaration).Function.Body.List[0].(*ast.ForOfStatement)
+			is(st.Await, true)
+			program = test(`async function f() { for (const x of y); }`, nil)
+			st = program.Body[0].(*ast.FunctionDeclaration).Function.Body.List[0].(*ast.ForOfStatement)
+			is(st.Await, false)
+			test(`({ async *m() { yield 1; } })`, nil)
+			test(`class C { async *m() { yield* this; } static async *[Symbol.asyncIterator]() {} }`, nil)
+		}
+
+		{
+			program := test(`{ using x = y, z = null; }`, nil)
+			decl := program.Body[0].(*ast.BlockStatement).List[0].(*ast.LexicalDeclaration)
+			is(decl.Token, token.USING)
+			is(decl.Await, false)
+			is(len(decl.List), 2)
+			program = test(`async function f() { for (await using x of y); }`, nil)
+			st := program.Body[0].(*ast.FunctionDeclaration).Function.Body.List[0].(*ast.ForOfStatement)
+			forDecl := st.Into.(*ast.ForDeclaration)
+			is(forDecl.Using, true)
+			is(forDecl.Await, true)
+			// not using declarations
+			program = test(`{ using [a] = y; using
+			x; for (using of y); }`, nil)
+			list := program.Body[0].(*ast.BlockStatement).List
+			is(len(list), 4)
+			_ = list[0].(*ast.ExpressionStatement)
+			test(`{ for (using of = y;;); }`, nil)
+			program = test(`var using = {a: 1}; using in {a: 1}; using instanceof Object; for (using in obj);`, nil)
+			is(len(program.Body), 4)
+			st1 := program.Body[1].(*ast.ExpressionStatement).Expression.(*ast.BinaryExpression)
+			is(st1.Operator, token.IN)
+			st1 = program.Body[2].(*ast.ExpressionStatement).Expression.(*ast.BinaryExpression)
+			is(st1.Operator, token.INSTANCEOF)
+			forIn := program.Body[3].(*ast.ForInStatement)
+			_ = forIn.Into.(*ast.ForIntoExpression)
+			program = test(`{ for (using of y); }`, nil)
+			forOf := program.Body[0].(*ast.BlockStatement).List[0].(*ast.ForOfStatement)
+			_ = forOf.Into.(*ast.ForIntoExpression)
+			program = test(`{ using let = y; using yield = z; using await = w; }`, nil)
+			is(len(program.Body[0].(*ast.BlockStatement).List), 3)
+		}
+
 		{
 			program := test(`(-2)**53`, nil)
 			st := program.Body[0].(*ast.ExpressionStatement).Expression.(*ast.BinaryExpression)
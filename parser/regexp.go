@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf16"
 	"unicode/utf8"
 )
 
@@ -166,6 +167,7 @@ func (self *_RegExp_parser) scan() {
 
 // (...)
 func (self *_RegExp_parser) scanGroup() {
+	dotAll := self.dotAll
 	str := self.str[self.chrOffset:]
 	if len(str) > 1 { // A possibility of (?= or (?!
 		if str[0] == '?' {
@@ -178,8 +180,10 @@ func (self *_RegExp_parser) scanGroup() {
 				self.error(false, "re2: Invalid (%s) <lookbehind>", self.str[self.chrOffset:self.chrOffset+2])
 				return
 			case ch != ':':
-				self.error(true, "Invalid group")
-				return
+				self.scanModifiers()
+				if self.err != nil {
+					return
+				}
 			}
 		}
 	}
@@ -210,6 +214,49 @@ func (self *_RegExp_parser) scanGroup() {
 		return
 	}
 	self.pass()
+	self.dotAll = dotAll
+}
+
+// (?ims-ims:...)
+// The modifiers are passed as they are (re2 supports the same syntax), apart from a trailing '-' which re2
+// doesn't allow.
+func (self *_RegExp_parser) scanModifiers() {
+	self.pass() // ?
+	seen := ""
+	remove := false
+	for self.chr != ':' {
+		switch self.chr {
+		case 'i', 'm', 's':
+			if strings.ContainsRune(seen, self.chr) {
+				self.error(true, "Repeated flag in modifiers")
+				return
+			}
+			seen += string(self.chr)
+			if self.chr == 's' {
+				self.dotAll = !remove
+			}
+			self.pass()
+		case '-':
+			if remove {
+				self.error(true, "Invalid group")
+				return
+			}
+			remove = true
+			if self.offset < self.length && self.str[self.offset] == ':' {
+				self.read()
+				continue
+			}
+			self.pass()
+		default:
+			self.error(true, "Invalid group")
+			return
+		}
+	}
+	if seen == "" {
+		self.error(true, "Invalid group")
+		return
+	}
+	self.pass() // :
 }
 
 // [...]
@@ -470,3 +517,225 @@ func (self *_RegExp_parser) error(fatal bool, msg string, msgValues ...interface
 	self.offset = self.length
 	self.chr = -1
 }
+
+type regexpAlternative struct {
+	group, alt int
+}
+
+type regexpNamedGroup struct {
+	name string
+	path []regexpAlternative // the alternatives containing the group, outermost first
+}
+
+type regexpEdit struct {
+	start, end int
+	name       string // the name of a \k<name> reference, "" for a named group
+}
+
+// TransformRegExpNamedGroups replaces the named capturing groups (?<name>...) of a JavaScript pattern with
+// unnamed ones and the named backreferences \k<name> with numbered ones. The result can be passed to
+// TransformRegExp or compiled by regexp2 (which numbers named groups differently).
+//
+// The returned slice contains the names of the capturing groups indexed by their numbers ("" for the unnamed
+// groups and the whole match), it's nil if the pattern has no named groups. Duplicate names are allowed as
+// long as the groups are in different alternatives.
+func TransformRegExpNamedGroups(pattern string, unicode bool) (transformed string, groupNames []string, err error) {
+	if !strings.Contains(pattern, `(?<`) && !(unicode && strings.Contains(pattern, `\k`)) {
+		return pattern, nil, nil
+	}
+
+	var groups []regexpNamedGroup
+	var edits, refs []regexpEdit
+	path := []regexpAlternative{{}}
+	groupCount := 0
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+			if i < len(pattern) && pattern[i] == 'k' {
+				ref := regexpEdit{start: i - 1, end: i + 1}
+				if i+1 < len(pattern) && pattern[i+1] == '<' {
+					if name, end, ok := parseRegExpGroupName(pattern, i+2); ok {
+						ref.name, ref.end = name, end
+						i = end - 1
+					}
+				}
+				refs = append(refs, ref)
+				edits = append(edits, ref)
+			}
+		case '[':
+			for i++; i < len(pattern) && pattern[i] != ']'; i++ {
+				if pattern[i] == '\\' {
+					i++
+				}
+			}
+		case '(':
+			capturing := true
+			if strings.HasPrefix(pattern[i+1:], "?") {
+				capturing = false
+				if strings.HasPrefix(pattern[i+1:], "?<") && !strings.HasPrefix(pattern[i+1:], "?<=") && !strings.HasPrefix(pattern[i+1:], "?<!") {
+					name, end, ok := parseRegExpGroupName(pattern, i+3)
+					if !ok {
+						return "", nil, regexpSyntaxError(i, "Invalid capture group name")
+					}
+					for _, g := range groups {
+						if g.name == name && !regexpAlternativesExclusive(g.path, path) {
+							return "", nil, regexpSyntaxError(i, "Duplicate capture group name")
+						}
+					}
+					groupCount++
+					for len(groups) < groupCount {
+						groups = append(groups, regexpNamedGroup{})
+					}
+					groups[groupCount-1] = regexpNamedGroup{
+						name: name,
+						path: append([]regexpAlternative(nil), path...),
+					}
+					edits = append(edits, regexpEdit{start: i + 1, end: end})
+				}
+			}
+			if capturing {
+				groupCount++
+			}
+			path = append(path, regexpAlternative{group: i + 1})
+		case ')':
+			if len(path) > 1 {
+				path = path[:len(path)-1]
+			}
+		case '|':
+			path[len(path)-1].alt++
+		}
+	}
+
+	if len(groups) == 0 {
+		if unicode && len(refs) > 0 {
+			return "", nil, regexpSyntaxError(refs[0].start, "Invalid named reference")
+		}
+		return pattern, nil, nil
+	}
+
+	groupNames = make([]string, groupCount+1)
+	for i, g := range groups {
+		groupNames[i+1] = g.name
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for _, e := range edits {
+		sb.WriteString(pattern[pos:e.start])
+		pos = e.end
+		if e.start < len(pattern) && pattern[e.start] != '\\' {
+			// (?<name> -> (
+			continue
+		}
+		if e.name == "" {
+			return "", nil, regexpSyntaxError(e.start, "Invalid named reference")
+		}
+		// Only one of the groups with the same name can participate in a match, the backreferences to the others
+		// match the empty string.
+		sb.WriteString("(?:")
+		found := false
+		for n, name := range groupNames {
+			if name == e.name {
+				sb.WriteByte('\\')
+				sb.WriteString(strconv.Itoa(n))
+				found = true
+			}
+		}
+		if !found {
+			return "", nil, regexpSyntaxError(e.start, "Invalid named capture referenced")
+		}
+		sb.WriteByte(')')
+	}
+	sb.WriteString(pattern[pos:])
+
+	return sb.String(), groupNames, nil
+}
+
+func regexpSyntaxError(offset int, msg string) error {
+	return RegexpSyntaxError{regexpParseError{
+		offset: offset,
+		err:    msg,
+	}}
+}
+
+// regexpAlternativesExclusive reports whether two groups with the given paths can't participate in the same
+// match, i.e. whether they're in different alternatives of the same disjunction.
+func regexpAlternativesExclusive(path1, path2 []regexpAlternative) bool {
+	for i := 0; i < len(path1) && i < len(path2); i++ {
+		if path1[i].group != path2[i].group {
+			return false
+		}
+		if path1[i].alt != path2[i].alt {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRegExpGroupName parses a group name starting at the given offset and terminated by '>'. It returns the
+// name with the escape sequences decoded and the offset after the terminating '>'.
+func parseRegExpGroupName(pattern string, offset int) (name string, end int, ok bool) {
+	var sb strings.Builder
+	first := true
+	for offset < len(pattern) {
+		chr, size := utf8.DecodeRuneInString(pattern[offset:])
+		offset += size
+		if chr == '>' {
+			if first {
+				return
+			}
+			return sb.String(), offset, true
+		}
+		if chr == '\\' {
+			if !strings.HasPrefix(pattern[offset:], "u") {
+				return
+			}
+			chr, offset = parseRegExpUnicodeEscape(pattern, offset+1)
+			if chr < 0 {
+				return
+			}
+			if utf16.IsSurrogate(chr) && strings.HasPrefix(pattern[offset:], `\u`) {
+				if second, next := parseRegExpUnicodeEscape(pattern, offset+2); second >= 0 {
+					if r := utf16.DecodeRune(chr, second); r != utf8.RuneError {
+						chr, offset = r, next
+					}
+				}
+			}
+		}
+		if first {
+			if chr == '\\' || !isIdentifierStart(chr) {
+				return
+			}
+			first = false
+		} else if chr == '\\' || !isIdentifierPart(chr) {
+			return
+		}
+		sb.WriteRune(chr)
+	}
+	return
+}
+
+// parseRegExpUnicodeEscape parses the XXXX or {X...} part of a \u escape. It returns -1 if it's invalid.
+func parseRegExpUnicodeEscape(pattern string, offset int) (rune, int) {
+	if strings.HasPrefix(pattern[offset:], "{") {
+		end := strings.IndexByte(pattern[offset:], '}')
+		if end <= 1 {
+			return -1, offset
+		}
+		v, err := strconv.ParseUint(pattern[offset+1:offset+end], 16, 32)
+		if err != nil || v > utf8.MaxRune {
+			return -1, offset
+		}
+		return rune(v), offset + end + 1
+	}
+	if len(pattern) < offset+4 {
+		return -1, offset
+	}
+	v, err := strconv.ParseUint(pattern[offset:offset+4], 16, 16)
+	if err != nil {
+		return -1, offset
+	}
+	return rune(v), offset + 4
+}
@@ -28,6 +28,10 @@ func TestRegExp(t *testing.T) {
 			test("(?U)", "Invalid group")
 			test("(?)|(?i)", "Invalid group")
 			test("(?P<w>)(?P<w>)(?P<D>)", "Invalid group")
+			test("(?ii:a)", "Repeated flag in modifiers")
+			test("(?i-i:a)", "Repeated flag in modifiers")
+			test("(?-:a)", "Invalid group")
+			test("(?i-m-s:a)", "Invalid group")
 		}
 
 		{
@@ -137,6 +141,12 @@ func TestRegExp(t *testing.T) {
 
 			test(`(a(bc))`, `(a(bc))`)
 
+			test(`(?i:a)(?-m:^)`, `(?i:a)(?-m:^)`)
+
+			test(`(?i-:a)`, `(?i:a)`)
+
+			test(`(?s:.(?-s:.)).`, `(?s:.(?-s:`+Re2Dot+`))`+Re2Dot)
+
 			test(`[]`, "[^\u0000-\U0001FFFF]")
 
 			test(`[^]`, "[\u0000-\U0001FFFF]")
@@ -168,6 +178,101 @@ func TestTransformRegExp(t *testing.T) {
 	})
 }
 
+func TestTransformRegExpNamedGroups(t *testing.T) {
+	tt(t, func() {
+		test := func(input string, unicode bool, expect string, expectNames []string) {
+			result, names, err := TransformRegExpNamedGroups(input, unicode)
+			is(err, nil)
+			is(result, expect)
+			is(len(names), len(expectNames))
+			for i, name := range expectNames {
+				is(names[i], name)
+			}
+		}
+
+		test(`(a)(?:b)`, false, `(a)(?:b)`, nil)
+		test(`\k<a>`, false, `\k<a>`, nil)
+		test(`(?<=a)(?<!b)`, false, `(?<=a)(?<!b)`, nil)
+		test(`(?<year>\d+)-(\d+)-(?<day>\d+)`, false, `(\d+)-(\d+)-(\d+)`, []string{"", "year", "", "day"})
+		test(`(?<a>x)\k<a>[(?<b>]`, false, `(x)(?:\1)[(?<b>]`, []string{"", "a"})
+		test(`(?<a>x)|(?<a>y)\k<a>`, true, `(x)|(y)(?:\1\2)`, []string{"", "a", "a"})
+		test(`(?<\u0061\u{62}>.)`, true, `(.)`, []string{"", "ab"})
+		test(`(?<\ud835\udc9c>.)`, false, `(.)`, []string{"", "\U0001d49c"})
+	})
+	tt(t, func() {
+		test := func(input string, unicode bool, expect string) {
+			_, _, err := TransformRegExpNamedGroups(input, unicode)
+			_, ok := err.(RegexpSyntaxError)
+			is(ok, true)
+			is(err, expect)
+		}
+
+		test(`(?<a>x)(?<a>y)`, false, "Duplicate capture group name")
+		test(`(?:(?<a>x)|y)(?<a>z)`, false, "Duplicate capture group name")
+		test(`(?<a>(?<a>x)|y)`, false, "Duplicate capture group name")
+		test(`(?<1a>x)`, false, "Invalid capture group name")
+		test(`(?<>x)`, false, "Invalid capture group name")
+		test(`(?<a>x)\k<b>`, false, "Invalid named capture referenced")
+		test(`(?<a>x)\k`, false, "Invalid named reference")
+		test(`\k<a>`, true, "Invalid named reference")
+	})
+}
+
+func TestTransformRegExpUnicodeClasses(t *testing.T) {
+	tt(t, func() {
+		test := func(input string, unicodeSets bool, expect string) {
+			result, err := TransformRegExpUnicodeClasses(input, unicodeSets)
+			is(err, nil)
+			is(result, expect)
+		}
+
+		test(`[a-z]\d`, false, `[a-z]\d`)
+		test(`\p{ASCII_Hex_Digit}+`, false, `[0-9A-Fa-f]+`)
+		test(`\P{AHex}`, false, `[\u{0}-\u{2f}\u{3a}-\u{40}G-\u{60}g-\u{10ffff}]`)
+		test(`[_\p{AHex}]`, false, `[_0-9A-Fa-f]`)
+		greek, _ := TransformRegExpUnicodeClasses(`\p{Script=Greek}`, false)
+		test(`\p{sc=Grek}`, false, greek)
+		test(`[a-z]`, true, `[a-z]`)
+		test(`[^a-z]`, true, `[^a-z]`)
+		test(`[[a-z]--[aeiou]]`, true, `[b-df-hj-np-tv-z]`)
+		test(`[\p{AHex}&&[a-z]]`, true, `[a-f]`)
+		test(`[\q{abc|d|}x]`, true, `(?:abc|[dx]|)`)
+		test(`[[a-c]--\q{b}]`, true, `[ac]`)
+		test(`[\d--\d]`, true, `[^\u{0}-\u{10ffff}]`)
+		test(`[\-\&]`, true, `[\u{26}\u{2d}]`)
+		test(`\p{scx=Hira}`, false, `[\u{3001}-\u{3003}\u{3008}-\u{3011}\u{3013}-\u{301f}\u{3030}-\u{3035}\u{3037}\u{303c}\u{303d}\u{3041}-\u{3096}\u{3099}-\u{30a0}\u{30fb}\u{30fc}\u{fe45}\u{fe46}\u{ff61}-\u{ff65}\u{ff70}\u{ff9e}\u{ff9f}\u{1b001}-\u{1b11f}\u{1b132}\u{1b150}-\u{1b152}\u{1f200}]`)
+		test(`\p{Emoji_Keycap_Sequence}`, true, `(?:\u{23}\u{fe0f}\u{20e3}|\u{2a}\u{fe0f}\u{20e3}|0\u{fe0f}\u{20e3}|1\u{fe0f}\u{20e3}|2\u{fe0f}\u{20e3}|3\u{fe0f}\u{20e3}|4\u{fe0f}\u{20e3}|5\u{fe0f}\u{20e3}|6\u{fe0f}\u{20e3}|7\u{fe0f}\u{20e3}|8\u{fe0f}\u{20e3}|9\u{fe0f}\u{20e3})`)
+	})
+	tt(t, func() {
+		test := func(input string, unicodeSets bool, expect string) {
+			_, err := TransformRegExpUnicodeClasses(input, unicodeSets)
+			_, ok := err.(RegexpSyntaxError)
+			is(ok, true)
+			is(err, expect)
+		}
+
+		test(`\p{Foo}`, false, "Invalid property name")
+		test(`\p{Script=Foo}`, false, "Invalid property name")
+		test(`\p{Other_Alphabetic}`, false, "Invalid property name")
+		test(`\pL`, false, "Invalid property name")
+		test(`[a-\p{L}]`, false, "Invalid character class")
+		test(`[a&&&b]`, true, "Invalid set operation in character class")
+		test(`[a-z&&b]`, true, "Invalid set operation in character class")
+		test(`[a&&b--c]`, true, "Invalid set operation in character class")
+		test(`[ab--c]`, true, "Invalid set operation in character class")
+		test(`[a(]`, true, "Invalid character in character class")
+		test(`[a-]`, true, "Invalid character in character class")
+		test(`[a!!b]`, true, "Invalid set operation in character class")
+		test(`[^\q{ab}]`, true, "Negated character class may contain strings")
+		test(`[^\p{RGI_Emoji_Flag_Sequence}]`, true, "Negated character class may contain strings")
+		test(`\P{RGI_Emoji}`, true, "Negated property escape may contain strings")
+		test(`\p{RGI_Emoji}`, false, "Invalid property name")
+		test(`[z-a]`, true, "Range out of order in character class")
+		test(`[\z]`, true, "Invalid escape")
+		test(`[a`, true, "Unterminated character class")
+	})
+}
+
 func BenchmarkTransformRegExp(b *testing.B) {
 	f := func(reStr string, b *testing.B) {
 		b.ResetTimer()
@@ -0,0 +1,1097 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+type regexpRange struct {
+	lo, hi rune
+}
+
+// regexpCharSet is the value of a character class in the 'v' mode: a set of code points and a set of strings
+// (only those that don't consist of exactly one code point).
+type regexpCharSet struct {
+	ranges  []regexpRange // sorted, non-overlapping and non-adjacent
+	strings map[string]struct{}
+}
+
+type regexpClassParser struct {
+	str string
+	pos int
+
+	unicodeSets bool
+
+	sb    strings.Builder
+	start int // the start of the part of str which is not yet written to sb
+}
+
+// TransformRegExpUnicodeClasses replaces the Unicode property escapes (\p{...} and \P{...}) of a JavaScript
+// pattern in the 'u' or 'v' mode with explicit character classes. In the 'v' mode (unicodeSets is true) it also
+// evaluates the character classes, which may contain nested classes, set operations (&& and --) and string
+// literals (\q{...}), and replaces them with plain classes or, if they contain strings, with alternations. The
+// result can be passed to TransformRegExp or compiled by regexp2 in the Unicode mode.
+//
+// The supported properties are General_Category, Script, Script_Extensions, the binary properties that can be
+// derived from the tables of the unicode package, the emoji properties and, in the 'v' mode, the emoji properties
+// of strings (RGI_Emoji and its subsets). Script_Extensions and the emoji properties come from the tables in
+// regexp_unicode_tables.go.
+func TransformRegExpUnicodeClasses(pattern string, unicodeSets bool) (string, error) {
+	if !unicodeSets && !strings.Contains(pattern, `\p`) && !strings.Contains(pattern, `\P`) {
+		return pattern, nil
+	}
+
+	p := regexpClassParser{
+		str:         pattern,
+		unicodeSets: unicodeSets,
+	}
+	for p.pos < len(p.str) {
+		switch p.str[p.pos] {
+		case '\\':
+			if p.isPropertyEscape() {
+				offset := p.pos
+				set, err := p.parseProperty()
+				if err != nil {
+					return "", err
+				}
+				p.flush(offset)
+				set.write(&p.sb, false)
+				p.start = p.pos
+				continue
+			}
+			p.pos += 2
+		case '[':
+			var err error
+			if unicodeSets {
+				err = p.scanClassSet()
+			} else {
+				err = p.scanClass()
+			}
+			if err != nil {
+				return "", err
+			}
+		default:
+			p.pos++
+		}
+	}
+	if p.start == 0 {
+		return pattern, nil
+	}
+	p.flush(len(p.str))
+	return p.sb.String(), nil
+}
+
+func (p *regexpClassParser) flush(offset int) {
+	p.sb.WriteString(p.str[p.start:offset])
+	p.start = offset
+}
+
+func (p *regexpClassParser) error(offset int, msg string) error {
+	return regexpSyntaxError(offset, msg)
+}
+
+func (p *regexpClassParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(p.str[p.pos:], s)
+}
+
+func (p *regexpClassParser) consume(s string) bool {
+	if p.hasPrefix(s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *regexpClassParser) isPropertyEscape() bool {
+	return p.pos+1 < len(p.str) && p.str[p.pos] == '\\' && (p.str[p.pos+1] == 'p' || p.str[p.pos+1] == 'P')
+}
+
+// parseProperty parses \p{...} or \P{...} and returns the code points (and, for the properties of strings, the
+// strings) it matches.
+func (p *regexpClassParser) parseProperty() (*regexpCharSet, error) {
+	offset := p.pos
+	negate := p.str[p.pos+1] == 'P'
+	p.pos += 2
+	if !p.consume("{") {
+		return nil, p.error(offset, "Invalid property name")
+	}
+	end := strings.IndexByte(p.str[p.pos:], '}')
+	if end < 0 {
+		return nil, p.error(offset, "Invalid property name")
+	}
+	name, value, hasValue := strings.Cut(p.str[p.pos:p.pos+end], "=")
+	p.pos += end + 1
+	if prop, exists := regexpPropertyOfStrings(name); exists && !hasValue {
+		if !p.unicodeSets {
+			return nil, p.error(offset, "Invalid property name")
+		}
+		if negate {
+			return nil, p.error(offset, "Negated property escape may contain strings")
+		}
+		return prop, nil
+	}
+	ranges, ok := regexpUnicodeProperty(name, value, hasValue)
+	if !ok {
+		return nil, p.error(offset, "Invalid property name")
+	}
+	if negate {
+		ranges = complementRegExpRanges(ranges)
+	}
+	return &regexpCharSet{ranges: ranges}, nil
+}
+
+// scanClass replaces the property escapes in a character class in the 'u' mode.
+func (p *regexpClassParser) scanClass() error {
+	p.pos++
+	p.consume("^")
+	first := p.pos
+	for p.pos < len(p.str) && p.str[p.pos] != ']' {
+		if !p.isPropertyEscape() {
+			if p.str[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+			continue
+		}
+		offset := p.pos
+		if offset-1 > first && p.str[offset-1] == '-' && p.str[offset-2] != '\\' {
+			return p.error(offset, "Invalid character class")
+		}
+		set, err := p.parseProperty()
+		if err != nil {
+			return err
+		}
+		if p.hasPrefix("-") && !p.hasPrefix("-]") {
+			return p.error(offset, "Invalid character class")
+		}
+		p.flush(offset)
+		writeRegExpRanges(&p.sb, set.ranges)
+		p.start = p.pos
+	}
+	return nil
+}
+
+// scanClassSet evaluates a character class in the 'v' mode and replaces it.
+func (p *regexpClassParser) scanClassSet() error {
+	offset := p.pos
+	p.pos++
+	negate := p.consume("^")
+	set, err := p.parseClassSetExpression()
+	if err != nil {
+		return err
+	}
+	if negate && len(set.strings) > 0 {
+		return p.error(offset, "Negated character class may contain strings")
+	}
+	p.flush(offset)
+	set.write(&p.sb, negate)
+	p.start = p.pos
+	return nil
+}
+
+// parseClassSetExpression parses the contents of a class (after '[' and '^') including the closing ']'.
+func (p *regexpClassParser) parseClassSetExpression() (*regexpCharSet, error) {
+	if p.consume("]") {
+		return &regexpCharSet{}, nil
+	}
+	offset := p.pos
+	set, isRange, err := p.parseClassSetItem()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"&&", "--"} {
+		if !p.hasPrefix(op) {
+			continue
+		}
+		if isRange {
+			return nil, p.error(offset, "Invalid set operation in character class")
+		}
+		for p.consume(op) {
+			if op == "&&" && p.hasPrefix("&") {
+				return nil, p.error(p.pos, "Invalid set operation in character class")
+			}
+			operand, err := p.parseClassSetOperand()
+			if err != nil {
+				return nil, err
+			}
+			if op == "&&" {
+				set = set.intersect(operand)
+			} else {
+				set = set.subtract(operand)
+			}
+		}
+		if !p.consume("]") {
+			return nil, p.error(p.pos, "Invalid set operation in character class")
+		}
+		return set, nil
+	}
+	for !p.consume("]") {
+		if p.hasPrefix("&&") || p.hasPrefix("--") {
+			return nil, p.error(p.pos, "Invalid set operation in character class")
+		}
+		item, _, err := p.parseClassSetItem()
+		if err != nil {
+			return nil, err
+		}
+		set = set.union(item)
+	}
+	return set, nil
+}
+
+// parseClassSetItem parses an operand or a range of a class union.
+func (p *regexpClassParser) parseClassSetItem() (set *regexpCharSet, isRange bool, err error) {
+	lo, ok, err := p.parseClassSetCharacter()
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		set, err = p.parseClassSetOperand()
+		return
+	}
+	if p.hasPrefix("-") && !p.hasPrefix("--") {
+		offset := p.pos
+		p.pos++
+		hi, ok, err := p.parseClassSetCharacter()
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			return nil, false, p.error(offset, "Invalid character class")
+		}
+		if lo > hi {
+			return nil, false, p.error(offset, "Range out of order in character class")
+		}
+		return &regexpCharSet{ranges: []regexpRange{{lo, hi}}}, true, nil
+	}
+	return &regexpCharSet{ranges: []regexpRange{{lo, lo}}}, false, nil
+}
+
+// parseClassSetOperand parses a nested class, a class escape, a string literal or a single character.
+func (p *regexpClassParser) parseClassSetOperand() (*regexpCharSet, error) {
+	offset := p.pos
+	switch {
+	case p.consume("["):
+		negate := p.consume("^")
+		set, err := p.parseClassSetExpression()
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			if len(set.strings) > 0 {
+				return nil, p.error(offset, "Negated character class may contain strings")
+			}
+			set = &regexpCharSet{ranges: complementRegExpRanges(set.ranges)}
+		}
+		return set, nil
+	case p.hasPrefix(`\q{`):
+		return p.parseClassStringDisjunction()
+	case p.hasPrefix(`\`) && p.pos+1 < len(p.str) && strings.IndexByte("dDwWsSpP", p.str[p.pos+1]) >= 0:
+		return p.parseClassEscape()
+	}
+	c, ok, err := p.parseClassSetCharacter()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, p.error(offset, "Invalid character class")
+	}
+	return &regexpCharSet{ranges: []regexpRange{{c, c}}}, nil
+}
+
+// parseClassSetCharacter parses a single character. It returns false without consuming anything if the
+// operand is not a character (i.e. a nested class, a class escape or a string literal).
+func (p *regexpClassParser) parseClassSetCharacter() (c rune, ok bool, err error) {
+	if p.pos >= len(p.str) {
+		return 0, false, p.error(p.pos, "Unterminated character class")
+	}
+	ch := p.str[p.pos]
+	switch ch {
+	case '[':
+		return 0, false, nil
+	case '\\':
+		if p.pos+1 >= len(p.str) {
+			return 0, false, p.error(p.pos, "\\ at end of pattern")
+		}
+		switch p.str[p.pos+1] {
+		case 'd', 'D', 'w', 'W', 's', 'S', 'p', 'P':
+			return 0, false, nil
+		case 'q':
+			if p.pos+2 < len(p.str) && p.str[p.pos+2] == '{' {
+				return 0, false, nil
+			}
+		case 'b':
+			p.pos += 2
+			return '\b', true, nil
+		}
+		c, err = p.parseCharacterEscape()
+		return c, err == nil, err
+	case '(', ')', ']', '{', '}', '/', '-', '|':
+		return 0, false, p.error(p.pos, "Invalid character in character class")
+	}
+	if p.pos+1 < len(p.str) && p.str[p.pos+1] == ch && strings.IndexByte("&!#$%*+,.:;<=>?@^`~", ch) >= 0 {
+		return 0, false, p.error(p.pos, "Invalid set operation in character class")
+	}
+	c, size := utf8.DecodeRuneInString(p.str[p.pos:])
+	p.pos += size
+	return c, true, nil
+}
+
+func (p *regexpClassParser) parseCharacterEscape() (rune, error) {
+	offset := p.pos
+	e := p.str[p.pos+1]
+	p.pos += 2
+	switch e {
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'v':
+		return '\v', nil
+	case 'c':
+		if p.pos < len(p.str) {
+			if c := p.str[p.pos]; 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+				p.pos++
+				return rune(c % 32), nil
+			}
+		}
+	case '0':
+		if p.pos >= len(p.str) || p.str[p.pos] < '0' || p.str[p.pos] > '9' {
+			return 0, nil
+		}
+	case 'x':
+		if p.pos+2 <= len(p.str) {
+			if v, err := strconv.ParseUint(p.str[p.pos:p.pos+2], 16, 8); err == nil {
+				p.pos += 2
+				return rune(v), nil
+			}
+		}
+	case 'u':
+		c, end := parseRegExpUnicodeEscape(p.str, p.pos)
+		if c < 0 {
+			return 0, p.error(offset, "Invalid Unicode escape")
+		}
+		p.pos = end
+		if utf16.IsSurrogate(c) && p.hasPrefix(`\u`) {
+			if second, next := parseRegExpUnicodeEscape(p.str, p.pos+2); second >= 0 {
+				if r := utf16.DecodeRune(c, second); r != utf8.RuneError {
+					c, p.pos = r, next
+				}
+			}
+		}
+		return c, nil
+	default:
+		if strings.IndexByte("^$\\.*+?()[]{}|/&-!#%,:;<=>@`~", e) >= 0 {
+			return rune(e), nil
+		}
+	}
+	return 0, p.error(offset, "Invalid escape")
+}
+
+// parseClassEscape parses \d, \D, \w, \W, \s, \S, \p{...} or \P{...}.
+func (p *regexpClassParser) parseClassEscape() (*regexpCharSet, error) {
+	e := p.str[p.pos+1]
+	if e == 'p' || e == 'P' {
+		return p.parseProperty()
+	}
+	p.pos += 2
+	var ranges []regexpRange
+	switch e {
+	case 'd', 'D':
+		ranges = []regexpRange{{'0', '9'}}
+	case 'w', 'W':
+		ranges = []regexpRange{{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}}
+	case 's', 'S':
+		for _, c := range WhitespaceChars {
+			ranges = append(ranges, regexpRange{c, c})
+		}
+		ranges = normalizeRegExpRanges(ranges)
+	}
+	if e == 'D' || e == 'W' || e == 'S' {
+		ranges = complementRegExpRanges(ranges)
+	}
+	return &regexpCharSet{ranges: ranges}, nil
+}
+
+// parseClassStringDisjunction parses \q{...}.
+func (p *regexpClassParser) parseClassStringDisjunction() (*regexpCharSet, error) {
+	set := &regexpCharSet{}
+	p.pos += 3
+	var s []rune
+	for {
+		if p.pos >= len(p.str) {
+			return nil, p.error(p.pos, "Unterminated character class")
+		}
+		switch p.str[p.pos] {
+		case '|', '}':
+			if len(s) == 1 {
+				set.ranges = normalizeRegExpRanges(append(set.ranges, regexpRange{s[0], s[0]}))
+			} else {
+				if set.strings == nil {
+					set.strings = make(map[string]struct{})
+				}
+				set.strings[string(s)] = struct{}{}
+			}
+			s = s[:0]
+			p.pos++
+			if p.str[p.pos-1] == '}' {
+				return set, nil
+			}
+			continue
+		}
+		offset := p.pos
+		c, ok, err := p.parseClassSetCharacter()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, p.error(offset, "Invalid escape")
+		}
+		s = append(s, c)
+	}
+}
+
+func (s *regexpCharSet) union(other *regexpCharSet) *regexpCharSet {
+	res := &regexpCharSet{
+		ranges: normalizeRegExpRanges(append(append([]regexpRange(nil), s.ranges...), other.ranges...)),
+	}
+	for _, strs := range []map[string]struct{}{s.strings, other.strings} {
+		for str := range strs {
+			if res.strings == nil {
+				res.strings = make(map[string]struct{})
+			}
+			res.strings[str] = struct{}{}
+		}
+	}
+	return res
+}
+
+func (s *regexpCharSet) intersect(other *regexpCharSet) *regexpCharSet {
+	res := &regexpCharSet{
+		ranges: intersectRegExpRanges(s.ranges, other.ranges),
+	}
+	for str := range s.strings {
+		if _, exists := other.strings[str]; exists {
+			if res.strings == nil {
+				res.strings = make(map[string]struct{})
+			}
+			res.strings[str] = struct{}{}
+		}
+	}
+	return res
+}
+
+func (s *regexpCharSet) subtract(other *regexpCharSet) *regexpCharSet {
+	res := &regexpCharSet{
+		ranges: intersectRegExpRanges(s.ranges, complementRegExpRanges(other.ranges)),
+	}
+	for str := range s.strings {
+		if _, exists := other.strings[str]; !exists {
+			if res.strings == nil {
+				res.strings = make(map[string]struct{})
+			}
+			res.strings[str] = struct{}{}
+		}
+	}
+	return res
+}
+
+// write writes the set as a class or, if it contains strings, as an alternation which tries the longest strings
+// first.
+func (s *regexpCharSet) write(sb *strings.Builder, negate bool) {
+	if len(s.strings) == 0 {
+		writeRegExpClass(sb, s.ranges, negate)
+		return
+	}
+	strs := make([]string, 0, len(s.strings))
+	for str := range s.strings {
+		strs = append(strs, str)
+	}
+	sort.Slice(strs, func(i, j int) bool {
+		if l1, l2 := utf8.RuneCountInString(strs[i]), utf8.RuneCountInString(strs[j]); l1 != l2 {
+			return l1 > l2
+		}
+		return strs[i] < strs[j]
+	})
+	sb.WriteString("(?:")
+	sep := false
+	for _, str := range strs {
+		if str == "" {
+			continue
+		}
+		if sep {
+			sb.WriteByte('|')
+		}
+		for _, c := range str {
+			writeRegExpChar(sb, c)
+		}
+		sep = true
+	}
+	if len(s.ranges) > 0 {
+		if sep {
+			sb.WriteByte('|')
+		}
+		writeRegExpClass(sb, s.ranges, false)
+	}
+	if _, exists := s.strings[""]; exists {
+		sb.WriteByte('|')
+	}
+	sb.WriteByte(')')
+}
+
+func writeRegExpClass(sb *strings.Builder, ranges []regexpRange, negate bool) {
+	if len(ranges) == 0 {
+		ranges = []regexpRange{{0, unicode.MaxRune}}
+		negate = !negate
+	}
+	sb.WriteByte('[')
+	if negate {
+		sb.WriteByte('^')
+	}
+	writeRegExpRanges(sb, ranges)
+	sb.WriteByte(']')
+}
+
+func writeRegExpRanges(sb *strings.Builder, ranges []regexpRange) {
+	for _, r := range ranges {
+		writeRegExpChar(sb, r.lo)
+		if r.hi > r.lo {
+			if r.hi > r.lo+1 {
+				sb.WriteByte('-')
+			}
+			writeRegExpChar(sb, r.hi)
+		}
+	}
+}
+
+func writeRegExpChar(sb *strings.Builder, c rune) {
+	if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' {
+		sb.WriteRune(c)
+		return
+	}
+	sb.WriteString(`\u{`)
+	sb.WriteString(strconv.FormatInt(int64(c), 16))
+	sb.WriteByte('}')
+}
+
+func normalizeRegExpRanges(ranges []regexpRange) []regexpRange {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].lo < ranges[j].lo
+	})
+	res := ranges[:0]
+	for _, r := range ranges {
+		if n := len(res); n > 0 && r.lo <= res[n-1].hi+1 {
+			if r.hi > res[n-1].hi {
+				res[n-1].hi = r.hi
+			}
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func complementRegExpRanges(ranges []regexpRange) []regexpRange {
+	var res []regexpRange
+	next := rune(0)
+	for _, r := range ranges {
+		if r.lo > next {
+			res = append(res, regexpRange{next, r.lo - 1})
+		}
+		next = r.hi + 1
+	}
+	if next <= unicode.MaxRune {
+		res = append(res, regexpRange{next, unicode.MaxRune})
+	}
+	return res
+}
+
+func intersectRegExpRanges(a, b []regexpRange) []regexpRange {
+	var res []regexpRange
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := a[i].lo, a[i].hi
+		if b[j].lo > lo {
+			lo = b[j].lo
+		}
+		if b[j].hi < hi {
+			hi = b[j].hi
+		}
+		if lo <= hi {
+			res = append(res, regexpRange{lo, hi})
+		}
+		if a[i].hi < b[j].hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// regexpTableRanges returns the union of the code points of the tables.
+func regexpTableRanges(tables ...*unicode.RangeTable) []regexpRange {
+	var ranges []regexpRange
+	for _, t := range tables {
+		for _, r := range t.R16 {
+			if r.Stride == 1 {
+				ranges = append(ranges, regexpRange{rune(r.Lo), rune(r.Hi)})
+				continue
+			}
+			for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+				ranges = append(ranges, regexpRange{c, c})
+			}
+		}
+		for _, r := range t.R32 {
+			if r.Stride == 1 {
+				ranges = append(ranges, regexpRange{rune(r.Lo), rune(r.Hi)})
+				continue
+			}
+			for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+				ranges = append(ranges, regexpRange{c, c})
+			}
+		}
+	}
+	return normalizeRegExpRanges(ranges)
+}
+
+// regexpUnicodeProperty returns the code points matched by \p{name} or \p{name=value}.
+func regexpUnicodeProperty(name, value string, hasValue bool) ([]regexpRange, bool) {
+	if hasValue {
+		switch name {
+		case "General_Category", "gc":
+			return regexpGeneralCategory(value)
+		case "Script", "sc":
+			return regexpScript(value)
+		case "Script_Extensions", "scx":
+			return regexpScriptExtensions(value)
+		}
+		return nil, false
+	}
+	if ranges, ok := regexpGeneralCategory(name); ok {
+		return ranges, true
+	}
+	return regexpBinaryProperty(name)
+}
+
+func regexpGeneralCategory(name string) ([]regexpRange, bool) {
+	if short, exists := regexpGeneralCategoryAliases[name]; exists {
+		name = short
+	}
+	switch name {
+	case "LC":
+		return regexpTableRanges(unicode.Lu, unicode.Ll, unicode.Lt), true
+	case "Cn":
+		return complementRegExpRanges(regexpAssigned()), true
+	case "C":
+		return normalizeRegExpRanges(append(regexpTableRanges(unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs),
+			complementRegExpRanges(regexpAssigned())...)), true
+	}
+	if t, exists := unicode.Categories[name]; exists {
+		return regexpTableRanges(t), true
+	}
+	return nil, false
+}
+
+func regexpAssigned() []regexpRange {
+	return regexpTableRanges(unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z,
+		unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs)
+}
+
+func regexpScript(name string) ([]regexpRange, bool) {
+	if long, exists := regexpScriptAliases[name]; exists {
+		name = long
+	}
+	if name == "Unknown" {
+		tables := make([]*unicode.RangeTable, 0, len(unicode.Scripts))
+		for _, t := range unicode.Scripts {
+			tables = append(tables, t)
+		}
+		return complementRegExpRanges(regexpTableRanges(tables...)), true
+	}
+	if t, exists := unicode.Scripts[name]; exists {
+		return regexpTableRanges(t), true
+	}
+	return nil, false
+}
+
+// regexpScriptExtensions returns the code points whose Script_Extensions contain the script: those of the
+// script, except the ones which have explicit Script_Extensions, and those whose explicit Script_Extensions
+// contain it.
+func regexpScriptExtensions(name string) ([]regexpRange, bool) {
+	if long, exists := regexpScriptAliases[name]; exists {
+		name = long
+	}
+	ranges, ok := regexpScript(name)
+	if !ok {
+		return nil, false
+	}
+	ranges = intersectRegExpRanges(ranges, complementRegExpRanges(regexpExplicitScriptExtensions))
+	return normalizeRegExpRanges(append(ranges, regexpScriptExtensionRanges[name]...)), true
+}
+
+func regexpBinaryProperty(name string) ([]regexpRange, bool) {
+	if long, exists := regexpBinaryPropertyAliases[name]; exists {
+		name = long
+	}
+	switch name {
+	case "Any":
+		return []regexpRange{{0, unicode.MaxRune}}, true
+	case "ASCII":
+		return []regexpRange{{0, 0x7f}}, true
+	case "Assigned":
+		return regexpAssigned(), true
+	case "Alphabetic":
+		return regexpTableRanges(unicode.L, unicode.Nl, unicode.Other_Alphabetic), true
+	case "Lowercase":
+		return regexpTableRanges(unicode.Ll, unicode.Other_Lowercase), true
+	case "Uppercase":
+		return regexpTableRanges(unicode.Lu, unicode.Other_Uppercase), true
+	case "Cased":
+		return regexpTableRanges(unicode.Lu, unicode.Ll, unicode.Lt, unicode.Other_Lowercase, unicode.Other_Uppercase), true
+	case "Math":
+		return regexpTableRanges(unicode.Sm, unicode.Other_Math), true
+	case "Grapheme_Extend":
+		return regexpTableRanges(unicode.Me, unicode.Mn, unicode.Other_Grapheme_Extend), true
+	case "Grapheme_Base":
+		excluded := regexpTableRanges(unicode.Cc, unicode.Cf, unicode.Cs, unicode.Co, unicode.Zl, unicode.Zp,
+			unicode.Me, unicode.Mn, unicode.Other_Grapheme_Extend)
+		return intersectRegExpRanges(regexpAssigned(), complementRegExpRanges(excluded)), true
+	case "ID_Start":
+		return intersectRegExpRanges(regexpTableRanges(unicode.L, unicode.Nl, unicode.Other_ID_Start),
+			complementRegExpRanges(regexpTableRanges(unicode.Pattern_Syntax, unicode.Pattern_White_Space))), true
+	case "ID_Continue":
+		return intersectRegExpRanges(regexpTableRanges(unicode.L, unicode.Nl, unicode.Other_ID_Start,
+			unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue),
+			complementRegExpRanges(regexpTableRanges(unicode.Pattern_Syntax, unicode.Pattern_White_Space))), true
+	}
+	if regexpBinaryProperties[name] {
+		if t, exists := unicode.Properties[name]; exists {
+			return regexpTableRanges(t), true
+		}
+	}
+	if ranges, exists := regexpEmojiProperties[name]; exists {
+		return ranges, true
+	}
+	return nil, false
+}
+
+// regexpStringProperty is the value of a property of strings: the code points and the strings of more than one
+// code point it matches.
+type regexpStringProperty struct {
+	ranges  []regexpRange
+	strings []string
+}
+
+// regexpPropertyOfStrings returns the set matched by the property of strings \p{name}. RGI_Emoji is the union of
+// the others.
+func regexpPropertyOfStrings(name string) (*regexpCharSet, bool) {
+	var props []regexpStringProperty
+	if name == "RGI_Emoji" {
+		for _, prop := range regexpEmojiSequences {
+			props = append(props, prop)
+		}
+	} else if prop, exists := regexpEmojiSequences[name]; exists {
+		props = append(props, prop)
+	} else {
+		return nil, false
+	}
+	set := &regexpCharSet{strings: make(map[string]struct{})}
+	for _, prop := range props {
+		set.ranges = append(set.ranges, prop.ranges...)
+		for _, str := range prop.strings {
+			set.strings[str] = struct{}{}
+		}
+	}
+	set.ranges = normalizeRegExpRanges(set.ranges)
+	return set, true
+}
+
+// regexpBinaryProperties are the binary properties which have tables in the unicode package.
+var regexpBinaryProperties = map[string]bool{
+	"ASCII_Hex_Digit":         true,
+	"Bidi_Control":            true,
+	"Dash":                    true,
+	"Deprecated":              true,
+	"Diacritic":               true,
+	"Extender":                true,
+	"Hex_Digit":               true,
+	"IDS_Binary_Operator":     true,
+	"IDS_Trinary_Operator":    true,
+	"Ideographic":             true,
+	"Join_Control":            true,
+	"Logical_Order_Exception": true,
+	"Noncharacter_Code_Point": true,
+	"Pattern_Syntax":          true,
+	"Pattern_White_Space":     true,
+	"Quotation_Mark":          true,
+	"Radical":                 true,
+	"Regional_Indicator":      true,
+	"Sentence_Terminal":       true,
+	"Soft_Dotted":             true,
+	"Terminal_Punctuation":    true,
+	"Unified_Ideograph":       true,
+	"Variation_Selector":      true,
+	"White_Space":             true,
+}
+
+var regexpBinaryPropertyAliases = map[string]string{
+	"AHex":    "ASCII_Hex_Digit",
+	"Alpha":   "Alphabetic",
+	"Bidi_C":  "Bidi_Control",
+	"Dep":     "Deprecated",
+	"Dia":     "Diacritic",
+	"EBase":   "Emoji_Modifier_Base",
+	"EComp":   "Emoji_Component",
+	"EMod":    "Emoji_Modifier",
+	"EPres":   "Emoji_Presentation",
+	"Ext":     "Extender",
+	"ExtPict": "Extended_Pictographic",
+	"Gr_Base": "Grapheme_Base",
+	"Gr_Ext":  "Grapheme_Extend",
+	"Hex":     "Hex_Digit",
+	"IDC":     "ID_Continue",
+	"IDS":     "ID_Start",
+	"IDSB":    "IDS_Binary_Operator",
+	"IDST":    "IDS_Trinary_Operator",
+	"Ideo":    "Ideographic",
+	"Join_C":  "Join_Control",
+	"LOE":     "Logical_Order_Exception",
+	"Lower":   "Lowercase",
+	"NChar":   "Noncharacter_Code_Point",
+	"Pat_Syn": "Pattern_Syntax",
+	"Pat_WS":  "Pattern_White_Space",
+	"QMark":   "Quotation_Mark",
+	"RI":      "Regional_Indicator",
+	"SD":      "Soft_Dotted",
+	"STerm":   "Sentence_Terminal",
+	"Term":    "Terminal_Punctuation",
+	"UIdeo":   "Unified_Ideograph",
+	"Upper":   "Uppercase",
+	"VS":      "Variation_Selector",
+	"space":   "White_Space",
+}
+
+var regexpGeneralCategoryAliases = map[string]string{
+	"Other":                 "C",
+	"Control":               "Cc",
+	"cntrl":                 "Cc",
+	"Format":                "Cf",
+	"Unassigned":            "Cn",
+	"Private_Use":           "Co",
+	"Surrogate":             "Cs",
+	"Letter":                "L",
+	"Cased_Letter":          "LC",
+	"Lowercase_Letter":      "Ll",
+	"Modifier_Letter":       "Lm",
+	"Other_Letter":          "Lo",
+	"Titlecase_Letter":      "Lt",
+	"Uppercase_Letter":      "Lu",
+	"Mark":                  "M",
+	"Combining_Mark":        "M",
+	"Spacing_Mark":          "Mc",
+	"Enclosing_Mark":        "Me",
+	"Nonspacing_Mark":       "Mn",
+	"Number":                "N",
+	"Decimal_Number":        "Nd",
+	"digit":                 "Nd",
+	"Letter_Number":         "Nl",
+	"Other_Number":          "No",
+	"Punctuation":           "P",
+	"punct":                 "P",
+	"Connector_Punctuation": "Pc",
+	"Dash_Punctuation":      "Pd",
+	"Close_Punctuation":     "Pe",
+	"Final_Punctuation":     "Pf",
+	"Initial_Punctuation":   "Pi",
+	"Other_Punctuation":     "Po",
+	"Open_Punctuation":      "Ps",
+	"Symbol":                "S",
+	"Currency_Symbol":       "Sc",
+	"Modifier_Symbol":       "Sk",
+	"Math_Symbol":           "Sm",
+	"Other_Symbol":          "So",
+	"Separator":             "Z",
+	"Line_Separator":        "Zl",
+	"Paragraph_Separator":   "Zp",
+	"Space_Separator":       "Zs",
+}
+
+// regexpScriptAliases maps the ISO 15924 codes to the script names used by the unicode package.
+var regexpScriptAliases = map[string]string{
+	"Adlm": "Adlam",
+	"Aghb": "Caucasian_Albanian",
+	"Arab": "Arabic",
+	"Armi": "Imperial_Aramaic",
+	"Armn": "Armenian",
+	"Avst": "Avestan",
+	"Bali": "Balinese",
+	"Bamu": "Bamum",
+	"Bass": "Bassa_Vah",
+	"Batk": "Batak",
+	"Beng": "Bengali",
+	"Berf": "Beria_Erfe",
+	"Bhks": "Bhaiksuki",
+	"Bopo": "Bopomofo",
+	"Brah": "Brahmi",
+	"Brai": "Braille",
+	"Bugi": "Buginese",
+	"Buhd": "Buhid",
+	"Cakm": "Chakma",
+	"Cans": "Canadian_Aboriginal",
+	"Cari": "Carian",
+	"Cher": "Cherokee",
+	"Chrs": "Chorasmian",
+	"Copt": "Coptic",
+	"Cpmn": "Cypro_Minoan",
+	"Cprt": "Cypriot",
+	"Cyrl": "Cyrillic",
+	"Deva": "Devanagari",
+	"Diak": "Dives_Akuru",
+	"Dogr": "Dogra",
+	"Dsrt": "Deseret",
+	"Dupl": "Duployan",
+	"Egyp": "Egyptian_Hieroglyphs",
+	"Elba": "Elbasan",
+	"Elym": "Elymaic",
+	"Ethi": "Ethiopic",
+	"Gara": "Garay",
+	"Geor": "Georgian",
+	"Glag": "Glagolitic",
+	"Gong": "Gunjala_Gondi",
+	"Gonm": "Masaram_Gondi",
+	"Goth": "Gothic",
+	"Gran": "Grantha",
+	"Grek": "Greek",
+	"Gujr": "Gujarati",
+	"Gukh": "Gurung_Khema",
+	"Guru": "Gurmukhi",
+	"Hang": "Hangul",
+	"Hani": "Han",
+	"Hano": "Hanunoo",
+	"Hatr": "Hatran",
+	"Hebr": "Hebrew",
+	"Hira": "Hiragana",
+	"Hluw": "Anatolian_Hieroglyphs",
+	"Hmng": "Pahawh_Hmong",
+	"Hmnp": "Nyiakeng_Puachue_Hmong",
+	"Hung": "Old_Hungarian",
+	"Ital": "Old_Italic",
+	"Java": "Javanese",
+	"Kali": "Kayah_Li",
+	"Kana": "Katakana",
+	"Khar": "Kharoshthi",
+	"Khmr": "Khmer",
+	"Khoj": "Khojki",
+	"Kits": "Khitan_Small_Script",
+	"Knda": "Kannada",
+	"Krai": "Kirat_Rai",
+	"Kthi": "Kaithi",
+	"Lana": "Tai_Tham",
+	"Laoo": "Lao",
+	"Latn": "Latin",
+	"Lepc": "Lepcha",
+	"Limb": "Limbu",
+	"Lina": "Linear_A",
+	"Linb": "Linear_B",
+	"Lyci": "Lycian",
+	"Lydi": "Lydian",
+	"Mahj": "Mahajani",
+	"Maka": "Makasar",
+	"Mand": "Mandaic",
+	"Mani": "Manichaean",
+	"Marc": "Marchen",
+	"Medf": "Medefaidrin",
+	"Mend": "Mende_Kikakui",
+	"Merc": "Meroitic_Cursive",
+	"Mero": "Meroitic_Hieroglyphs",
+	"Mlym": "Malayalam",
+	"Mong": "Mongolian",
+	"Mroo": "Mro",
+	"Mtei": "Meetei_Mayek",
+	"Mult": "Multani",
+	"Mymr": "Myanmar",
+	"Nagm": "Nag_Mundari",
+	"Nand": "Nandinagari",
+	"Narb": "Old_North_Arabian",
+	"Nbat": "Nabataean",
+	"Nkoo": "Nko",
+	"Nshu": "Nushu",
+	"Ogam": "Ogham",
+	"Olck": "Ol_Chiki",
+	"Onao": "Ol_Onal",
+	"Orkh": "Old_Turkic",
+	"Orya": "Oriya",
+	"Osge": "Osage",
+	"Osma": "Osmanya",
+	"Ougr": "Old_Uyghur",
+	"Palm": "Palmyrene",
+	"Pauc": "Pau_Cin_Hau",
+	"Perm": "Old_Permic",
+	"Phag": "Phags_Pa",
+	"Phli": "Inscriptional_Pahlavi",
+	"Phlp": "Psalter_Pahlavi",
+	"Phnx": "Phoenician",
+	"Plrd": "Miao",
+	"Prti": "Inscriptional_Parthian",
+	"Qaac": "Coptic",
+	"Qaai": "Inherited",
+	"Rjng": "Rejang",
+	"Rohg": "Hanifi_Rohingya",
+	"Runr": "Runic",
+	"Samr": "Samaritan",
+	"Sarb": "Old_South_Arabian",
+	"Saur": "Saurashtra",
+	"Sgnw": "SignWriting",
+	"Shaw": "Shavian",
+	"Shrd": "Sharada",
+	"Sidd": "Siddham",
+	"Sidt": "Sidetic",
+	"Sind": "Khudawadi",
+	"Sinh": "Sinhala",
+	"Sogd": "Sogdian",
+	"Sogo": "Old_Sogdian",
+	"Sora": "Sora_Sompeng",
+	"Soyo": "Soyombo",
+	"Sund": "Sundanese",
+	"Sunu": "Sunuwar",
+	"Sylo": "Syloti_Nagri",
+	"Syrc": "Syriac",
+	"Tagb": "Tagbanwa",
+	"Takr": "Takri",
+	"Tale": "Tai_Le",
+	"Talu": "New_Tai_Lue",
+	"Taml": "Tamil",
+	"Tang": "Tangut",
+	"Tavt": "Tai_Viet",
+	"Tayo": "Tai_Yo",
+	"Telu": "Telugu",
+	"Tfng": "Tifinagh",
+	"Tglg": "Tagalog",
+	"Thaa": "Thaana",
+	"Tibt": "Tibetan",
+	"Tirh": "Tirhuta",
+	"Tnsa": "Tangsa",
+	"Todr": "Todhri",
+	"Tols": "Tolong_Siki",
+	"Tutg": "Tulu_Tigalari",
+	"Ugar": "Ugaritic",
+	"Vaii": "Vai",
+	"Vith": "Vithkuqi",
+	"Wara": "Warang_Citi",
+	"Wcho": "Wancho",
+	"Xpeo": "Old_Persian",
+	"Xsux": "Cuneiform",
+	"Yezi": "Yezidi",
+	"Yiii": "Yi",
+	"Zanb": "Zanabazar_Square",
+	"Zinh": "Inherited",
+	"Zyyy": "Common",
+	"Zzzz": "Unknown",
+}
@@ -9,6 +9,7 @@ type _scope struct {
 	outer           *_scope
 	allowIn         bool
 	allowLet        bool
+	allowUsing      bool // using declarations are allowed in the current statement list
 	inIteration     bool
 	inSwitch        bool
 	inFuncParams    bool
@@ -29,6 +29,7 @@ func (self *_parser) parseEmptyStatement() ast.Statement {
 func (self *_parser) parseStatementList() (list []ast.Statement) {
 	for self.token != token.RIGHT_BRACE && self.token != token.EOF {
 		self.scope.allowLet = true
+		self.scope.allowUsing = true
 		list = append(list, self.parseStatement())
 	}
 
@@ -73,6 +74,14 @@ func (self *_parser) parseStatement() ast.Statement {
 		self.insertSemicolon = true
 	case token.CONST:
 		return self.parseLexicalDeclaration(self.token)
+	case token.IDENTIFIER:
+		if self.literal == "using" && self.isUsingDeclaration(false, false) {
+			return self.parseUsingDeclaration()
+		}
+	case token.AWAIT:
+		if self.scope.allowAwait && self.scope.inAsync && self.isUsingDeclaration(true, false) {
+			return self.parseUsingDeclaration()
+		}
 	case token.ASYNC:
 		if f := self.parseMaybeAsyncFunction(true); f != nil {
 			return &ast.FunctionDeclaration{
@@ -581,6 +590,7 @@ func (self *_parser) parseCaseStatement() *ast.CaseStatement {
 			break
 		}
 		self.scope.allowLet = true
+		self.scope.allowUsing = false
 		node.Consequent = append(node.Consequent, self.parseStatement())
 
 	}
@@ -655,6 +665,14 @@ func (self *_parser) parseFor(idx file.Idx, initializer ast.ForLoopInitializer)
 
 func (self *_parser) parseForOrForInStatement() ast.Statement {
 	idx := self.expect(token.FOR)
+	await := false
+	if self.token == token.AWAIT && self.scope.allowAwait {
+		if !self.scope.inAsync {
+			self.errorUnexpectedToken(token.AWAIT)
+		}
+		await = true
+		self.next()
+	}
 	self.expect(token.LEFT_PARENTHESIS)
 
 	var initializer ast.ForLoopInitializer
@@ -674,8 +692,18 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 				tok = token.IDENTIFIER
 			}
 		}
-		if tok == token.VAR || tok == token.LET || tok == token.CONST {
+		awaitUsing := false
+		if tok == token.IDENTIFIER && self.literal == "using" && self.isUsingDeclaration(false, true) {
+			tok = token.USING
+		} else if tok == token.AWAIT && self.scope.allowAwait && self.scope.inAsync && self.isUsingDeclaration(true, true) {
+			tok = token.USING
+			awaitUsing = true
+		}
+		if tok == token.VAR || tok == token.LET || tok == token.CONST || tok == token.USING {
 			idx := self.idx
+			if awaitUsing {
+				self.next() // await
+			}
 			self.next()
 			var list []*ast.Binding
 			if tok == token.VAR {
@@ -684,7 +712,7 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 				list = self.parseVariableDeclarationList()
 			}
 			if len(list) == 1 {
-				if self.token == token.IN {
+				if self.token == token.IN && !await {
 					self.next() // in
 					forIn = true
 				} else if self.token == token.IDENTIFIER && self.literal == "of" {
@@ -696,6 +724,13 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 				if list[0].Initializer != nil {
 					self.error(list[0].Initializer.Idx0(), "for-in loop variable declaration may not have an initializer")
 				}
+				if tok == token.USING {
+					if forIn {
+						self.error(idx, "for-in loop variable declaration may not be a using declaration")
+					} else if _, ok := list[0].Target.(ast.Pattern); ok {
+						self.error(list[0].Idx0(), "Using declarations may not have binding patterns")
+					}
+				}
 				if tok == token.VAR {
 					into = &ast.ForIntoVar{
 						Binding: list[0],
@@ -703,12 +738,18 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 				} else {
 					into = &ast.ForDeclaration{
 						Idx:     idx,
-						IsConst: tok == token.CONST,
+						IsConst: tok == token.CONST || tok == token.USING,
+						Using:   tok == token.USING,
+						Await:   awaitUsing,
 						Target:  list[0].Target,
 					}
 				}
 			} else {
-				self.ensurePatternInit(list)
+				if tok == token.USING {
+					self.ensureUsingInit(list)
+				} else {
+					self.ensurePatternInit(list)
+				}
 				if tok == token.VAR {
 					initializer = &ast.ForLoopInitializerVarDeclList{
 						List: list,
@@ -718,6 +759,7 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 						LexicalDeclaration: ast.LexicalDeclaration{
 							Idx:   idx,
 							Token: tok,
+							Await: awaitUsing,
 							List:  list,
 						},
 					}
@@ -725,7 +767,7 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 			}
 		} else {
 			expr := self.parseExpression()
-			if self.token == token.IN {
+			if self.token == token.IN && !await {
 				self.next()
 				forIn = true
 			} else if self.token == token.IDENTIFIER && self.literal == "of" {
@@ -757,12 +799,19 @@ func (self *_parser) parseForOrForInStatement() ast.Statement {
 		self.scope.allowIn = allowIn
 	}
 
+	if forOf {
+		stmt := self.parseForOf(idx, into)
+		stmt.Await = await
+		return stmt
+	}
+	if await {
+		self.errorUnexpectedToken(self.token)
+		self.nextStatement()
+		return &ast.BadStatement{From: idx, To: self.idx}
+	}
 	if forIn {
 		return self.parseForIn(idx, into)
 	}
-	if forOf {
-		return self.parseForOf(idx, into)
-	}
 
 	self.expect(token.SEMICOLON)
 	return self.parseFor(idx, initializer)
@@ -810,6 +859,45 @@ func (self *_parser) parseLexicalDeclaration(tok token.Token) *ast.LexicalDeclar
 	}
 }
 
+// parseUsingDeclaration parses a 'using' or an 'await using' declaration.
+func (self *_parser) parseUsingDeclaration() *ast.LexicalDeclaration {
+	idx := self.idx
+	await := self.token == token.AWAIT
+	if await {
+		self.next()
+	}
+	self.next() // using
+	if !self.scope.allowLet {
+		self.error(idx, "Lexical declaration cannot appear in a single-statement context")
+	} else if !self.scope.allowUsing {
+		self.error(idx, "Using declarations are not allowed at the top level of a script or directly in a switch case")
+	}
+
+	list := self.parseVariableDeclarationList()
+	self.ensureUsingInit(list)
+	self.semicolon()
+
+	return &ast.LexicalDeclaration{
+		Idx:   idx,
+		Token: token.USING,
+		Await: await,
+		List:  list,
+	}
+}
+
+func (self *_parser) ensureUsingInit(list []*ast.Binding) {
+	for _, item := range list {
+		if _, ok := item.Target.(ast.Pattern); ok {
+			self.error(item.Idx0(), "Using declarations may not have binding patterns")
+			break
+		}
+		if item.Initializer == nil {
+			self.error(item.Idx1(), "Missing initializer in using declaration")
+			break
+		}
+	}
+}
+
 func (self *_parser) parseDoWhileStatement() ast.Statement {
 	inIteration := self.scope.inIteration
 	self.scope.inIteration = true
@@ -851,9 +939,10 @@ func (self *_parser) parseWhileStatement() ast.Statement {
 }
 
 func (self *_parser) parseIfStatement() ast.Statement {
-	self.expect(token.IF)
+	idx := self.expect(token.IF)
 	self.expect(token.LEFT_PARENTHESIS)
 	node := &ast.IfStatement{
+		If:   idx,
 		Test: self.parseExpression(),
 	}
 	self.expect(token.RIGHT_PARENTHESIS)
@@ -877,7 +966,12 @@ func (self *_parser) parseIfStatement() ast.Statement {
 func (self *_parser) parseSourceElements() (body []ast.Statement) {
 	for self.token != token.EOF {
 		self.scope.allowLet = true
-		body = append(body, self.parseStatement())
+		self.scope.allowUsing = self.module
+		if self.module {
+			body = append(body, self.parseModuleItem())
+		} else {
+			body = append(body, self.parseStatement())
+		}
 	}
 
 	return body
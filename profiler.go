@@ -3,6 +3,7 @@ package goja
 import (
 	"errors"
 	"io"
+	"math"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -11,6 +12,7 @@ import (
 	"github.com/google/pprof/profile"
 )
 
+// Defaults of ProfileOptions, always used by StartProfile
 const profInterval = 10 * time.Millisecond
 const profMaxStackDepth = 64
 
@@ -34,7 +36,7 @@ type profTracker struct {
 	req, finished int32
 	start, stop   time.Time
 	numFrames     int
-	frames        [profMaxStackDepth]StackFrame
+	frames        []StackFrame
 }
 
 type profiler struct {
@@ -42,6 +44,10 @@ type profiler struct {
 	trackers []*profTracker
 	buf      *profBuffer
 	running  bool
+
+	interval      time.Duration
+	maxStackDepth int
+	allocRate     int64
 }
 
 type profFunc struct {
@@ -57,12 +63,26 @@ type profSampleNode struct {
 }
 
 type profBuffer struct {
-	funcs map[*Program]*profFunc
-	root  profSampleNode
+	funcs  map[*Program]*profFunc
+	root   profSampleNode
+	period time.Duration
+	allocs bool // Samples have the alloc_objects and alloc_space values too
 }
 
 func (pb *profBuffer) addSample(pt *profTracker) {
-	sampleFrames := pt.frames[:pt.numFrames]
+	smpl := pb.sample(pt.frames[:pt.numFrames])
+	smpl.Value[0]++
+	smpl.Value[1] += int64(pt.stop.Sub(pt.start))
+}
+
+func (pb *profBuffer) addAllocation(frames []StackFrame, objects, bytes int64) {
+	smpl := pb.sample(frames)
+	smpl.Value[2] += objects
+	smpl.Value[3] += bytes
+}
+
+// sample returns the sample of a stack, creating it if it's the first time the stack is seen
+func (pb *profBuffer) sample(sampleFrames []StackFrame) *profile.Sample {
 	n := &pb.root
 	for j := len(sampleFrames) - 1; j >= 0; j-- {
 		frame := sampleFrames[j]
@@ -104,14 +124,17 @@ func (pb *profBuffer) addSample(pt *profTracker) {
 		for n1 := n; n1.loc != nil; n1 = n1.parent {
 			locs = append(locs, n1.loc)
 		}
+		values := 2
+		if pb.allocs {
+			values = 4
+		}
 		smpl = &profile.Sample{
 			Location: locs,
-			Value:    make([]int64, 2),
+			Value:    make([]int64, values),
 		}
 		n.sample = smpl
 	}
-	smpl.Value[0]++
-	smpl.Value[1] += int64(pt.stop.Sub(pt.start))
+	return smpl
 }
 
 func (pb *profBuffer) profile() *profile.Profile {
@@ -121,7 +144,13 @@ func (pb *profBuffer) profile() *profile.Profile {
 		{Type: "cpu", Unit: "nanoseconds"},
 	}
 	pr.PeriodType = pr.SampleType[1]
-	pr.Period = int64(profInterval)
+	if pb.allocs {
+		pr.SampleType = append(pr.SampleType,
+			&profile.ValueType{Type: "alloc_objects", Unit: "count"},
+			&profile.ValueType{Type: "alloc_space", Unit: "bytes"},
+		)
+	}
+	pr.Period = int64(pb.period)
 	mapping := &profile.Mapping{
 		ID:   1,
 		File: "[ECMAScript code]",
@@ -132,11 +161,14 @@ func (pb *profBuffer) profile() *profile.Profile {
 	pr.Function = make([]*profile.Function, 0, len(pb.funcs))
 	funcNames := make(map[string]struct{})
 	var funcId, locId uint64
+	// The instructions of a line share its location
+	merged := make(map[*profile.Location]*profile.Location)
 	for prg, f := range pb.funcs {
-		fileName := prg.src.Name()
+		start := prg.src.Position(prg.sourceOffset(0))
 		funcId++
 		f.f.ID = funcId
-		f.f.Filename = fileName
+		f.f.Filename = start.Filename
+		f.f.StartLine = int64(start.Line)
 		var funcName string
 		if prg.funcName != "" {
 			funcName = prg.funcName.String()
@@ -152,10 +184,16 @@ func (pb *profBuffer) profile() *profile.Profile {
 		}
 		f.f.Name = funcName
 		pr.Function = append(pr.Function, &f.f)
+		lines := make(map[int]*profile.Location, len(f.locs))
 		for pc, loc := range f.locs {
+			pos := prg.src.Position(prg.sourceOffset(int(pc)))
+			if lineLoc := lines[pos.Line]; lineLoc != nil {
+				merged[loc] = lineLoc
+				continue
+			}
+			lines[pos.Line] = loc
 			locId++
 			loc.ID = locId
-			pos := prg.src.Position(prg.sourceOffset(int(pc)))
 			loc.Line = []profile.Line{
 				{
 					Function: &f.f,
@@ -168,6 +206,13 @@ func (pb *profBuffer) profile() *profile.Profile {
 		}
 	}
 	pb.addSamples(&pr, &pb.root)
+	for _, smpl := range pr.Sample {
+		for i, loc := range smpl.Location {
+			if lineLoc := merged[loc]; lineLoc != nil {
+				smpl.Location[i] = lineLoc
+			}
+		}
+	}
 	return &pr
 }
 
@@ -180,8 +225,10 @@ func (pb *profBuffer) addSamples(p *profile.Profile, n *profSampleNode) {
 	}
 }
 
-func (p *profiler) run() {
-	ticker := time.NewTicker(profInterval)
+// run sends the sampling requests to the VMs, starting with the interval read by the caller with the lock held.
+// Later changes of p.interval are only read with the lock held too.
+func (p *profiler) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	counter := 0
 
 	for ts := range ticker.C {
@@ -190,6 +237,11 @@ func (p *profiler) run() {
 		if left == 0 {
 			break
 		}
+		if p.interval != interval {
+			// The profiler has been restarted with another interval before it was done with the previous VMs
+			interval = p.interval
+			ticker.Reset(interval)
+		}
 		for {
 			// This loop runs until either one of the VMs is signalled or all of the VMs are scanned and found
 			// busy or deleted.
@@ -231,9 +283,10 @@ func (p *profiler) registerVm() *profTracker {
 	pt := new(profTracker)
 	p.mu.Lock()
 	if p.buf != nil {
+		pt.frames = make([]StackFrame, p.maxStackDepth)
 		p.trackers = append(p.trackers, pt)
 		if !p.running {
-			go p.run()
+			go p.run(p.interval)
 			p.running = true
 		}
 	} else {
@@ -243,13 +296,14 @@ func (p *profiler) registerVm() *profTracker {
 	return pt
 }
 
-func (p *profiler) start() error {
+func (p *profiler) start(interval time.Duration, maxStackDepth int, allocRate int64) error {
 	p.mu.Lock()
 	if p.buf != nil {
 		p.mu.Unlock()
 		return errors.New("profiler is already active")
 	}
-	p.buf = new(profBuffer)
+	p.buf = &profBuffer{period: interval, allocs: allocRate > 0}
+	p.interval, p.maxStackDepth, p.allocRate = interval, maxStackDepth, allocRate
 	p.mu.Unlock()
 	return nil
 }
@@ -325,10 +379,11 @@ because otherwise the graph view merges them together (even if they are in diffe
 
 The sampling period is set to 10ms.
 
-It returns an error if profiling is already active.
+It returns an error if profiling is already active. Runtime.StartProfile and StartProfiler profile chosen
+Runtimes instead, and are not affected by the process-wide profile.
 */
 func StartProfile(w io.Writer) error {
-	err := globalProfiler.p.start()
+	err := globalProfiler.p.start(profInterval, profMaxStackDepth, 0)
 	if err != nil {
 		return err
 	}
@@ -348,3 +403,114 @@ func StopProfile() {
 	}
 	globalProfiler.w = nil
 }
+
+// ProfileOptions configures the profiles started with Runtime.StartProfile and StartProfiler
+type ProfileOptions struct {
+	// Interval is the sampling period, 10ms if zero
+	Interval time.Duration
+	// MaxStackDepth is the number of frames kept in a sample, the innermost ones, 64 if zero
+	MaxStackDepth int
+	// AllocationRate adds the alloc_objects and alloc_space sample types to the profile if it's not zero:
+	// the objects, arrays and strings created by the code are sampled every AllocationRate bytes, 1 records
+	// them all. A sampled allocation stands for all the bytes allocated since the previous one.
+	// Sizes are estimates based on the kind of the values: the objects, the initial elements of the array
+	// literals and the strings made by concatenation and template literals are counted, later growth isn't.
+	AllocationRate int64
+}
+
+// Profiler is an execution time profile of a set of Runtimes, running independently of the process-wide
+// profile started by StartProfile and of the other Profilers.
+type Profiler struct {
+	p profiler
+
+	mu       sync.Mutex
+	runtimes []*Runtime
+	stopped  bool
+}
+
+// StartProfiler starts profiling a set of Runtimes, more can be added with Add. A Runtime can only be part
+// of one Profiler at a time. The profile is the same as the one of StartProfile, restricted to the code
+// run by these Runtimes.
+func StartProfiler(opts ProfileOptions, runtimes ...*Runtime) (*Profiler, error) {
+	if opts.Interval < 0 || opts.MaxStackDepth < 0 || opts.AllocationRate < 0 {
+		return nil, errors.New("invalid profile options")
+	}
+	if opts.Interval == 0 {
+		opts.Interval = profInterval
+	}
+	if opts.MaxStackDepth == 0 {
+		opts.MaxStackDepth = profMaxStackDepth
+	}
+	p := &Profiler{}
+	if err := p.p.start(opts.Interval, opts.MaxStackDepth, opts.AllocationRate); err != nil {
+		return nil, err
+	}
+	for _, r := range runtimes {
+		if err := p.Add(r); err != nil {
+			p.Stop()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// StartProfile starts profiling the Runtime, see StartProfiler.
+func (r *Runtime) StartProfile(opts ProfileOptions) (*Profiler, error) {
+	return StartProfiler(opts, r)
+}
+
+// Add adds a Runtime to the profile. It's safe to call while the Runtime is running. It fails if the
+// Runtime is already being profiled by another Profiler or if the profile is stopped.
+func (p *Profiler) Add(r *Runtime) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return errors.New("the profile is stopped")
+	}
+	if !r.vm.profiler.CompareAndSwap(nil, &p.p) {
+		if r.vm.profiler.Load() == &p.p {
+			return nil
+		}
+		return errors.New("the runtime is already being profiled")
+	}
+	p.runtimes = append(p.runtimes, r)
+	return nil
+}
+
+// Stop stops profiling and returns the profile, which can be written in the pprof format with its Write
+// method. It returns nil if the profile is already stopped.
+func (p *Profiler) Stop() *profile.Profile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return nil
+	}
+	p.stopped = true
+	for _, r := range p.runtimes {
+		r.vm.profiler.CompareAndSwap(&p.p, nil)
+	}
+	p.runtimes = nil
+	return p.p.stop()
+}
+
+// profileAlloc records an allocation made while the VM is part of an allocation profile, see
+// ProfileOptions.AllocationRate.
+func (vm *vm) profileAlloc(objects, bytes int64) {
+	p := vm.profiler.Load()
+	if p == nil || p.allocRate == 0 || bytes <= 0 {
+		return
+	}
+	vm.allocBytes += bytes
+	if vm.allocBytes < p.allocRate {
+		return
+	}
+	total := vm.allocBytes
+	vm.allocBytes = 0
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.buf != nil {
+		frames := vm.r.CaptureCallStack(p.maxStackDepth, nil)
+		p.buf.addAllocation(frames, int64(math.Round(float64(objects)*float64(total)/float64(bytes))), total)
+	}
+}
@@ -1,9 +1,12 @@
 package goja
 
 import (
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/google/pprof/profile"
 )
 
 func TestProfiler(t *testing.T) {
@@ -100,3 +103,140 @@ func TestProfiler1(t *testing.T) {
 		t.Fatal("No samples were recorded")
 	}
 }
+
+func TestRuntimeProfiler(t *testing.T) {
+	const SCRIPT = `
+	function %s() {
+		for(;;) {}
+	}
+	%s();
+	`
+
+	vm1, vm2, vm3 := New(), New(), New()
+	p1, err := vm1.StartProfile(ProfileOptions{Interval: time.Millisecond, MaxStackDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := StartProfiler(ProfileOptions{}, vm2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Add(vm1); err == nil {
+		t.Fatal("Expected an error for a runtime in another profile")
+	}
+
+	for i, vm := range []*Runtime{vm1, vm2, vm3} {
+		name := []string{"first", "second", "third"}[i]
+		vm := vm
+		go func() {
+			_, err := vm.RunScript(name+".js", fmt.Sprintf(SCRIPT, name, name))
+			if _, ok := err.(*InterruptedError); !ok {
+				panic(err)
+			}
+		}()
+	}
+	defer func() {
+		vm1.Interrupt(nil)
+		vm2.Interrupt(nil)
+		vm3.Interrupt(nil)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	pr1 := p1.Stop()
+	// A runtime can be added to a profile while it's running
+	if err := p2.Add(vm3); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	pr2 := p2.Stop()
+	if p2.Stop() != nil {
+		t.Fatal("The profile is stopped twice")
+	}
+
+	functions := func(pr *profile.Profile) map[string]bool {
+		names := make(map[string]bool)
+		for _, s := range pr.Sample {
+			for _, loc := range s.Location {
+				names[loc.Line[0].Function.Name] = true
+			}
+		}
+		return names
+	}
+	if names := functions(pr1); len(names) != 1 || !names["first"] {
+		t.Errorf("Unexpected functions in the first profile: %v", names)
+	}
+	if names := functions(pr2); names["first"] || !names["second"] || !names["third"] {
+		t.Errorf("Unexpected functions in the second profile: %v", names)
+	}
+	for _, s := range pr1.Sample {
+		if len(s.Location) > 1 {
+			t.Fatalf("The stack is deeper than the maximum: %v", s.Location)
+		}
+	}
+	if pr1.Period != int64(time.Millisecond) || pr2.Period != int64(profInterval) {
+		t.Errorf("Unexpected periods %d, %d", pr1.Period, pr2.Period)
+	}
+
+	// The runtime is no longer profiled
+	p3, err := StartProfiler(ProfileOptions{}, vm1, vm2, vm3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p3.Stop()
+}
+
+func TestProfilerAllocations(t *testing.T) {
+	const SCRIPT = `
+	function build(n) {
+		var list = [];
+		for (var i = 0; i < n; i++) {
+			list.push({i: i});
+			var s = "item" + i;
+		}
+		return list;
+	}
+	build(1000);
+	`
+
+	vm := New()
+	p, err := vm.StartProfile(ProfileOptions{AllocationRate: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.RunScript("alloc.js", SCRIPT); err != nil {
+		t.Fatal(err)
+	}
+	pr := p.Stop()
+
+	if len(pr.SampleType) != 4 || pr.SampleType[2].Type != "alloc_objects" || pr.SampleType[3].Type != "alloc_space" {
+		t.Fatalf("Unexpected sample types %v", pr.SampleType)
+	}
+	objects := make(map[int64]int64)
+	for _, s := range pr.Sample {
+		leaf := s.Location[0].Line[0]
+		if leaf.Function.Name == "build" {
+			objects[leaf.Line] += s.Value[2]
+			if s.Value[2] > 0 && s.Value[3] <= 0 {
+				t.Errorf("No size for the allocations at line %d", leaf.Line)
+			}
+		}
+	}
+	// Built-in objects are created lazily, when they're used for the first time
+	if objects[5] < 1000 || objects[5] > 1010 || objects[6] != 1000 {
+		t.Errorf("Unexpected allocations per line: %v", objects)
+	}
+
+	// Locations are per line
+	lines := make(map[string]bool)
+	for _, loc := range pr.Location {
+		line := loc.Line[0]
+		key := fmt.Sprintf("%s:%d", line.Function.Name, line.Line)
+		if lines[key] {
+			t.Errorf("Duplicate location for %s", key)
+		}
+		lines[key] = true
+		if line.Function.Name == "build" && (line.Function.StartLine != 2 || line.Function.Filename != "alloc.js") {
+			t.Errorf("Unexpected function %+v", line.Function)
+		}
+	}
+}
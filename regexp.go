@@ -61,7 +61,11 @@ func (rd *arrayRuneReader) ReadRune() (r rune, size int, err error) {
 type regexpPattern struct {
 	src string
 
-	global, ignoreCase, multiline, dotAll, sticky, unicode bool
+	// unicode is also set in the 'v' mode (unicodeSets)
+	global, ignoreCase, multiline, dotAll, sticky, unicode, unicodeSets, hasIndices bool
+
+	// the names of the capturing groups indexed by their numbers, nil if there are no named groups
+	groupNames []string
 
 	regexpWrapper  *regexpWrapper
 	regexp2Wrapper *regexp2Wrapper
@@ -177,13 +181,16 @@ func (p *regexpPattern) findAllSubmatchIndex(s String, start int, limit int, sti
 // clone creates a copy of the regexpPattern which can be used concurrently.
 func (p *regexpPattern) clone() *regexpPattern {
 	ret := &regexpPattern{
-		src:        p.src,
-		global:     p.global,
-		ignoreCase: p.ignoreCase,
-		multiline:  p.multiline,
-		dotAll:     p.dotAll,
-		sticky:     p.sticky,
-		unicode:    p.unicode,
+		src:         p.src,
+		global:      p.global,
+		ignoreCase:  p.ignoreCase,
+		multiline:   p.multiline,
+		dotAll:      p.dotAll,
+		sticky:      p.sticky,
+		unicode:     p.unicode,
+		unicodeSets: p.unicodeSets,
+		hasIndices:  p.hasIndices,
+		groupNames:  p.groupNames,
 	}
 	if p.regexpWrapper != nil {
 		ret.regexpWrapper = p.regexpWrapper.clone()
@@ -524,12 +531,53 @@ func (r *regexpObject) execResultToArray(target String, result []int) Value {
 			valueArray[index] = _undefined
 		}
 	}
-	match := r.val.runtime.newArrayValues(valueArray)
+	rt := r.val.runtime
+	match := rt.newArrayValues(valueArray)
 	match.self.setOwnStr("input", target, false)
 	match.self.setOwnStr("index", intToValue(int64(matchIndex)), false)
+	match.self.setOwnStr("groups", r.pattern.newGroupsObject(rt, func(idx int) Value {
+		return valueArray[idx]
+	}), false)
+	if r.pattern.hasIndices {
+		indices := make([]Value, captureCount)
+		for index := range indices {
+			if valueArray[index] != _undefined {
+				offset := index << 1
+				indices[index] = rt.newArrayValues([]Value{intToValue(int64(result[offset])), intToValue(int64(result[offset+1]))})
+			} else {
+				indices[index] = _undefined
+			}
+		}
+		indicesArray := rt.newArrayValues(indices)
+		indicesArray.self.setOwnStr("groups", r.pattern.newGroupsObject(rt, func(idx int) Value {
+			return indices[idx]
+		}), false)
+		match.self.setOwnStr("indices", indicesArray, false)
+	}
 	return match
 }
 
+// newGroupsObject creates the 'groups' object of a match, capture returns the value of the capturing group with
+// the given number. The result is undefined if the pattern has no named groups.
+func (p *regexpPattern) newGroupsObject(r *Runtime, capture func(int) Value) Value {
+	if p.groupNames == nil {
+		return _undefined
+	}
+	o := r.newBaseObject(nil, classObject)
+	for idx, name := range p.groupNames {
+		if name == "" {
+			continue
+		}
+		v := capture(idx)
+		if v == _undefined && o.hasOwnPropertyStr(unistring.NewFromString(name)) {
+			// a group with the same name has already been added, only one of them can participate in the match
+			continue
+		}
+		o.setOwnStr(unistring.NewFromString(name), v, false)
+	}
+	return o.val
+}
+
 func (r *regexpObject) getLastIndex() int64 {
 	lastIndex := toLength(r.getStr("lastIndex", nil))
 	if !r.pattern.global && !r.pattern.sticky {
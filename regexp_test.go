@@ -416,8 +416,10 @@ func TestRegexpConsecutiveMatchCache(t *testing.T) {
 		];
 		expectedMatches[0].index = 0;
 		expectedMatches[0].input = 'test1test2';
+		expectedMatches[0].groups = undefined;
 		expectedMatches[1].index = 5;
 		expectedMatches[1].input = 'test1test2';
+		expectedMatches[1].groups = undefined;
 
 		assert(deepEqual(matches, expectedMatches), "#1");
 
@@ -432,6 +434,7 @@ func TestRegexpConsecutiveMatchCache(t *testing.T) {
 		];
 		expectedMatch.index = 1;
 		expectedMatch.input = ' test5';
+		expectedMatch.groups = undefined;
 		assert(deepEqual(match, expectedMatch), "#2");
 		assert.sameValue(regex.lastIndex, 6, "#3");
 
@@ -445,6 +448,7 @@ func TestRegexpConsecutiveMatchCache(t *testing.T) {
 		];
 		expectedMatch.index = 6;
 		expectedMatch.input = ' test5test6';
+		expectedMatch.groups = undefined;
 		assert(deepEqual(match, expectedMatch), "#4");
 		assert.sameValue(regex.lastIndex, 11, "#5");
 
@@ -508,8 +512,10 @@ func TestRegexpMatchAll(t *testing.T) {
 		];
 		expectedMatches[0].index = 0;
 		expectedMatches[0].input = 'test1test2';
+		expectedMatches[0].groups = undefined;
 		expectedMatches[1].index = 5;
 		expectedMatches[1].input = 'test1test2';
+		expectedMatches[1].groups = undefined;
 
 		assert(deepEqual(matches, expectedMatches), "#1");
 		assert.sameValue(regex.lastIndex, 0, "#1 lastIndex");
@@ -530,6 +536,7 @@ func TestRegexpMatchAll(t *testing.T) {
 		];
 		expectedMatches[0].index = 1;
 		expectedMatches[0].input = ' test5';
+		expectedMatches[0].groups = undefined;
 		assert(deepEqual(matches, expectedMatches), "#2");
 		assert.sameValue(regex.lastIndex, 0, "#2 lastIndex");
 
@@ -555,8 +562,10 @@ func TestRegexpMatchAll(t *testing.T) {
 		];
 		expectedMatches[0].index = 1;
 		expectedMatches[0].input = ' test5test6';
+		expectedMatches[0].groups = undefined;
 		expectedMatches[1].index = 6;
 		expectedMatches[1].input = ' test5test6';
+		expectedMatches[1].groups = undefined;
 		assert(deepEqual(matches, expectedMatches), "#3");
 		assert.sameValue(regex.lastIndex, 0, "#3 lastindex");
 	});
@@ -754,6 +763,251 @@ func TestRegexpUnicodeEscape(t *testing.T) {
 	testScriptWithTestLib(SCRIPT, _undefined, t)
 }
 
+func TestRegexpNamedGroups(t *testing.T) {
+	const SCRIPT = `
+	let m = /(?<year>\d{4})-(?<month>\d{2})(-(?<day>\d{2}))?/.exec("on 2024-05");
+	assert.sameValue(m.groups.year, "2024");
+	assert.sameValue(m.groups.month, "05");
+	assert.sameValue(m.groups.day, undefined);
+	assert("day" in m.groups, "non-participating groups are present");
+	assert.sameValue(Object.getPrototypeOf(m.groups), null);
+	assert(compareArray(Object.keys(m.groups), ["year", "month", "day"]), "keys");
+	assert.sameValue(m[1], "2024");
+
+	m = /(\d)/.exec("a1");
+	assert(m.hasOwnProperty("groups"), "groups is always present");
+	assert.sameValue(m.groups, undefined);
+
+	assert(/(?<a>x)-\k<a>/.test("x-x"), "backreference");
+	assert(!/(?<a>x)-\k<a>/.test("x-y"), "backreference mismatch");
+	assert(/\k<a>/.test("k<a>"), "identity escape without named groups");
+	assert.sameValue(/(?<\u{41}\u0062>.)/u.exec("x").groups.Ab, "x");
+
+	assert.sameValue("2024-05".replace(/(?<y>\d+)-(?<m>\d+)/, "$<m>/$<y>$<none>|$<"), "05/2024|$<");
+	assert.sameValue("2024-05".replace(/(\d+)-(\d+)/, "$<m>"), "$<m>");
+	assert.sameValue("a1b2".replace(/(?<l>[a-z])(?<d>\d)/g, "$<d>$<l>"), "1a2b");
+	assert.sameValue("a1".replace(/(?<l>[a-z])(?<d>\d)/, function(match, p1, p2, offset, str, groups) {
+		return groups.d + groups.l + offset;
+	}), "1a0");
+	assert.sameValue("a1".replace(/([a-z])/, function() {
+		return arguments.length;
+	}), "41", "no groups argument without named groups");
+	assert.sameValue([..."a1b2".matchAll(/(?<l>[a-z])\d/g)].map(m => m.groups.l).join(), "a,b");
+
+	class MyRegExp extends RegExp {
+		exec(s) {
+			const res = super.exec(s);
+			if (res) {
+				res.groups = { x: "X" };
+			}
+			return res;
+		}
+	}
+	assert.sameValue("abc".replace(new MyRegExp("b"), "[$<x>]"), "a[X]c", "generic replace");
+
+	[
+		"(?<a>x)(?<a>y)",
+		"(?<a>x)\\k<b>",
+		"(?<1>x)",
+		"(?<a>x)\\k",
+	].forEach(function(s) {
+		assert.throws(SyntaxError, function() {
+			new RegExp(s);
+		}, s);
+	});
+	assert.throws(SyntaxError, function() {
+		new RegExp("\\k<a>", "u");
+	});
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestRegexpDuplicateNamedGroups(t *testing.T) {
+	const SCRIPT = `
+	const re = /(?<year>\d{4})-\d{2}|\d{2}-(?<year>\d{4})/;
+	assert.sameValue(re.exec("2024-05").groups.year, "2024");
+	assert.sameValue(re.exec("05-2023").groups.year, "2023");
+	assert(compareArray(Object.keys(re.exec("05-2023").groups), ["year"]), "keys");
+	assert.sameValue("05-2023".replace(re, "$<year>"), "2023");
+
+	const backref = /(?:(?<x>a)|(?<x>b))\k<x>/;
+	assert(backref.test("aa"), "aa");
+	assert(backref.test("bb"), "bb");
+	assert(!backref.test("ab"), "ab");
+	assert(!backref.test("ba"), "ba");
+
+	assert.throws(SyntaxError, function() {
+		new RegExp("(?:(?<a>x)|y)(?<a>z)");
+	});
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestRegexpHasIndices(t *testing.T) {
+	const SCRIPT = `
+	const re = /(?<word>[a-z]+)(\d)?/d;
+	assert.sameValue(re.hasIndices, true);
+	assert.sameValue(re.flags, "d");
+	assert.sameValue(re.toString(), "/(?<word>[a-z]+)(\\d)?/d");
+	assert.sameValue(/a/.hasIndices, false);
+	assert.sameValue(RegExp.prototype.hasIndices, undefined);
+	assert.sameValue(new RegExp("a", "dgimsuy").flags, "dgimsuy");
+	assert.throws(SyntaxError, () => new RegExp("a", "dd"));
+
+	let m = re.exec("12 abc");
+	assert(compareArray(m.indices[0], [3, 6]), "indices[0]");
+	assert(compareArray(m.indices[1], [3, 6]), "indices[1]");
+	assert.sameValue(m.indices[2], undefined);
+	assert.sameValue(m.indices.length, 3);
+	assert(compareArray(m.indices.groups.word, [3, 6]), "indices.groups");
+	assert.sameValue(Object.getPrototypeOf(m.indices.groups), null);
+
+	m = /b(c)/d.exec("abc");
+	assert.sameValue(m.indices.groups, undefined);
+	assert(m.indices.hasOwnProperty("groups"), "indices.groups is always present");
+	assert.sameValue(/b/.exec("abc").indices, undefined);
+
+	m = /(?=(a))\1b/d.exec("xab");
+	assert(compareArray(m.indices[0], [1, 3]), "regexp2 indices[0]");
+	assert(compareArray(m.indices[1], [1, 2]), "regexp2 indices[1]");
+
+	m = /(?<x>b)/dg.exec("\u{1F600}b");
+	assert(compareArray(m.indices.groups.x, [2, 3]), "UTF-16 indices");
+
+	const flagsOrder = [];
+	const obj = {};
+	["hasIndices", "global", "ignoreCase", "multiline", "dotAll", "unicode", "sticky"].forEach(function(name) {
+		Object.defineProperty(obj, name, {
+			get() {
+				flagsOrder.push(name);
+				return true;
+			}
+		});
+	});
+	assert.sameValue(Object.getOwnPropertyDescriptor(RegExp.prototype, "flags").get.call(obj), "dgimsuy");
+	assert(compareArray(flagsOrder, ["hasIndices", "global", "ignoreCase", "multiline", "dotAll", "unicode", "sticky"]), "flags order");
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestRegexpUnicodePropertyEscapes(t *testing.T) {
+	const SCRIPT = `
+	assert(/^\p{L}+$/u.test("Grüße"), "\\p{L}");
+	assert(/^\p{Letter}+$/u.test("Grüße"), "\\p{Letter}");
+	assert(/^\p{Script=Han}+$/u.test("漢字"), "Script=Han");
+	assert(!/^\p{sc=Hani}+$/u.test("漢字a"), "sc=Hani");
+	assert(/^\p{scx=Grek}+$/u.test("αβγ"), "scx=Grek");
+	assert(/\p{scx=Hira}/u.test("ー"), "scx=Hira");
+	assert(/\p{Script_Extensions=Kana}/u.test("ー"), "scx=Kana");
+	assert(!/\p{sc=Hira}/u.test("ー"), "sc=Hira");
+	assert(!/\p{scx=Zyyy}/u.test("ー"), "scx=Zyyy");
+	assert(/^\p{Emoji}\p{Emoji_Presentation}\p{ExtPict}$/u.test("#\u{1F600}\u{1F6FF}"), "emoji properties");
+	assert(/^\p{EMod}$/u.test("\u{1F3FB}"), "Emoji_Modifier");
+	assert(!/\p{Extended_Pictographic}/u.test("a"), "Extended_Pictographic");
+	assert(/^\P{L}+$/u.test("123"), "\\P{L}");
+	assert(/^\p{gc=Lu}\p{General_Category=Lowercase_Letter}$/u.test("Ab"), "General_Category");
+	assert(/^[\p{Lu}\d]+$/u.test("AB12"), "property in a class");
+	assert(/^[^\p{Lu}]+$/u.test("ab"), "property in a negated class");
+	assert(/^\p{Alphabetic}\p{White_Space}\p{ID_Start}\p{ID_Continue}$/u.test("a b1"), "binary properties");
+	assert(/^\p{Any}$/u.test("\u{1F600}"), "Any");
+	assert(/^\p{L}+(?=1)/u.test("abc1"), "regexp2");
+	assert(/\p{L}/.test("p{L}"), "identity escape without the u flag");
+
+	assert.throws(SyntaxError, () => new RegExp("\\p{Foo}", "u"));
+	assert.throws(SyntaxError, () => new RegExp("\\p{Script=Foo}", "u"));
+	assert.throws(SyntaxError, () => new RegExp("\\p{Script}", "u"));
+	assert.throws(SyntaxError, () => new RegExp("\\pL", "u"));
+	assert.throws(SyntaxError, () => new RegExp("\\p{RGI_Emoji}", "u"));
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestRegexpUnicodeSets(t *testing.T) {
+	const SCRIPT = `
+	const re = /[\p{L}--[a-z]]/v;
+	assert.sameValue(re.unicodeSets, true);
+	assert.sameValue(re.unicode, false);
+	assert.sameValue(re.flags, "v");
+	assert.sameValue(re.toString(), "/[\\p{L}--[a-z]]/v");
+	assert.sameValue(/a/u.unicodeSets, false);
+	assert.sameValue(RegExp.prototype.unicodeSets, undefined);
+	assert.sameValue(new RegExp("a", "dgimsvy").flags, "dgimsvy");
+	assert.throws(SyntaxError, () => new RegExp("a", "uv"));
+	assert.throws(SyntaxError, () => new RegExp("a", "vv"));
+
+	assert(re.test("Ä"), "difference");
+	assert(!re.test("a"), "difference");
+	assert(/^[\p{L}&&\p{Script=Greek}]+$/v.test("αβγ"), "intersection");
+	assert(!/[\p{L}&&\p{Script=Greek}]/v.test("abc"), "intersection");
+	assert.sameValue("hello world".replace(/[[a-z]--[aeiou]]/gv, "_"), "_e__o _o___");
+	assert.sameValue(/^[\q{abc|d}x]+$/v.exec("abcdxabc")[0], "abcdxabc");
+	assert.sameValue(/[\q{ab|abc}]/v.exec("abcd")[0], "abc", "longest string first");
+	assert.sameValue(/[\w--\d]+/v.exec("12ab_3")[0], "ab_");
+	assert.sameValue("a\u{1F600}b".split(/(?:)/v).length, 3);
+	assert.sameValue("\u{1F600}".replace(/(?:)/gv, "-"), "-\u{1F600}-");
+	assert(/^\p{RGI_Emoji}$/v.test("\u{1F468}\u200D\u{1F469}\u200D\u{1F467}"), "RGI_Emoji_ZWJ_Sequence");
+	assert.sameValue("\u{1F1EF}\u{1F1F5}1\uFE0F\u20E3".match(/\p{RGI_Emoji}/gv).length, 2, "RGI_Emoji");
+	assert(/^[\p{Emoji_Keycap_Sequence}--\q{1\uFE0F\u20E3}]$/v.test("2\uFE0F\u20E3"), "Emoji_Keycap_Sequence");
+	assert(!/^[\p{Emoji_Keycap_Sequence}--\q{1\uFE0F\u20E3}]$/v.test("1\uFE0F\u20E3"), "Emoji_Keycap_Sequence");
+	assert(/^\p{Basic_Emoji}$/v.test("\u{1F600}"), "Basic_Emoji");
+
+	assert.throws(SyntaxError, () => new RegExp("[a&&&b]", "v"));
+	assert.throws(SyntaxError, () => new RegExp("[a-z&&b]", "v"));
+	assert.throws(SyntaxError, () => new RegExp("[(]", "v"));
+	assert.throws(SyntaxError, () => new RegExp("[^\\q{ab}]", "v"));
+	assert.throws(SyntaxError, () => new RegExp("\\P{RGI_Emoji}", "v"));
+	assert.throws(SyntaxError, () => new RegExp("[^\\p{RGI_Emoji}]", "v"));
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestRegexpModifiers(t *testing.T) {
+	const SCRIPT = `
+	assert(/(?i:a)b/.test("Ab"), "(?i:)");
+	assert(!/(?i:a)b/.test("AB"), "(?i:) scope");
+	assert(/(?-i:a)b/i.test("aB"), "(?-i:)");
+	assert(!/(?-i:a)b/i.test("AB"), "(?-i:) scope");
+	assert(/(?s:.)/.test("\n"), "(?s:)");
+	assert(!/(?-s:.)/s.test("\n"), "(?-s:)");
+	assert(/(?-s:.)./s.test("a\n"), "(?-s:) scope");
+	assert(/(?m:^a)/.test("x\na"), "(?m:)");
+	assert(!/(?-m:^a)/m.test("x\na"), "(?-m:)");
+	assert(/(?i-:a)/.test("A"), "(?i-:)");
+	assert(/(?i:(?-i:a)b)/.test("aB"), "nested");
+	assert(!/(?i:(?-i:a)b)/.test("AB"), "nested");
+	assert(/(?i:a)(?=b)/.test("Ab"), "regexp2");
+	assert(!/(?-i:a)(?=b)/i.test("Ab"), "regexp2");
+	assert.sameValue(/(?i:(?<x>a))\k<x>/.exec("AA").groups.x, "A");
+
+	assert.throws(SyntaxError, () => new RegExp("(?ii:a)"));
+	assert.throws(SyntaxError, () => new RegExp("(?i-i:a)"));
+	assert.throws(SyntaxError, () => new RegExp("(?-:a)"));
+	assert.throws(SyntaxError, () => new RegExp("(?x:a)"));
+	assert.throws(SyntaxError, () => new RegExp("(?i)a"));
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestRegexpEscape(t *testing.T) {
+	const SCRIPT = `
+	assert.sameValue(RegExp.escape("foo.bar*baz"), "\\x66oo\\.bar\\*baz");
+	assert.sameValue(RegExp.escape("_a/(b)"), "_a\\/\\(b\\)");
+	assert.sameValue(RegExp.escape("1 a,b\n"), "\\x31\\x20a\\x2cb\\n");
+	assert.sameValue(RegExp.escape("\u2028\ufeff"), "\\u2028\\ufeff");
+	assert.sameValue(RegExp.escape("\ud800x\u{1F600}"), "\\ud800x\u{1F600}");
+	assert.sameValue(RegExp.escape(""), "");
+	assert.sameValue(RegExp.escape.length, 1);
+	assert.sameValue(RegExp.escape.name, "escape");
+	assert.throws(TypeError, () => RegExp.escape(1));
+	assert.throws(TypeError, () => RegExp.escape(new String("a")));
+
+	const s = "a.b*c[d](e)|f^$g+h?{i}\\j/k";
+	assert(new RegExp("^" + RegExp.escape(s) + "$").test(s), "round trip");
+	assert(new RegExp("^" + RegExp.escape(s) + "$", "v").test(s), "round trip (v)");
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
 func BenchmarkRegexpSplitWithBackRef(b *testing.B) {
 	const SCRIPT = `
 	"aaaaaaaaaaaaaaaaaaaaaaaaa++bbbbbbbbbbbbbbbbbbbbbb+-ccccccccccccccccccccccc".split(/([+-])\1/)
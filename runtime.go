@@ -87,14 +87,20 @@ type global struct {
 	Map     *Object
 	Set     *Object
 
-	Error          *Object
-	AggregateError *Object
-	TypeError      *Object
-	ReferenceError *Object
-	SyntaxError    *Object
-	RangeError     *Object
-	EvalError      *Object
-	URIError       *Object
+	Iterator *Object
+
+	DisposableStack      *Object
+	AsyncDisposableStack *Object
+
+	Error           *Object
+	AggregateError  *Object
+	SuppressedError *Object
+	TypeError       *Object
+	ReferenceError  *Object
+	SyntaxError     *Object
+	RangeError      *Object
+	EvalError       *Object
+	URIError        *Object
 
 	GoError *Object
 
@@ -118,18 +124,30 @@ type global struct {
 	SetPrototype         *Object
 	PromisePrototype     *Object
 
+	DisposableStackPrototype      *Object
+	AsyncDisposableStackPrototype *Object
+
 	GeneratorFunctionPrototype *Object
 	GeneratorFunction          *Object
 	GeneratorPrototype         *Object
 
 	AsyncFunctionPrototype *Object
 
+	AsyncGeneratorFunctionPrototype *Object
+	AsyncGeneratorFunction          *Object
+	AsyncGeneratorPrototype         *Object
+
 	IteratorPrototype             *Object
 	ArrayIteratorPrototype        *Object
 	MapIteratorPrototype          *Object
 	SetIteratorPrototype          *Object
 	StringIteratorPrototype       *Object
 	RegExpStringIteratorPrototype *Object
+	IteratorHelperPrototype       *Object
+	IteratorWrapPrototype         *Object
+
+	AsyncIteratorPrototype         *Object
+	AsyncFromSyncIteratorPrototype *Object
 
 	ErrorPrototype *Object
 
@@ -204,6 +222,15 @@ type Runtime struct {
 
 	promiseRejectionTracker PromiseRejectionTracker
 	asyncContextTracker     AsyncContextTracker
+
+	modules        map[*ModuleRecord]*moduleInstance
+	resolveModule  HostResolveModuleFunc
+	loadModule     HostLoadModuleFunc
+	initImportMeta func(meta *Object, m *ModuleRecord)
+
+	moduleAsyncEvaluationCount uint64
+
+	hostJobs hostJobQueue
 }
 
 type StackFrame struct {
@@ -211,8 +238,8 @@ type StackFrame struct {
 	funcName unistring.String
 	pc       int
 	// Internal: reference to the context for accessing variables
-	ctx      *context // Internal use only
-	vm       *vm      // Internal use only
+	ctx *context // Internal use only
+	vm  *vm      // Internal use only
 }
 
 func (f *StackFrame) SrcName() string {
@@ -237,10 +264,10 @@ func (f *StackFrame) GetLocalVariables() map[string]Value {
 	if f.ctx == nil || f.ctx.stash == nil {
 		return nil
 	}
-	
+
 	vars := make(map[string]Value)
 	stash := f.ctx.stash
-	
+
 	// Extract variables from the stash
 	if stash.names != nil {
 		for name, idx := range stash.names {
@@ -251,7 +278,7 @@ func (f *StackFrame) GetLocalVariables() map[string]Value {
 			}
 		}
 	}
-	
+
 	// If no names in stash, check if we're looking at the wrong stash
 	// Sometimes variables might be in an outer stash (closure)
 	if len(vars) == 0 && stash.outer != nil {
@@ -266,7 +293,7 @@ func (f *StackFrame) GetLocalVariables() map[string]Value {
 			}
 		}
 	}
-	
+
 	return vars
 }
 
@@ -275,7 +302,7 @@ func (f *StackFrame) GetArguments() []Value {
 	if f.ctx == nil || f.ctx.stash == nil {
 		return nil
 	}
-	
+
 	// Arguments are typically stored at the beginning of the stash values
 	// The number of arguments is stored in ctx.args
 	if f.ctx.args > 0 && len(f.ctx.stash.values) >= f.ctx.args {
@@ -283,7 +310,7 @@ func (f *StackFrame) GetArguments() []Value {
 		copy(args, f.ctx.stash.values[:f.ctx.args])
 		return args
 	}
-	
+
 	return nil
 }
 
@@ -292,13 +319,13 @@ func (f *StackFrame) GetThis() Value {
 	if f.vm == nil || f.ctx == nil {
 		return nil
 	}
-	
+
 	// The 'this' value is typically at stack[sb-2] for methods
 	// For regular functions, it might be undefined or the global object
 	if f.ctx.sb >= 2 && f.ctx.sb <= len(f.vm.stack) {
 		return f.vm.stack[f.ctx.sb-2]
 	}
-	
+
 	return nil
 }
 
@@ -309,6 +336,15 @@ func (f *StackFrame) Position() file.Position {
 	return f.prg.src.Position(f.prg.sourceOffset(f.pc))
 }
 
+// GeneratedPosition is like Position, but ignores the source map of the code, if there is one: the
+// position is always in the file that was run.
+func (f *StackFrame) GeneratedPosition() file.Position {
+	if f.prg == nil || f.prg.src == nil {
+		return file.Position{}
+	}
+	return f.prg.src.GeneratedPosition(f.prg.sourceOffset(f.pc))
+}
+
 func (f *StackFrame) WriteToValueBuilder(b *StringBuilder) {
 	if f.prg != nil {
 		if n := f.prg.funcName; n != "" {
@@ -498,19 +534,20 @@ func (e *Exception) Stack() []StackFrame {
 	return e.stack
 }
 
-func (r *Runtime) createIterProto(val *Object) objectImpl {
+func (r *Runtime) createAsyncIterProto(val *Object) objectImpl {
 	o := newBaseObjectObj(val, r.global.ObjectPrototype, classObject)
 
-	o._putSym(SymIterator, valueProp(r.newNativeFunc(r.returnThis, "[Symbol.iterator]", 0), true, false, true))
+	o._putSym(SymAsyncIterator, valueProp(r.newNativeFunc(r.returnThis, "[Symbol.asyncIterator]", 0), true, false, true))
+	o._putSym(SymAsyncDispose, valueProp(r.newNativeFunc(r.asyncIteratorProto_asyncDispose, "[Symbol.asyncDispose]", 0), true, false, true))
 	return o
 }
 
-func (r *Runtime) getIteratorPrototype() *Object {
+func (r *Runtime) getAsyncIteratorPrototype() *Object {
 	var o *Object
-	if o = r.global.IteratorPrototype; o == nil {
+	if o = r.global.AsyncIteratorPrototype; o == nil {
 		o = &Object{runtime: r}
-		r.global.IteratorPrototype = o
-		o.self = r.createIterProto(o)
+		r.global.AsyncIteratorPrototype = o
+		o.self = r.createAsyncIterProto(o)
 	}
 	return o
 }
@@ -656,6 +693,16 @@ func (r *Runtime) newGeneratorFunc(name unistring.String, length int, strict boo
 	return
 }
 
+func (r *Runtime) newAsyncGeneratorFunc(name unistring.String, length int, strict bool) (f *asyncGeneratorFuncObject) {
+	f = &asyncGeneratorFuncObject{}
+	r.initBaseJsFunction(&f.baseJsFuncObject, strict)
+	f.prototype = r.getAsyncGeneratorFunctionPrototype()
+	f.val.self = f
+	f.init(name, intToValue(int64(length)))
+	f._putProp("prototype", r.newBaseObject(r.getAsyncGeneratorPrototype(), classObject).val, true, false, false)
+	return
+}
+
 func (r *Runtime) newClassFunc(name unistring.String, length int, proto *Object, derived bool) (f *classFuncObject) {
 	v := &Object{runtime: r}
 
@@ -707,6 +754,16 @@ func (r *Runtime) newAsyncMethod(name unistring.String, length int, strict bool)
 	return
 }
 
+func (r *Runtime) newAsyncGeneratorMethod(name unistring.String, length int, strict bool) (f *asyncGeneratorMethodFuncObject) {
+	f = &asyncGeneratorMethodFuncObject{}
+	r.initBaseJsFunction(&f.baseJsFuncObject, strict)
+	f.prototype = r.getAsyncGeneratorFunctionPrototype()
+	f.val.self = f
+	f.init(name, intToValue(int64(length)))
+	f._putProp("prototype", r.newBaseObject(r.getAsyncGeneratorPrototype(), classObject).val, true, false, false)
+	return
+}
+
 func (r *Runtime) initArrowFunc(f *arrowFuncObject, strict bool) {
 	r.initBaseJsFunction(&f.baseJsFuncObject, strict)
 	f.newTarget = r.vm.newTarget
@@ -1025,7 +1082,7 @@ func (r *Runtime) eval(srcVal String, direct, strict bool) Value {
 
 	// Resolve any pending breakpoints if debugger is enabled
 	if r.debugger != nil {
-		r.debugger.resolvePendingBreakpoints()
+		r.debugger.resolvePendingBreakpoints(p)
 	}
 
 	vm.push(funcObj)
@@ -1411,6 +1468,13 @@ func CompileAST(prg *js_ast.Program, strict bool) (*Program, error) {
 	return compileAST(prg, strict, true, nil)
 }
 
+// CompileForDebugging is like Compile, but also records the names of the variables kept on the stack, so that
+// a Debugger can show and change them, and the statements of the code, for the Coverage. Code compiled by a
+// Runtime with a Debugger enabled or collecting the coverage records them as well.
+func CompileForDebugging(name, src string, strict bool) (*Program, error) {
+	return compileWithDebugMode(name, src, strict, true, nil, false, true)
+}
+
 // MustCompile is like Compile but panics if the code cannot be compiled.
 // It simplifies safe initialization of global variables holding compiled JavaScript code.
 func MustCompile(name, src string, strict bool) *Program {
@@ -1445,24 +1509,27 @@ func Parse(name, src string, options ...parser.Option) (prg *js_ast.Program, err
 }
 
 func compile(name, src string, strict, inGlobal bool, evalVm *vm, parserOptions ...parser.Option) (p *Program, err error) {
-	return compileWithDebugMode(name, src, strict, inGlobal, evalVm, false, parserOptions...)
+	return compileWithDebugMode(name, src, strict, inGlobal, evalVm, false, false, parserOptions...)
 }
 
-func compileWithDebugMode(name, src string, strict, inGlobal bool, evalVm *vm, debugMode bool, parserOptions ...parser.Option) (p *Program, err error) {
+func compileWithDebugMode(name, src string, strict, inGlobal bool, evalVm *vm, debugMode, debugInfo bool, parserOptions ...parser.Option) (p *Program, err error) {
 	prg, err := Parse(name, src, parserOptions...)
 	if err != nil {
 		return
 	}
 
-	return compileASTWithDebugMode(prg, strict, inGlobal, evalVm, debugMode)
+	return compileASTWithDebugMode(prg, strict, inGlobal, evalVm, debugMode, debugInfo)
 }
 
 func compileAST(prg *js_ast.Program, strict, inGlobal bool, evalVm *vm) (p *Program, err error) {
-	return compileASTWithDebugMode(prg, strict, inGlobal, evalVm, false)
+	return compileASTWithDebugMode(prg, strict, inGlobal, evalVm, false, false)
 }
 
-func compileASTWithDebugMode(prg *js_ast.Program, strict, inGlobal bool, evalVm *vm, debugMode bool) (p *Program, err error) {
+// compileASTWithDebugMode compiles the program, see compiler.debugMode. If debugInfo is set the names of the
+// variables are recorded for the debugger even if they are not forced to the stash.
+func compileASTWithDebugMode(prg *js_ast.Program, strict, inGlobal bool, evalVm *vm, debugMode, debugInfo bool) (p *Program, err error) {
 	c := newCompilerWithDebugMode(debugMode)
+	c.debugInfo = debugMode || debugInfo
 
 	defer func() {
 		if x := recover(); x != nil {
@@ -1482,7 +1549,7 @@ func compileASTWithDebugMode(prg *js_ast.Program, strict, inGlobal bool, evalVm
 }
 
 func (r *Runtime) compile(name, src string, strict, inGlobal bool, evalVm *vm) (p *Program, err error) {
-	p, err = compileWithDebugMode(name, src, strict, inGlobal, evalVm, r.debugMode, r.parserOptions...)
+	p, err = compileWithDebugMode(name, src, strict, inGlobal, evalVm, r.debugMode, r.debugger != nil || r.vm.coverage != nil, r.parserOptions...)
 	if err != nil {
 		switch x1 := err.(type) {
 		case *CompilerSyntaxError:
@@ -1578,7 +1645,7 @@ func (r *Runtime) RunProgram(p *Program) (result Value, err error) {
 
 	// Resolve any pending breakpoints if debugger is enabled
 	if r.debugger != nil {
-		r.debugger.resolvePendingBreakpoints()
+		r.debugger.resolvePendingBreakpoints(p)
 	}
 
 	ex := vm.runTry()
@@ -2917,6 +2984,165 @@ func (ir *iteratorRecord) close() {
 	ir.next = nil
 }
 
+// getAsyncIterator implements GetIterator(obj, async). An iterator of an object that is only iterable
+// synchronously is wrapped into an %AsyncFromSyncIteratorPrototype% object.
+func (r *Runtime) getAsyncIterator(obj Value) *iteratorRecord {
+	method := toMethod(r.getV(obj, SymAsyncIterator))
+	if method == nil {
+		syncMethod := toMethod(r.getV(obj, SymIterator))
+		if syncMethod == nil {
+			panic(r.NewTypeError("object is not async iterable"))
+		}
+		return r.createAsyncFromSyncIterator(r.getIterator(obj, syncMethod))
+	}
+	return r.getIterator(obj, method)
+}
+
+type asyncFromSyncIteratorObject struct {
+	baseObject
+	iter iteratorRecord
+}
+
+func (r *Runtime) createAsyncFromSyncIterator(iter *iteratorRecord) *iteratorRecord {
+	o := &Object{runtime: r}
+	it := &asyncFromSyncIteratorObject{
+		baseObject: baseObject{
+			class:      classObject,
+			val:        o,
+			extensible: true,
+			prototype:  r.getAsyncFromSyncIteratorPrototype(),
+		},
+		iter: *iter,
+	}
+	o.self = it
+	it.init()
+	return &iteratorRecord{
+		iterator: o,
+		next:     r.asyncFromSyncIteratorProto_next,
+	}
+}
+
+func (r *Runtime) toAsyncFromSyncIterator(v Value) *asyncFromSyncIteratorObject {
+	if o, ok := v.(*Object); ok {
+		if it, ok := o.self.(*asyncFromSyncIteratorObject); ok {
+			return it
+		}
+	}
+	panic(r.NewTypeError("Method called on incompatible receiver %s", r.objectproto_toString(FunctionCall{This: v})))
+}
+
+// closeSync closes the wrapped iterator. Unlike iteratorRecord.returnIter(), it can be called more than once.
+func (it *asyncFromSyncIteratorObject) closeSync() {
+	iter := it.iter
+	iter.returnIter()
+}
+
+// continuation implements AsyncFromSyncIteratorContinuation.
+func (it *asyncFromSyncIteratorObject) continuation(result *Object, promiseCap *promiseCapability, closeOnRejection bool) Value {
+	r := it.val.runtime
+	var done bool
+	var valueWrapper *Object
+	if !promiseCap.try(func() {
+		done = iteratorComplete(result)
+		value := iteratorValue(result)
+		if ex := r.vm.try(func() {
+			valueWrapper = r.promiseResolve(r.getPromise(), value)
+		}); ex != nil {
+			if !done && closeOnRejection {
+				_ = r.vm.try(it.closeSync)
+			}
+			panic(ex)
+		}
+	}) {
+		return promiseCap.promise
+	}
+	onFulfilled := r.newNativeFunc(func(call FunctionCall) Value {
+		return r.createIterResultObject(call.Argument(0), done)
+	}, "", 1)
+	var onRejected Value = _undefined
+	if !done && closeOnRejection {
+		onRejected = r.newNativeFunc(func(call FunctionCall) Value {
+			_ = r.vm.try(it.closeSync)
+			panic(call.Argument(0))
+		}, "", 1)
+	}
+	return r.performPromiseThen(valueWrapper.self.(*Promise), onFulfilled, onRejected, promiseCap)
+}
+
+func (r *Runtime) asyncFromSyncIteratorProto_next(call FunctionCall) Value {
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	var it *asyncFromSyncIteratorObject
+	var result *Object
+	if promiseCap.try(func() {
+		it = r.toAsyncFromSyncIterator(call.This)
+		if it.iter.next == nil {
+			panic(r.NewTypeError("iterator.next is missing or not a function"))
+		}
+		result = r.toObject(it.iter.next(FunctionCall{This: it.iter.iterator, Arguments: call.Arguments}))
+	}) {
+		return it.continuation(result, promiseCap, true)
+	}
+	return promiseCap.promise
+}
+
+func (r *Runtime) asyncFromSyncIteratorProto_return(call FunctionCall) Value {
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	var it *asyncFromSyncIteratorObject
+	var result *Object
+	if promiseCap.try(func() {
+		it = r.toAsyncFromSyncIterator(call.This)
+		method := toMethod(it.iter.iterator.self.getStr("return", nil))
+		if method == nil {
+			return
+		}
+		result = r.toObject(method(FunctionCall{This: it.iter.iterator, Arguments: call.Arguments}))
+	}) {
+		if result == nil {
+			promiseCap.resolve(r.createIterResultObject(call.Argument(0), true))
+			return promiseCap.promise
+		}
+		return it.continuation(result, promiseCap, false)
+	}
+	return promiseCap.promise
+}
+
+func (r *Runtime) asyncFromSyncIteratorProto_throw(call FunctionCall) Value {
+	promiseCap := r.newPromiseCapability(r.getPromise())
+	var it *asyncFromSyncIteratorObject
+	var result *Object
+	if promiseCap.try(func() {
+		it = r.toAsyncFromSyncIterator(call.This)
+		method := toMethod(it.iter.iterator.self.getStr("throw", nil))
+		if method == nil {
+			it.closeSync()
+			panic(r.NewTypeError("The iterator does not provide a 'throw' method"))
+		}
+		result = r.toObject(method(FunctionCall{This: it.iter.iterator, Arguments: call.Arguments}))
+	}) {
+		return it.continuation(result, promiseCap, true)
+	}
+	return promiseCap.promise
+}
+
+func (r *Runtime) createAsyncFromSyncIterProto(val *Object) objectImpl {
+	o := newBaseObjectObj(val, r.getAsyncIteratorPrototype(), classObject)
+
+	o._putProp("next", r.newNativeFunc(r.asyncFromSyncIteratorProto_next, "next", 1), true, false, true)
+	o._putProp("return", r.newNativeFunc(r.asyncFromSyncIteratorProto_return, "return", 1), true, false, true)
+	o._putProp("throw", r.newNativeFunc(r.asyncFromSyncIteratorProto_throw, "throw", 1), true, false, true)
+	return o
+}
+
+func (r *Runtime) getAsyncFromSyncIteratorPrototype() *Object {
+	var o *Object
+	if o = r.global.AsyncFromSyncIteratorPrototype; o == nil {
+		o = &Object{runtime: r}
+		r.global.AsyncFromSyncIteratorPrototype = o
+		o.self = r.createAsyncFromSyncIterProto(o)
+	}
+	return o
+}
+
 // ForOf is a Go equivalent of for-of loop. The function panics if an exception is thrown at any point
 // while iterating, including if the supplied value is not iterable
 // (https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Iteration_protocols#the_iterable_protocol).
@@ -2965,11 +3191,18 @@ func (r *Runtime) getHash() *maphash.Hash {
 // called when the top level function returns normally (i.e. control is passed outside the Runtime).
 func (r *Runtime) leave() {
 	var jobs []func()
-	for len(r.jobQueue) > 0 {
-		jobs, r.jobQueue = r.jobQueue, jobs[:0]
-		for _, job := range jobs {
-			job()
+	for {
+		for len(r.jobQueue) > 0 {
+			jobs, r.jobQueue = r.jobQueue, jobs[:0]
+			for _, job := range jobs {
+				job()
+			}
+		}
+		job, _, _ := r.hostJobs.take()
+		if job == nil {
+			break
 		}
+		job()
 	}
 	r.jobQueue = nil
 	r.vm.stack = nil
@@ -88,9 +88,7 @@ type _keyword struct {
 //	const
 //	class
 //	enum
-//	export
 //	extends
-//	import
 //	super
 //
 // 7.6.1.2 Future Reserved Words (strict):
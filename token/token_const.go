@@ -91,6 +91,7 @@ const (
 	IF
 	IN
 	OF
+	USING
 	DO
 
 	VAR
@@ -127,6 +128,9 @@ const (
 
 	INSTANCEOF
 
+	IMPORT
+	EXPORT
+
 	ESCAPED_RESERVED_WORD
 	// Non-reserved keywords below
 
@@ -209,6 +213,7 @@ var token2string = [...]string{
 	IF:                          "if",
 	IN:                          "in",
 	OF:                          "of",
+	USING:                       "using",
 	DO:                          "do",
 	VAR:                         "var",
 	LET:                         "let",
@@ -242,6 +247,8 @@ var token2string = [...]string{
 	CONTINUE:                    "continue",
 	DEBUGGER:                    "debugger",
 	INSTANCEOF:                  "instanceof",
+	IMPORT:                      "import",
+	EXPORT:                      "export",
 }
 
 var keywordTable = map[string]_keyword{
@@ -337,15 +344,13 @@ var keywordTable = map[string]_keyword{
 		futureKeyword: true,
 	},
 	"export": {
-		token:         KEYWORD,
-		futureKeyword: true,
+		token: EXPORT,
 	},
 	"extends": {
 		token: EXTENDS,
 	},
 	"import": {
-		token:         KEYWORD,
-		futureKeyword: true,
+		token: IMPORT,
 	},
 	"super": {
 		token: SUPER,
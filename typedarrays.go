@@ -27,8 +27,10 @@ type typedArrayObjectCtor func(buf *arrayBufferObject, offset, length int, proto
 
 type arrayBufferObject struct {
 	baseObject
-	detached bool
-	data     []byte
+	detached      bool
+	resizable     bool
+	maxByteLength int
+	data          []byte
 }
 
 // ArrayBuffer is a Go wrapper around ECMAScript ArrayBuffer. Calling Runtime.ToValue() on it
@@ -42,6 +44,9 @@ type dataViewObject struct {
 	baseObject
 	viewedArrayBuf      *arrayBufferObject
 	byteLen, byteOffset int
+	// lengthTracking is set for views created over a resizable buffer without an explicit length,
+	// their byteLen is ignored and follows the size of the buffer instead.
+	lengthTracking bool
 }
 
 type typedArray interface {
@@ -76,6 +81,9 @@ type typedArrayObject struct {
 	length, offset int
 	elemSize       int
 	typedArray     typedArray
+	// lengthTracking is set for arrays created over a resizable buffer without an explicit length,
+	// their length is ignored and follows the size of the buffer instead.
+	lengthTracking bool
 }
 
 func (a ArrayBuffer) toValue(r *Runtime) Value {
@@ -113,6 +121,33 @@ func (a ArrayBuffer) Detached() bool {
 	return a.buf.detached
 }
 
+// Transfer detaches the ArrayBuffer and returns its underlying []byte without copying it, so that the data
+// can be handed over to Go code (or to another ArrayBuffer via Runtime.NewArrayBuffer()) without any ECMAScript
+// code retaining access to it.
+// Returns nil if the ArrayBuffer is already detached.
+// Note, this method may only be called from the goroutine that 'owns' the Runtime, it may not
+// be called concurrently.
+func (a ArrayBuffer) Transfer() []byte {
+	if a.buf.detached {
+		return nil
+	}
+	data := a.buf.data
+	a.buf.detach()
+	return data
+}
+
+// Resizable returns true if the ArrayBuffer was created with a maximum byte length, i.e. it can be resized
+// with ArrayBuffer.prototype.resize().
+func (a ArrayBuffer) Resizable() bool {
+	return a.buf.resizable
+}
+
+// MaxByteLength returns the maximum length (in bytes) the ArrayBuffer can be resized to. For fixed-length
+// ArrayBuffers this is the same as the current length. For detached ArrayBuffers returns 0.
+func (a ArrayBuffer) MaxByteLength() int {
+	return a.buf.getMaxByteLength()
+}
+
 // NewArrayBuffer creates a new instance of ArrayBuffer backed by the provided byte slice.
 //
 // Warning: be careful when using unaligned slices (sub-slices that do not start at word boundaries). If later a
@@ -127,6 +162,25 @@ func (r *Runtime) NewArrayBuffer(data []byte) ArrayBuffer {
 	}
 }
 
+// NewResizableArrayBuffer creates a new instance of resizable ArrayBuffer backed by the provided byte slice
+// which can grow up to maxByteLength bytes. Panics with a RangeError if len(data) exceeds maxByteLength.
+//
+// Note, resizing the buffer within the capacity of the slice is done in place, otherwise a new slice is allocated,
+// so the result of Bytes() should not be retained across calls into ECMAScript code.
+// The same alignment considerations as for NewArrayBuffer() apply.
+func (r *Runtime) NewResizableArrayBuffer(data []byte, maxByteLength int) ArrayBuffer {
+	if len(data) > maxByteLength {
+		panic(r.newError(r.getRangeError(), "Invalid array buffer max length: %d", maxByteLength))
+	}
+	buf := r._newArrayBuffer(r.getArrayBufferPrototype(), nil)
+	buf.data = data
+	buf.resizable = true
+	buf.maxByteLength = maxByteLength
+	return ArrayBuffer{
+		buf: buf,
+	}
+}
+
 func (a *uint8Array) toRaw(v Value) uint64 {
 	return uint64(toUint8(v))
 }
@@ -764,10 +818,7 @@ func (a *bigUint64Array) exportType() reflect.Type {
 }
 
 func (a *typedArrayObject) _getIdx(idx int) Value {
-	if 0 <= idx && idx < a.length {
-		if !a.viewedArrayBuf.ensureNotDetached(false) {
-			return nil
-		}
+	if a.isValidIntegerIndex(idx) {
 		return a.typedArray.get(idx + a.offset)
 	}
 	return nil
@@ -821,13 +872,44 @@ func (a *typedArrayObject) getIdx(idx valueInt, receiver Value) Value {
 	return a._getIdx(toIntClamp(int64(idx)))
 }
 
-func (a *typedArrayObject) isValidIntegerIndex(idx int) bool {
-	if a.viewedArrayBuf.ensureNotDetached(false) {
-		if idx >= 0 && idx < a.length {
-			return true
-		}
+// isOutOfBounds returns true if the buffer is detached or has been resized so that it no longer
+// covers the array.
+func (a *typedArrayObject) isOutOfBounds() bool {
+	if a.viewedArrayBuf.detached {
+		return true
 	}
-	return false
+	bufLen := len(a.viewedArrayBuf.data)
+	start := a.offset * a.elemSize
+	if a.lengthTracking {
+		return start > bufLen
+	}
+	return start+a.length*a.elemSize > bufLen
+}
+
+// getLength returns the current length of the array, 0 if it's out of bounds.
+func (a *typedArrayObject) getLength() int {
+	if a.isOutOfBounds() {
+		return 0
+	}
+	if a.lengthTracking {
+		return (len(a.viewedArrayBuf.data) - a.offset*a.elemSize) / a.elemSize
+	}
+	return a.length
+}
+
+func (a *typedArrayObject) ensureNotOutOfBounds(throw bool) bool {
+	if !a.viewedArrayBuf.ensureNotDetached(throw) {
+		return false
+	}
+	if a.isOutOfBounds() {
+		a.val.runtime.typeErrorResult(throw, "TypedArray is out of bounds")
+		return false
+	}
+	return true
+}
+
+func (a *typedArrayObject) isValidIntegerIndex(idx int) bool {
+	return idx >= 0 && idx < a.getLength()
 }
 
 func (a *typedArrayObject) _putIdx(idx int, v Value) {
@@ -952,7 +1034,7 @@ func (a *typedArrayObject) deleteStr(name unistring.String, throw bool) bool {
 }
 
 func (a *typedArrayObject) deleteIdx(idx valueInt, throw bool) bool {
-	if a.viewedArrayBuf.ensureNotDetached(false) && idx >= 0 && int64(idx) < int64(a.length) {
+	if a.isValidIntegerIndex(toIntClamp(int64(idx))) {
 		a.val.runtime.typeErrorResult(throw, "Cannot delete property '%d' of %s", idx, a.val.String())
 		return false
 	}
@@ -961,10 +1043,11 @@ func (a *typedArrayObject) deleteIdx(idx valueInt, throw bool) bool {
 }
 
 func (a *typedArrayObject) stringKeys(all bool, accum []Value) []Value {
+	length := a.getLength()
 	if accum == nil {
-		accum = make([]Value, 0, a.length)
+		accum = make([]Value, 0, length)
 	}
-	for i := 0; i < a.length; i++ {
+	for i := 0; i < length; i++ {
 		accum = append(accum, asciiString(strconv.Itoa(i)))
 	}
 	return a.baseObject.stringKeys(all, accum)
@@ -976,7 +1059,7 @@ type typedArrayPropIter struct {
 }
 
 func (i *typedArrayPropIter) next() (propIterItem, iterNextFunc) {
-	if i.idx < i.a.length {
+	if i.idx < i.a.getLength() {
 		name := strconv.Itoa(i.idx)
 		prop := i.a._getIdx(i.idx)
 		i.idx++
@@ -1001,7 +1084,7 @@ func (a *typedArrayObject) exportToArrayOrSlice(dst reflect.Value, typ reflect.T
 }
 
 func (a *typedArrayObject) export(_ *objectExportCtx) interface{} {
-	return a.typedArray.export(a.offset, a.length)
+	return a.typedArray.export(a.offset, a.getLength())
 }
 
 func (a *typedArrayObject) exportType() reflect.Type {
@@ -1084,9 +1167,41 @@ func (r *Runtime) newBigUint64ArrayObject(buf *arrayBufferObject, offset, length
 	return r._newTypedArrayObject(buf, offset, length, 8, r.global.BigUint64Array, (*bigUint64Array)(&buf.data), proto)
 }
 
+// isOutOfBounds returns true if the buffer is detached or has been resized so that it no longer
+// covers the view.
+func (o *dataViewObject) isOutOfBounds() bool {
+	if o.viewedArrayBuf.detached {
+		return true
+	}
+	bufLen := len(o.viewedArrayBuf.data)
+	if o.lengthTracking {
+		return o.byteOffset > bufLen
+	}
+	return o.byteOffset+o.byteLen > bufLen
+}
+
+// getByteLen returns the current length of the view. Must only be called if the view is not out of bounds.
+func (o *dataViewObject) getByteLen() int {
+	if o.lengthTracking {
+		return len(o.viewedArrayBuf.data) - o.byteOffset
+	}
+	return o.byteLen
+}
+
+func (o *dataViewObject) ensureNotOutOfBounds(throw bool) bool {
+	if !o.viewedArrayBuf.ensureNotDetached(throw) {
+		return false
+	}
+	if o.isOutOfBounds() {
+		o.val.runtime.typeErrorResult(throw, "DataView is out of bounds")
+		return false
+	}
+	return true
+}
+
 func (o *dataViewObject) getIdxAndByteOrder(getIdx int, littleEndianVal Value, size int) (int, byteOrder) {
-	o.viewedArrayBuf.ensureNotDetached(true)
-	if getIdx+size > o.byteLen {
+	o.ensureNotOutOfBounds(true)
+	if getIdx+size > o.getByteLen() {
 		panic(o.val.runtime.newError(o.val.runtime.getRangeError(), "Index %d is out of bounds", getIdx))
 	}
 	getIdx += o.byteOffset
@@ -1277,6 +1392,33 @@ func (o *arrayBufferObject) detach() {
 	o.detached = true
 }
 
+func (o *arrayBufferObject) getMaxByteLength() int {
+	if o.detached {
+		return 0
+	}
+	if o.resizable {
+		return o.maxByteLength
+	}
+	return len(o.data)
+}
+
+// resize changes the length of the buffer. If the new length fits into the capacity of the current slice
+// it is re-sliced (and the newly exposed bytes are zeroed), otherwise a new slice is allocated.
+// The typed arrays viewing the buffer hold a pointer to o.data so they pick up the change automatically.
+func (o *arrayBufferObject) resize(newLen int) {
+	if newLen <= cap(o.data) {
+		oldLen := len(o.data)
+		o.data = o.data[:newLen]
+		for i := oldLen; i < newLen; i++ {
+			o.data[i] = 0
+		}
+		return
+	}
+	data := o.val.runtime.allocByteSlice(newLen)
+	copy(data, o.data)
+	o.data = data
+}
+
 func (o *arrayBufferObject) exportType() reflect.Type {
 	return arrayBufferType
 }
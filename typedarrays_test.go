@@ -542,3 +542,139 @@ func TestTypedArrayExport(t *testing.T) {
 	})
 
 }
+
+func TestArrayBufferResizable(t *testing.T) {
+	const SCRIPT = `
+	const buf = new ArrayBuffer(4, { maxByteLength: 16 });
+	assert(buf.resizable, "resizable");
+	assert.sameValue(buf.maxByteLength, 16, "maxByteLength");
+	assert(!new ArrayBuffer(4).resizable, "fixed length is not resizable");
+	assert.sameValue(new ArrayBuffer(4).maxByteLength, 4, "fixed length maxByteLength");
+	assert.throws(RangeError, () => new ArrayBuffer(4, { maxByteLength: 2 }));
+
+	const tracking = new Uint16Array(buf);
+	const fixed = new Uint8Array(buf, 0, 4);
+	const dv = new DataView(buf, 2);
+	tracking[0] = 0x1234;
+	assert.sameValue(tracking.length, 2, "tracking.length");
+
+	buf.resize(10);
+	assert.sameValue(buf.byteLength, 10, "byteLength after grow");
+	assert.sameValue(tracking.length, 5, "tracking.length after grow");
+	assert.sameValue(tracking[0], 0x1234, "data is preserved");
+	assert.sameValue(tracking[4], 0, "new data is zeroed");
+	assert.sameValue(dv.byteLength, 8, "dv.byteLength after grow");
+	dv.setUint8(7, 42);
+	assert.sameValue(new Uint8Array(buf)[9], 42, "dv.setUint8");
+
+	buf.resize(3);
+	assert.sameValue(tracking.length, 1, "tracking.length after shrink");
+	assert.sameValue(tracking[1], undefined, "element beyond the new length");
+	assert.sameValue(fixed.length, 0, "out of bounds length");
+	assert.sameValue(fixed.byteOffset, 0, "out of bounds byteOffset");
+	assert.sameValue(fixed[0], undefined, "out of bounds element");
+	assert.throws(TypeError, () => fixed.fill(0), "method on out of bounds array");
+	assert.throws(RangeError, () => dv.getUint16(0), "dv access beyond the end");
+
+	buf.resize(4);
+	assert.sameValue(fixed.length, 4, "back in bounds");
+	assert.sameValue(fixed[3], 0, "bytes are zeroed after shrink and grow");
+	assert.throws(RangeError, () => buf.resize(17));
+	assert.throws(TypeError, () => new ArrayBuffer(1).resize(1));
+
+	const sub = tracking.subarray(1);
+	buf.resize(8);
+	assert.sameValue(sub.length, 3, "subarray of a length tracking array is length tracking");
+
+	const ta = new Uint8Array(buf);
+	ta.set([1, 2, 3, 4, 5, 6, 7, 8]);
+	const res = ta.map((v, i) => {
+		if (i === 0) {
+			buf.resize(2);
+		}
+		return v;
+	});
+	assert(compareArray(res, [1, 2, 0, 0, 0, 0, 0, 0]), "map with shrink: " + res);
+	buf.resize(8);
+	ta.set([1, 2, 3, 4, 5, 6, 7, 8]);
+	assert.sameValue(ta.includes(undefined, { valueOf() { buf.resize(4); return 0; } }), true, "includes undefined after shrink");
+	assert.sameValue(ta.indexOf(4), 3, "indexOf after shrink");
+	assert(compareArray(ta.slice(), [1, 2, 3, 4]), "slice");
+	ta.copyWithin(0, { valueOf() { buf.resize(3); return 1; } });
+	assert(compareArray(ta, [2, 3, 3]), "copyWithin with shrink: " + ta);
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestArrayBufferTransfer(t *testing.T) {
+	const SCRIPT = `
+	const buf = new ArrayBuffer(4, { maxByteLength: 8 });
+	const a = new Uint8Array(buf);
+	a.set([1, 2, 3, 4]);
+
+	const buf1 = buf.transfer();
+	assert(buf.detached, "detached");
+	assert.sameValue(buf.byteLength, 0, "detached byteLength");
+	assert.sameValue(buf.maxByteLength, 0, "detached maxByteLength");
+	assert.sameValue(a.length, 0, "view of detached buffer");
+	assert.throws(TypeError, () => buf.transfer());
+	assert.throws(TypeError, () => buf.slice(0), "slice() of a detached buffer");
+	assert(!buf1.detached, "new buffer is not detached");
+	assert(buf1.resizable, "transfer() preserves resizability");
+	assert.sameValue(buf1.maxByteLength, 8, "transfer() preserves maxByteLength");
+	assert(compareArray(new Uint8Array(buf1), [1, 2, 3, 4]), "data");
+
+	const buf2 = buf1.transfer(6);
+	assert(compareArray(new Uint8Array(buf2), [1, 2, 3, 4, 0, 0]), "grow");
+	assert.throws(RangeError, () => buf2.transfer(9));
+	assert(!buf2.detached, "failed transfer doesn't detach");
+
+	const buf3 = buf2.transferToFixedLength(2);
+	assert(!buf3.resizable, "transferToFixedLength()");
+	assert(compareArray(new Uint8Array(buf3), [1, 2]), "shrink");
+	const buf4 = buf3.transfer(16);
+	assert(compareArray(new Uint8Array(buf4), [1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0]), "grow fixed length");
+	`
+	testScriptWithTestLib(SCRIPT, _undefined, t)
+}
+
+func TestArrayBufferGoTransfer(t *testing.T) {
+	vm := New()
+	data := []byte{1, 2, 3, 4}
+	vm.Set("buf", vm.NewResizableArrayBuffer(data, 8))
+	ret, err := vm.RunString(`
+	if (!buf.resizable || buf.maxByteLength !== 8) {
+		throw new Error("not resizable");
+	}
+	const a = new Uint8Array(buf);
+	a[0] = 42;
+	buf.resize(2);
+	buf;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ab := ret.Export().(ArrayBuffer)
+	if !ab.Resizable() || ab.MaxByteLength() != 8 {
+		t.Fatal("not resizable")
+	}
+	b := ab.Transfer()
+	if len(b) != 2 || b[0] != 42 || &b[0] != &data[0] {
+		t.Fatal(b)
+	}
+	if !ab.Detached() || ab.Transfer() != nil {
+		t.Fatal("not detached")
+	}
+	vm.Set("buf1", vm.NewArrayBuffer(b))
+	_, err = vm.RunString(`
+	if (a.length !== 0 || !buf.detached) {
+		throw new Error("a is still accessible");
+	}
+	if (new Uint8Array(buf1)[0] !== 42) {
+		throw new Error("buf1");
+	}
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -109,9 +109,10 @@ func (vm *vm) resume(ctx *execCtx) {
 }
 
 type iterStackItem struct {
-	val  Value
-	f    iterNextFunc
-	iter *iteratorRecord
+	val   Value
+	f     iterNextFunc
+	iter  *iteratorRecord
+	async bool // the iterator of a 'for await' loop, it's closed by the compiled code
 }
 
 type ref interface {
@@ -383,6 +384,11 @@ type vm struct {
 	curAsyncRunner *asyncRunner
 
 	profTracker *profTracker
+	profiler    atomic.Pointer[profiler] // Set while a Profiler profiles the Runtime
+	allocBytes  int64                    // Bytes allocated since the last allocation sample
+	coverage    *Coverage                // Set while collecting code coverage
+	meter       *meter                   // Set while metering the gas consumption
+	memory      *memoryAccount           // Set while accounting the memory
 }
 
 type instruction interface {
@@ -503,6 +509,9 @@ func (s *stash) getByName(name unistring.String) (v Value, exists bool) {
 	}
 	if idx, exists := s.names[name]; exists {
 		v := s.values[idx&^maskTyp]
+		if b, ok := v.(*importBinding); ok {
+			v = b.get()
+		}
 		if v == nil {
 			if idx&maskVar == 0 {
 				panic(errAccessBeforeInit)
@@ -527,6 +536,9 @@ func (s *stash) getRefByName(name unistring.String, strict bool) ref {
 		}
 	} else {
 		if idx, exists := s.names[name]; exists {
+			if b, ok := s.values[idx&^maskTyp].(*importBinding); ok {
+				return &importRef{n: name, b: b}
+			}
 			if idx&maskVar == 0 {
 				if idx&maskConst == 0 {
 					return &stashRefLex{
@@ -618,7 +630,10 @@ func (vm *vm) run() {
 	interrupted := false
 	for {
 		if count == 0 {
-			if atomic.LoadInt32(&globalProfiler.enabled) == 1 && !vm.runWithProfiler() {
+			if (atomic.LoadInt32(&globalProfiler.enabled) == 1 || vm.profiler.Load() != nil) && !vm.runWithProfiler() {
+				return
+			}
+			if (vm.coverage != nil || vm.meter != nil) && !vm.runInstrumented() {
 				return
 			}
 			count = 100
@@ -633,40 +648,10 @@ func (vm *vm) run() {
 		if pc < 0 || pc >= len(vm.prg.code) {
 			break
 		}
-		
-		// Check debugger breakpoints BEFORE updating lastSourceLine
-		if vm.r.debugger != nil {
-			// Check if we should pause
-			if vm.r.debugger.checkBreakpoint(vm) {
-				if vm.r.debugger.logger != nil {
-					vm.r.debugger.logger.Printf("VM: Pausing at PC=%d\n", pc)
-				}
-				vm.r.debugger.handlePause(vm)
-			}
-			
-			// Update lastPC before executing
-			vm.r.debugger.mu.Lock()
-			vm.r.debugger.lastPC = pc
-			vm.r.debugger.mu.Unlock()
-		}
-		
-		vm.prg.code[pc].exec(vm)
-		
-		// Update lastSourceLine AFTER executing the instruction
 		if vm.r.debugger != nil {
-			vm.r.debugger.mu.Lock()
-			if vm.prg != nil && vm.prg.srcMap != nil && pc < len(vm.prg.srcMap) {
-				item := vm.prg.srcMap[pc]
-				if item.srcPos >= 0 && vm.prg.src != nil {
-					pos := vm.prg.src.Position(item.srcPos)
-					// Only update lastSourceLine if we have a valid line
-					// Don't update to 0 as it would prevent stopping at the next valid line
-					if pos.Line > 0 {
-						vm.r.debugger.lastSourceLine = pos.Line
-					}
-				}
-			}
-			vm.r.debugger.mu.Unlock()
+			vm.execHooked(vm.prg, pc)
+		} else {
+			vm.prg.code[pc].exec(vm)
 		}
 	}
 
@@ -684,7 +669,11 @@ func (vm *vm) run() {
 func (vm *vm) runWithProfiler() bool {
 	pt := vm.profTracker
 	if pt == nil {
-		pt = globalProfiler.p.registerVm()
+		p := vm.profiler.Load()
+		if p == nil {
+			p = &globalProfiler.p
+		}
+		pt = p.registerVm()
 		vm.profTracker = pt
 		defer func() {
 			atomic.StoreInt32(&vm.profTracker.finished, 1)
@@ -701,41 +690,7 @@ func (vm *vm) runWithProfiler() bool {
 		if pc < 0 || pc >= len(vm.prg.code) {
 			break
 		}
-		
-		// Check debugger breakpoints BEFORE updating lastSourceLine
-		if vm.r.debugger != nil {
-			// Check if we should pause
-			if vm.r.debugger.checkBreakpoint(vm) {
-				if vm.r.debugger.logger != nil {
-					vm.r.debugger.logger.Printf("VM: Pausing at PC=%d\n", pc)
-				}
-				vm.r.debugger.handlePause(vm)
-			}
-			
-			// Update lastPC before executing
-			vm.r.debugger.mu.Lock()
-			vm.r.debugger.lastPC = pc
-			vm.r.debugger.mu.Unlock()
-		}
-		
-		vm.prg.code[pc].exec(vm)
-		
-		// Update lastSourceLine AFTER executing the instruction
-		if vm.r.debugger != nil {
-			vm.r.debugger.mu.Lock()
-			if vm.prg != nil && vm.prg.srcMap != nil && pc < len(vm.prg.srcMap) {
-				item := vm.prg.srcMap[pc]
-				if item.srcPos >= 0 && vm.prg.src != nil {
-					pos := vm.prg.src.Position(item.srcPos)
-					// Only update lastSourceLine if we have a valid line
-					// Don't update to 0 as it would prevent stopping at the next valid line
-					if pos.Line > 0 {
-						vm.r.debugger.lastSourceLine = pos.Line
-					}
-				}
-			}
-			vm.r.debugger.mu.Unlock()
-		}
+		vm.execHooked(vm.prg, pc)
 		req := atomic.LoadInt32(&pt.req)
 		if req == profReqStop {
 			return true
@@ -752,6 +707,77 @@ func (vm *vm) runWithProfiler() bool {
 	return false
 }
 
+// runInstrumented is run() collecting code coverage and metering the gas consumption. It returns true
+// when run() should take over again: the coverage or the metering has been stopped or started, a
+// profiler has been started or the VM has been interrupted.
+func (vm *vm) runInstrumented() bool {
+	cov, m := vm.coverage, vm.meter
+	count := 0
+	for {
+		if count == 0 {
+			if vm.coverage != cov || vm.meter != m || atomic.LoadInt32(&globalProfiler.enabled) == 1 || vm.profiler.Load() != nil {
+				return true
+			}
+			count = 100
+		} else {
+			count--
+		}
+		if atomic.LoadUint32(&vm.interrupted) != 0 {
+			return true
+		}
+
+		pc := vm.pc
+		if pc < 0 || pc >= len(vm.prg.code) {
+			break
+		}
+		vm.execHooked(vm.prg, pc)
+	}
+
+	return false
+}
+
+// execHooked executes the instruction at pc of prg with the per-instruction hooks: the debugger may pause
+// before it, its gas is charged and it's counted for the coverage.
+func (vm *vm) execHooked(prg *Program, pc int) {
+	d := vm.r.debugger
+	if d != nil {
+		if d.checkBreakpoint(vm) {
+			if d.logger != nil {
+				d.logger.Printf("VM: Pausing at PC=%d\n", pc)
+			}
+			d.handlePause(vm)
+			if vm.pc != pc || vm.prg != prg {
+				// A frame has been restarted
+				return
+			}
+		}
+		d.mu.Lock()
+		d.lastPC = pc
+		d.mu.Unlock()
+	}
+
+	if m := vm.meter; m != nil {
+		m.charge(vm, m.instructionCosts(prg)[pc])
+	}
+	var counters *coverageCounters
+	if cov := vm.coverage; cov != nil {
+		counters = cov.hit(prg, pc)
+	}
+	prg.code[pc].exec(vm)
+	if counters != nil {
+		counters.branched(pc, vm.pc)
+	}
+
+	if d != nil {
+		// Don't update lastSourceLine to 0 as it would prevent stopping at the next valid line
+		d.mu.Lock()
+		if line := sourcePosition(prg, pc).Line; line > 0 {
+			d.lastSourceLine = line
+		}
+		d.mu.Unlock()
+	}
+}
+
 func (vm *vm) Interrupt(v interface{}) {
 	vm.interruptLock.Lock()
 	vm.interruptVal = v
@@ -857,7 +883,7 @@ func (vm *vm) restoreStacks(iterLen, refLen uint32) (ex *Exception) {
 	// Restore other stacks
 	iterTail := vm.iterStack[iterLen:]
 	for i := len(iterTail) - 1; i >= 0; i-- {
-		if iter := iterTail[i].iter; iter != nil {
+		if iter := iterTail[i].iter; iter != nil && !iterTail[i].async {
 			ex1 := vm.try(func() {
 				iter.returnIter()
 			})
@@ -878,6 +904,9 @@ func (vm *vm) restoreStacks(iterLen, refLen uint32) (ex *Exception) {
 
 func (vm *vm) handleThrow(arg interface{}) *Exception {
 	ex := vm.exceptionFromValue(arg)
+	if ex != nil && vm.r.debugger != nil {
+		vm.r.debugger.checkException(vm, ex)
+	}
 	for len(vm.tryStack) > 0 {
 		tf := &vm.tryStack[len(vm.tryStack)-1]
 		if tf.catchPos == -1 && tf.finallyPos == -1 || ex == nil && tf.catchPos != tryPanicMarker {
@@ -1358,7 +1387,12 @@ func (_add) exec(vm *vm) {
 		if !isRightString {
 			rightString = right.toString()
 		}
-		ret = leftString.Concat(rightString)
+		size := stringSizeCheck{r: vm.r}
+		size.add(leftString)
+		size.add(rightString)
+		str := leftString.Concat(rightString)
+		vm.r.trackString(str)
+		ret = str
 	} else {
 		switch left := left.(type) {
 		case valueInt:
@@ -1997,6 +2031,7 @@ func (_setElem) exec(vm *vm) {
 	val := vm.stack[vm.sp-1]
 
 	obj.setOwn(propName, val, false)
+	vm.elemWritten(obj, propName)
 
 	vm.sp -= 2
 	vm.stack[vm.sp-1] = val
@@ -2070,6 +2105,7 @@ func (_setElemP) exec(vm *vm) {
 	val := vm.stack[vm.sp-1]
 
 	obj.setOwn(propName, val, false)
+	vm.elemWritten(obj, propName)
 
 	vm.sp -= 3
 	vm.pc++
@@ -2085,6 +2121,7 @@ func (_setElemStrict) exec(vm *vm) {
 	val := vm.stack[vm.sp-1]
 	if receiverObj, ok := receiver.(*Object); ok {
 		receiverObj.setOwn(propName, val, true)
+		vm.elemWritten(receiverObj, propName)
 	} else {
 		base := receiver.ToObject(vm.r)
 		base.set(propName, val, receiver, true)
@@ -2110,6 +2147,9 @@ func (_setElemRecv) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.set(propName, val, receiver, false)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.elemWritten(receiverObj, propName)
+	}
 
 	vm.sp -= 3
 	vm.stack[vm.sp-1] = val
@@ -2131,6 +2171,9 @@ func (_setElemRecvStrict) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.set(propName, val, receiver, true)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.elemWritten(receiverObj, propName)
+	}
 
 	vm.sp -= 3
 	vm.stack[vm.sp-1] = val
@@ -2147,6 +2190,7 @@ func (_setElemStrictP) exec(vm *vm) {
 	val := vm.stack[vm.sp-1]
 	if receiverObj, ok := receiver.(*Object); ok {
 		receiverObj.setOwn(propName, val, true)
+		vm.elemWritten(receiverObj, propName)
 	} else {
 		base := receiver.ToObject(vm.r)
 		base.set(propName, val, receiver, true)
@@ -2171,6 +2215,9 @@ func (_setElemRecvP) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.set(propName, val, receiver, false)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.elemWritten(receiverObj, propName)
+	}
 
 	vm.sp -= 4
 	vm.pc++
@@ -2191,6 +2238,9 @@ func (_setElemRecvStrictP) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.set(propName, val, receiver, true)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.elemWritten(receiverObj, propName)
+	}
 
 	vm.sp -= 4
 	vm.pc++
@@ -2204,6 +2254,7 @@ func (_deleteElem) exec(vm *vm) {
 	obj := vm.stack[vm.sp-2].ToObject(vm.r)
 	propName := toPropertyKey(vm.stack[vm.sp-1])
 	if obj.delete(propName, false) {
+		vm.elemWritten(obj, propName)
 		vm.stack[vm.sp-2] = valueTrue
 	} else {
 		vm.stack[vm.sp-2] = valueFalse
@@ -2220,6 +2271,7 @@ func (_deleteElemStrict) exec(vm *vm) {
 	obj := vm.stack[vm.sp-2].ToObject(vm.r)
 	propName := toPropertyKey(vm.stack[vm.sp-1])
 	obj.delete(propName, true)
+	vm.elemWritten(obj, propName)
 	vm.stack[vm.sp-2] = valueTrue
 	vm.sp--
 	vm.pc++
@@ -2230,6 +2282,7 @@ type deleteProp unistring.String
 func (d deleteProp) exec(vm *vm) {
 	obj := vm.stack[vm.sp-1].ToObject(vm.r)
 	if obj.self.deleteStr(unistring.String(d), false) {
+		vm.propWritten(obj, unistring.String(d))
 		vm.stack[vm.sp-1] = valueTrue
 	} else {
 		vm.stack[vm.sp-1] = valueFalse
@@ -2242,6 +2295,7 @@ type deletePropStrict unistring.String
 func (d deletePropStrict) exec(vm *vm) {
 	obj := vm.stack[vm.sp-1].ToObject(vm.r)
 	obj.self.deleteStr(unistring.String(d), true)
+	vm.propWritten(obj, unistring.String(d))
 	vm.stack[vm.sp-1] = valueTrue
 	vm.pc++
 }
@@ -2298,7 +2352,9 @@ type setProp unistring.String
 
 func (p setProp) exec(vm *vm) {
 	val := vm.stack[vm.sp-1]
-	vm.stack[vm.sp-2].ToObject(vm.r).self.setOwnStr(unistring.String(p), val, false)
+	obj := vm.stack[vm.sp-2].ToObject(vm.r)
+	obj.self.setOwnStr(unistring.String(p), val, false)
+	vm.propWritten(obj, unistring.String(p))
 	vm.stack[vm.sp-2] = val
 	vm.sp--
 	vm.pc++
@@ -2308,7 +2364,9 @@ type setPropP unistring.String
 
 func (p setPropP) exec(vm *vm) {
 	val := vm.stack[vm.sp-1]
-	vm.stack[vm.sp-2].ToObject(vm.r).self.setOwnStr(unistring.String(p), val, false)
+	obj := vm.stack[vm.sp-2].ToObject(vm.r)
+	obj.self.setOwnStr(unistring.String(p), val, false)
+	vm.propWritten(obj, unistring.String(p))
 	vm.sp -= 2
 	vm.pc++
 }
@@ -2321,6 +2379,7 @@ func (p setPropStrict) exec(vm *vm) {
 	propName := unistring.String(p)
 	if receiverObj, ok := receiver.(*Object); ok {
 		receiverObj.self.setOwnStr(propName, val, true)
+		vm.propWritten(receiverObj, propName)
 	} else {
 		base := receiver.ToObject(vm.r)
 		base.setStr(propName, val, receiver, true)
@@ -2344,6 +2403,9 @@ func (p setPropRecv) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.setStr(propName, val, receiver, false)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.propWritten(receiverObj, propName)
+	}
 
 	vm.stack[vm.sp-3] = val
 	vm.sp -= 2
@@ -2363,6 +2425,9 @@ func (p setPropRecvStrict) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.setStr(propName, val, receiver, true)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.propWritten(receiverObj, propName)
+	}
 
 	vm.stack[vm.sp-3] = val
 	vm.sp -= 2
@@ -2382,6 +2447,9 @@ func (p setPropRecvP) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.setStr(propName, val, receiver, false)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.propWritten(receiverObj, propName)
+	}
 
 	vm.sp -= 3
 	vm.pc++
@@ -2400,6 +2468,9 @@ func (p setPropRecvStrictP) exec(vm *vm) {
 		base := o.ToObject(vm.r)
 		base.setStr(propName, val, receiver, true)
 	}
+	if receiverObj, ok := receiver.(*Object); ok {
+		vm.propWritten(receiverObj, propName)
+	}
 
 	vm.sp -= 3
 	vm.pc++
@@ -2413,6 +2484,7 @@ func (p setPropStrictP) exec(vm *vm) {
 	propName := unistring.String(p)
 	if receiverObj, ok := receiver.(*Object); ok {
 		receiverObj.self.setOwnStr(propName, val, true)
+		vm.propWritten(receiverObj, propName)
 	} else {
 		base := receiver.ToObject(vm.r)
 		base.setStr(propName, val, receiver, true)
@@ -2914,6 +2986,7 @@ func (vm *vm) setLocalLex(s int) {
 		panic(errAccessBeforeInit)
 	}
 	*p = v
+	vm.varWritten(&stash.values, int(idx))
 	vm.pc++
 }
 
@@ -2926,9 +2999,57 @@ func (vm *vm) initLocal(s int) {
 		stash = stash.outer
 	}
 	stash.initByIdx(idx, v)
+	vm.varWritten(&stash.values, int(idx))
 	vm.pc++
 }
 
+// propWritten lets the debugger know that the code has written or deleted the property name of obj, for
+// its data breakpoints.
+func (vm *vm) propWritten(obj *Object, name unistring.String) {
+	if d := vm.r.debugger; d != nil && d.watchingData.Load() {
+		d.notePropWrite(obj, name)
+	}
+}
+
+// elemWritten is like propWritten, key is a property key (see toPropertyKey).
+func (vm *vm) elemWritten(obj *Object, key Value) {
+	if d := vm.r.debugger; d != nil && d.watchingData.Load() {
+		if _, ok := key.(*Symbol); !ok {
+			d.notePropWrite(obj, key.string())
+		}
+	}
+}
+
+// varWritten lets the debugger know that the code has written the variable at idx in the values of a stash.
+func (vm *vm) varWritten(values *[]Value, idx int) {
+	if d := vm.r.debugger; d != nil && d.watchingData.Load() {
+		d.noteVarWrite(values, idx)
+	}
+}
+
+// globalWritten lets the debugger know that the code has written the global variable name.
+func (vm *vm) globalWritten(name unistring.String) {
+	if d := vm.r.debugger; d != nil && d.watchingData.Load() {
+		d.noteGlobalWrite(name)
+	}
+}
+
+// refWritten lets the debugger know that the code has written through ref.
+func (vm *vm) refWritten(ref ref) {
+	if d := vm.r.debugger; d != nil && d.watchingData.Load() {
+		switch r := ref.(type) {
+		case *objStrRef:
+			d.notePropWrite(r.base, r.name)
+		case *objRef:
+			vm.elemWritten(r.base, r.getKey())
+		case *stashRef:
+			d.noteVarWrite(r.v, r.idx)
+		case *stashRefLex:
+			d.noteVarWrite(r.v, r.idx)
+		}
+	}
+}
+
 type storeStash uint32
 
 func (s storeStash) exec(vm *vm) {
@@ -3096,6 +3217,7 @@ type setGlobal unistring.String
 
 func (s setGlobal) exec(vm *vm) {
 	vm.r.setGlobal(unistring.String(s), vm.peek(), false)
+	vm.globalWritten(unistring.String(s))
 	vm.pc++
 }
 
@@ -3103,6 +3225,7 @@ type setGlobalStrict unistring.String
 
 func (s setGlobalStrict) exec(vm *vm) {
 	vm.r.setGlobal(unistring.String(s), vm.peek(), true)
+	vm.globalWritten(unistring.String(s))
 	vm.pc++
 }
 
@@ -3482,6 +3605,7 @@ func (_putValue) exec(vm *vm) {
 	vm.refStack[l] = nil
 	vm.refStack = vm.refStack[:l]
 	ref.set(vm.stack[vm.sp-1])
+	vm.refWritten(ref)
 	vm.pc++
 }
 
@@ -3506,6 +3630,7 @@ func (_putValueP) exec(vm *vm) {
 	vm.refStack[l] = nil
 	vm.refStack = vm.refStack[:l]
 	ref.set(vm.stack[vm.sp-1])
+	vm.refWritten(ref)
 	vm.sp--
 	vm.pc++
 }
@@ -4095,6 +4220,20 @@ func (n *newGeneratorFunc) exec(vm *vm) {
 	vm.pc++
 }
 
+type newAsyncGeneratorFunc struct {
+	newFunc
+}
+
+func (n *newAsyncGeneratorFunc) exec(vm *vm) {
+	obj := vm.r.newAsyncGeneratorFunc(n.name, n.length, n.strict)
+	obj.prg = n.prg
+	obj.stash = vm.stash
+	obj.privEnv = vm.privEnv
+	obj.src = n.source
+	vm.push(obj.val)
+	vm.pc++
+}
+
 type newMethod struct {
 	newFunc
 	homeObjOffset uint32
@@ -4134,6 +4273,15 @@ func (n *newGeneratorMethod) exec(vm *vm) {
 	n._exec(vm, &obj.methodFuncObject)
 }
 
+type newAsyncGeneratorMethod struct {
+	newMethod
+}
+
+func (n *newAsyncGeneratorMethod) exec(vm *vm) {
+	obj := vm.r.newAsyncGeneratorMethod(n.name, n.length, n.strict)
+	n._exec(vm, &obj.methodFuncObject)
+}
+
 type newArrowFunc struct {
 	newFunc
 }
@@ -4164,6 +4312,8 @@ func getHomeObject(v Value) *Object {
 			return fn.homeObject
 		case *asyncMethodFuncObject:
 			return fn.homeObject
+		case *asyncGeneratorMethodFuncObject:
+			return fn.homeObject
 		case *classFuncObject:
 			return o.runtime.toObject(fn.getStr("prototype", nil))
 		case *arrowFuncObject:
@@ -4399,6 +4549,13 @@ func (j jneP) exec(vm *vm) {
 	}
 }
 
+// jcond is jneP for the conditional operator, so that the coverage can tell it from an if.
+type jcond int32
+
+func (j jcond) exec(vm *vm) {
+	jneP(j).exec(vm)
+}
+
 type jeqP int32
 
 func (j jeqP) exec(vm *vm) {
@@ -4859,6 +5016,49 @@ func (leaveFinally) exec(vm *vm) {
 	}
 }
 
+type _newDisposeCapability struct{}
+
+// newDisposeCapability pushes the object holding the dispose capability of a block with 'using' declarations.
+var newDisposeCapability _newDisposeCapability
+
+func (_newDisposeCapability) exec(vm *vm) {
+	vm.push(vm.r.newDisposableStackObject(nil, false).val)
+	vm.pc++
+}
+
+// addDisposableResource adds the value below the dispose capability object on the stack to the capability,
+// asynchronously disposable if it's set. The capability object is popped.
+type addDisposableResource bool
+
+func (a addDisposableResource) exec(vm *vm) {
+	ds := vm.stack[vm.sp-1].(*Object).self.(*disposableStackObject)
+	ds.capability.add(vm.r, vm.stack[vm.sp-2], bool(a), nil)
+	vm.sp--
+	vm.pc++
+}
+
+// disposeResources disposes of the resources of the dispose capability object on top of the stack. It must be
+// used in a 'finally' block, the pending exception (if any) is suppressed by the errors thrown by the dispose
+// methods. If it's set, the resources are disposed asynchronously and the object is replaced by a promise to
+// await, otherwise it's popped.
+type disposeResources bool
+
+func (d disposeResources) exec(vm *vm) {
+	ds := vm.stack[vm.sp-1].(*Object).self.(*disposableStackObject)
+	tf := &vm.tryStack[len(vm.tryStack)-1]
+	if d {
+		vm.stack[vm.sp-1] = ds.capability.disposeAsync(vm.r, tf.exception)
+		vm.pc++
+		return
+	}
+	vm.sp--
+	if ex := ds.capability.dispose(vm.r, tf.exception); ex != tf.exception {
+		vm.throw(ex)
+		return
+	}
+	vm.pc++
+}
+
 type _throw struct{}
 
 var throw _throw
@@ -5204,6 +5404,112 @@ func (iterGetNextOrUndef) exec(vm *vm) {
 	vm.pc++
 }
 
+type _iterateAsyncP struct{}
+
+var iterateAsyncP _iterateAsyncP
+
+func (_iterateAsyncP) exec(vm *vm) {
+	iter := vm.r.getAsyncIterator(vm.stack[vm.sp-1])
+	vm.iterStack = append(vm.iterStack, iterStackItem{iter: iter, async: true})
+	vm.sp--
+	vm.pc++
+}
+
+// iterNextAsync calls the next() method of the 'for await' iterator. The result is awaited, then
+// checked by iterResultAsync.
+type _iterNextAsync struct{}
+
+var iterNextAsync _iterNextAsync
+
+func (_iterNextAsync) exec(vm *vm) {
+	iter := vm.iterStack[len(vm.iterStack)-1].iter
+	if iter.next == nil {
+		panic(vm.r.NewTypeError("iterator.next is missing or not a function"))
+	}
+	vm.push(iter.next(FunctionCall{This: iter.iterator}))
+	vm.pc++
+}
+
+type iterResultAsync int32
+
+func (jmp iterResultAsync) exec(vm *vm) {
+	res := vm.r.toObject(vm.stack[vm.sp-1])
+	vm.sp--
+	if iteratorComplete(res) {
+		vm.pc += int(jmp)
+		return
+	}
+	vm.iterStack[len(vm.iterStack)-1].val = iteratorValue(res)
+	vm.pc++
+}
+
+func (vm *vm) popAsyncIter() *iteratorRecord {
+	l := len(vm.iterStack) - 1
+	iter := vm.iterStack[l].iter
+	vm.iterStack[l] = iterStackItem{}
+	vm.iterStack = vm.iterStack[:l]
+	return iter
+}
+
+// iterPopCloseAsync pops the 'for await' iterator and calls its return() method. The result is awaited,
+// then checked by iterCheckReturnResult. If there is no return() method it jumps over both.
+type iterPopCloseAsync int32
+
+func (jmp iterPopCloseAsync) exec(vm *vm) {
+	iter := vm.popAsyncIter()
+	method := toMethod(iter.iterator.self.getStr("return", nil))
+	if method == nil {
+		vm.pc += int(jmp)
+		return
+	}
+	vm.push(method(FunctionCall{This: iter.iterator}))
+	vm.pc++
+}
+
+// iterPopCloseAsyncQuiet is like iterPopCloseAsync, but it's used when the loop is exited by an exception,
+// so any error is ignored (in which case it jumps the same way as if there is no return() method).
+type iterPopCloseAsyncQuiet int32
+
+func (jmp iterPopCloseAsyncQuiet) exec(vm *vm) {
+	iter := vm.popAsyncIter()
+	var res Value
+	_ = vm.try(func() {
+		if method := toMethod(iter.iterator.self.getStr("return", nil)); method != nil {
+			res = method(FunctionCall{This: iter.iterator})
+		}
+	})
+	if res == nil {
+		vm.pc += int(jmp)
+		return
+	}
+	vm.push(res)
+	vm.pc++
+}
+
+type _iterCheckReturnResult struct{}
+
+var iterCheckReturnResult _iterCheckReturnResult
+
+func (_iterCheckReturnResult) exec(vm *vm) {
+	vm.r.toObject(vm.stack[vm.sp-1])
+	vm.sp--
+	vm.pc++
+}
+
+// asyncGenResume follows a yield in an async generator. If the generator is resumed with return() it
+// unwraps the value and continues with the next instruction (which starts the return sequence),
+// otherwise it jumps over the return sequence.
+type asyncGenResume int32
+
+func (jmp asyncGenResume) exec(vm *vm) {
+	if ret, ok := vm.stack[vm.sp-1].(*asyncGenReturn); ok {
+		vm.stack[vm.sp-1] = ret.value
+		vm.pc++
+		return
+	}
+	vm.pc += int(jmp)
+}
+
 type copyStash struct{}
 
 func (copyStash) exec(vm *vm) {
@@ -5330,19 +5636,25 @@ func (n concatStrings) exec(vm *vm) {
 
 	vm.sp -= int(n) - 1
 	if allAscii {
+		vm.r.checkAlloc(memoryStrings, int64(length), 1)
 		var buf strings.Builder
 		buf.Grow(length)
 		for _, s := range strs {
 			buf.WriteString(string(s.(asciiString)))
 		}
-		vm.stack[vm.sp-1] = asciiString(buf.String())
+		str := asciiString(buf.String())
+		vm.r.trackString(str)
+		vm.stack[vm.sp-1] = str
 	} else {
+		vm.r.checkAlloc(memoryStrings, int64(length), 2)
 		var buf unicodeStringBuilder
 		buf.ensureStarted(length)
 		for _, s := range strs {
 			buf.writeString(s.(String))
 		}
-		vm.stack[vm.sp-1] = buf.String()
+		str := buf.String()
+		vm.r.trackString(str)
+		vm.stack[vm.sp-1] = str
 	}
 	vm.pc++
 }